@@ -1,48 +1,143 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/bot"
 	"github.com/rgeraskin/squad-challenge-bot/internal/config"
+	"github.com/rgeraskin/squad-challenge-bot/internal/health"
 	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/metrics"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository/postgres"
 	"github.com/rgeraskin/squad-challenge-bot/internal/repository/sqlite"
 )
 
+// store is a repository backend with versioned schema migrations
+type store interface {
+	repository.Repository
+	repository.Migrator
+}
+
+// openStore opens PostgreSQL when DATABASE_URL is set and SQLite otherwise, without migrating
+func openStore(cfg *config.Config) (store, error) {
+	if cfg.DatabaseURL != "" {
+		logger.Info("Initializing database", "backend", "postgres")
+		return postgres.Open(cfg.DatabaseURL)
+	}
+	logger.Info("Initializing database", "backend", "sqlite", "path", cfg.DatabasePath)
+	return sqlite.Open(cfg.DatabasePath)
+}
+
+// latestMigrationVersion returns the schema version this build migrates the configured backend to
+func latestMigrationVersion(cfg *config.Config) (int, error) {
+	if cfg.DatabaseURL != "" {
+		return postgres.LatestMigrationVersion()
+	}
+	return sqlite.LatestMigrationVersion()
+}
+
 func main() {
+	migrateStatus := flag.Bool("migrate-status", false, "print the schema migration status and exit")
+	migrateTo := flag.Int("migrate-to", 0, "apply migrations up to this version and exit")
+	flag.Parse()
+
 	cfg := config.Load()
 
 	// Initialize logger
 	logger.Init(cfg.LogLevel)
 
+	if *migrateStatus || *migrateTo > 0 {
+		if err := runMigrationCommand(cfg, *migrateStatus, *migrateTo); err != nil {
+			logger.Fatal("Migration command failed", "error", err)
+		}
+		return
+	}
+
 	if cfg.TelegramBotToken == "" {
 		logger.Fatal("TELEGRAM_BOT_TOKEN environment variable is required")
 	}
 
 	// Initialize database
-	logger.Info("Initializing database", "path", cfg.DatabasePath)
-	repo, err := sqlite.New(cfg.DatabasePath)
+	repo, err := openStore(cfg)
 	if err != nil {
 		logger.Fatal("Failed to initialize database", "error", err)
 	}
 	defer repo.Close()
 
+	if err := repo.Migrate(0); err != nil {
+		logger.Fatal("Failed to migrate database", "error", err)
+	}
+
+	if version, err := repo.SchemaVersion(); err == nil {
+		logger.Info("Database schema up to date", "version", version)
+	}
+	expectedVersion, err := latestMigrationVersion(cfg)
+	if err != nil {
+		logger.Fatal("Failed to load migrations", "error", err)
+	}
+
+	// Webhook mode replaces long polling when a public URL is configured
+	var webhook *bot.Webhook
+	if cfg.WebhookURL != "" {
+		certFile := ""
+		if cfg.WebhookSelfSigned {
+			certFile = cfg.TLSCertFile
+		}
+		webhook, err = bot.NewWebhook(cfg.WebhookURL, cfg.WebhookSecret, certFile)
+		if err != nil {
+			logger.Fatal("Invalid webhook configuration", "error", err)
+		}
+	}
+
 	// Initialize bot
 	logger.Info("Initializing bot")
 	if cfg.SuperAdminID > 0 {
 		logger.Info("Super admin ID configured", "telegram_id", cfg.SuperAdminID)
 	}
-	b, err := bot.New(cfg.TelegramBotToken, repo, cfg.SuperAdminID)
+	metrics.Registry.MustRegister(metrics.NewStatsCollector(repo))
+	limits := bot.RateLimits{
+		Text:      cfg.RateLimitText,
+		Callback:  cfg.RateLimitCallback,
+		Expensive: cfg.RateLimitExpensive,
+	}
+	b, err := bot.New(cfg.TelegramBotToken, metrics.InstrumentRepository(repo), cfg.SuperAdminID, cfg.TrashRetention, limits, webhook)
 	if err != nil {
 		logger.Fatal("Failed to initialize bot", "error", err)
 	}
 
-	// Start health check server if port is configured
-	if cfg.HealthPort != "" {
-		go startHealthServer(cfg.HealthPort)
+	// The webhook shares one HTTP server with the health endpoints; in polling mode the server
+	// only runs if a health port is configured. Metrics get their own listener when METRICS_LISTEN
+	// is set, and are never served on the public webhook listener
+	mux := http.NewServeMux()
+	registerHealthHandlers(mux, repo, expectedVersion, b, cfg.LivenessThreshold)
+	var server, metricsServer *http.Server
+	if cfg.MetricsListen != "" {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", metrics.Handler())
+		metricsServer = startHTTPServer(cfg.MetricsListen, metricsMux, "", "")
+	}
+	if webhook != nil {
+		if cfg.HealthPort != "" {
+			logger.Warn("HEALTH_PORT is ignored in webhook mode; health endpoints are served on WEBHOOK_LISTEN")
+		}
+		if metricsServer == nil {
+			logger.Warn("Metrics are not served in webhook mode unless METRICS_LISTEN is set")
+		}
+		mux.Handle(webhook.Path(), webhook)
+		server = startHTTPServer(cfg.WebhookListen, mux, cfg.TLSCertFile, cfg.TLSKeyFile)
+	} else if cfg.HealthPort != "" {
+		if metricsServer == nil {
+			mux.Handle("/metrics", metrics.Handler())
+		}
+		server = startHTTPServer(":"+cfg.HealthPort, mux, "", "")
 	}
 
 	// Handle graceful shutdown
@@ -52,27 +147,100 @@ func main() {
 	go func() {
 		<-quit
 		logger.Info("Shutting down...")
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		// Stop taking updates before the bot stops processing them
+		for _, s := range []*http.Server{server, metricsServer} {
+			if s == nil {
+				continue
+			}
+			if err := s.Shutdown(ctx); err != nil {
+				logger.Warn("HTTP server shutdown error", "error", err)
+			}
+		}
 		b.Stop()
 	}()
 
 	// Start bot
-	logger.Info("Bot started", "username", b.Username())
+	mode := "polling"
+	if webhook != nil {
+		mode = "webhook"
+	}
+	logger.Info("Bot started", "username", b.Username(), "mode", mode)
 	b.Start()
 }
 
-func startHealthServer(port string) {
-	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status":"ok"}`))
-	})
+// runMigrationCommand migrates the database to a target version and/or prints the migration status
+func runMigrationCommand(cfg *config.Config, status bool, target int) error {
+	repo, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	if target > 0 {
+		if err := repo.Migrate(target); err != nil {
+			return err
+		}
+		logger.Info("Database migrated", "version", target)
+	}
+
+	if !status {
+		return nil
+	}
+
+	statuses, err := repo.MigrationStatus()
+	if err != nil {
+		return err
+	}
+	for _, m := range statuses {
+		state := "pending"
+		if m.Applied {
+			state = "applied " + m.AppliedAt.UTC().Format("2006-01-02 15:04:05")
+			if m.Modified {
+				state += " (modified since)"
+			}
+		}
+		fmt.Printf("%03d  %-24s  %s\n", m.Version, m.Name, state)
+	}
+	return nil
+}
+
+// registerHealthHandlers adds the health check endpoints to mux. Liveness fails when the bot
+// hasn't heard from Telegram within threshold; readiness fails when the database is unreachable
+// or its schema isn't at expectedVersion
+func registerHealthHandlers(mux *http.ServeMux, repo store, expectedVersion int, b *bot.Bot, threshold time.Duration) {
+	mux.Handle("/health", health.Handler(map[string]health.Check{
+		"telegram": health.Activity(b.LastActivity, threshold),
+	}))
 
-	http.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status":"ready"}`))
-	})
+	mux.Handle("/ready", health.Handler(map[string]health.Check{
+		"database":   health.Database(repo.Ping),
+		"migrations": health.Migrations(repo.SchemaVersion, expectedVersion),
+	}))
+}
 
-	logger.Info("Health server started", "port", port)
-	if err := http.ListenAndServe(":"+port, nil); err != nil {
-		logger.Error("Health server error", "error", err)
+// startHTTPServer serves handler on addr in the background, over HTTPS when both TLS files are set
+func startHTTPServer(addr string, handler http.Handler, certFile, keyFile string) *http.Server {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
 	}
+
+	go func() {
+		var err error
+		if certFile != "" && keyFile != "" {
+			logger.Info("HTTPS server started", "addr", addr)
+			err = server.ListenAndServeTLS(certFile, keyFile)
+		} else {
+			logger.Info("HTTP server started", "addr", addr)
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			logger.Error("HTTP server error", "error", err)
+		}
+	}()
+
+	return server
 }
@@ -0,0 +1,42 @@
+package bot
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// activity records when the bot last heard from Telegram, for the liveness check
+type activity struct {
+	last atomic.Int64 // unix nanoseconds
+}
+
+func newActivity() *activity {
+	a := &activity{}
+	a.touch()
+	return a
+}
+
+func (a *activity) touch() {
+	a.last.Store(time.Now().UnixNano())
+}
+
+func (a *activity) time() time.Time {
+	return time.Unix(0, a.last.Load())
+}
+
+// activityTransport touches activity after every successful getUpdates call, including
+// empty ones, so a quiet bot that is still polling stays live
+type activityTransport struct {
+	next     http.RoundTripper
+	activity *activity
+}
+
+func (t *activityTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusOK && strings.HasSuffix(req.URL.Path, "/getUpdates") {
+		t.activity.touch()
+	}
+	return resp, err
+}
@@ -0,0 +1,39 @@
+package bot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestActivityTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/botTOKEN/getUpdates" && r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+
+	act := &activity{}
+	client := &http.Client{Transport: &activityTransport{next: http.DefaultTransport, activity: act}}
+	call := func(path string) {
+		t.Helper()
+		resp, err := client.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s error = %v", path, err)
+		}
+		resp.Body.Close()
+	}
+
+	call("/botTOKEN/sendMessage")
+	call("/botTOKEN/getUpdates?fail=1")
+	if !act.time().Equal(time.Unix(0, 0)) {
+		t.Errorf("activity = %v, want untouched by other methods and failed polls", act.time())
+	}
+
+	call("/botTOKEN/getUpdates")
+	if time.Since(act.time()) > time.Minute {
+		t.Errorf("activity = %v, want touched by a successful getUpdates", act.time())
+	}
+}
@@ -1,10 +1,12 @@
 package bot
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/bot/handlers"
 	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/metrics"
 	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
 	"github.com/rgeraskin/squad-challenge-bot/internal/service"
 	tele "gopkg.in/telebot.v3"
@@ -12,15 +14,31 @@ import (
 
 // Bot wraps the telebot instance and handlers
 type Bot struct {
-	bot      *tele.Bot
-	handlers *handlers.Handler
+	bot       *tele.Bot
+	handlers  *handlers.Handler
+	activity  *activity
+	trash     *service.TrashService
+	stopPurge chan struct{}
 }
 
-// New creates a new bot instance
-func New(token string, repo repository.Repository, superAdminID int64) (*Bot, error) {
+// New creates a new bot instance. Updates come from webhook when it is set and from long polling otherwise.
+// Deleted tasks and challenges can be restored for trashRetention; users going over limits get a cooldown
+func New(token string, repo repository.Repository, superAdminID int64, trashRetention time.Duration, limits RateLimits, webhook *Webhook) (*Bot, error) {
+	act := newActivity()
+	var poller tele.Poller = &tele.LongPoller{Timeout: 10 * time.Second}
+	if webhook != nil {
+		webhook.activity = act
+		poller = webhook
+	}
+
 	pref := tele.Settings{
 		Token:  token,
-		Poller: &tele.LongPoller{Timeout: 10 * time.Second},
+		Poller: poller,
+		// Same timeout as telebot's default client
+		Client: &http.Client{
+			Timeout:   time.Minute,
+			Transport: &activityTransport{next: http.DefaultTransport, activity: act},
+		},
 	}
 
 	b, err := tele.NewBot(pref)
@@ -34,9 +52,18 @@ func New(token string, repo repository.Repository, superAdminID int64) (*Bot, er
 	participantSvc := service.NewParticipantService(repo)
 	completionSvc := service.NewCompletionService(repo)
 	stateSvc := service.NewStateService(repo)
-	notifySvc := service.NewNotificationService(repo, b)
+	notifySvc := service.NewNotificationService(repo, metrics.InstrumentNotifier(b))
 	superAdminSvc := service.NewSuperAdminService(repo)
 	templateSvc := service.NewTemplateService(repo)
+	commentSvc := service.NewCommentService(repo)
+	kudosSvc := service.NewKudosService(repo)
+	nudgeSvc := service.NewNudgeService(repo)
+	achievementSvc := service.NewAchievementService(repo)
+	statsSvc := service.NewStatsService(repo)
+	exportSvc := service.NewExportService(repo)
+	portableSvc := service.NewPortableService(repo)
+	auditSvc := service.NewAuditService(repo)
+	trashSvc := service.NewTrashService(repo, trashRetention)
 
 	// Seed super admin from environment
 	if superAdminID > 0 {
@@ -58,14 +85,37 @@ func New(token string, repo repository.Repository, superAdminID int64) (*Bot, er
 		notifySvc,
 		superAdminSvc,
 		templateSvc,
+		commentSvc,
+		kudosSvc,
+		nudgeSvc,
+		achievementSvc,
+		statsSvc,
+		exportSvc,
+		portableSvc,
+		auditSvc,
+		trashSvc,
 		b,
 	)
 
 	bot := &Bot{
-		bot:      b,
-		handlers: h,
+		bot:       b,
+		handlers:  h,
+		activity:  act,
+		trash:     trashSvc,
+		stopPurge: make(chan struct{}),
 	}
 
+	limiter := newRateLimiter(limits, func(userID int64, throttled int) {
+		logger.Warn("Repeat rate limit offender", "user_id", userID, "throttled", throttled, "window", offenderWindow)
+		if err := auditSvc.RecordRateLimited(userID, throttled); err != nil {
+			logger.Error("Failed to record rate limit offender", "user_id", userID, "error", err)
+		}
+	})
+
+	// Must come before the handlers are registered to apply to them. Metrics come first to
+	// count throttled updates too
+	b.Use(metrics.Middleware(handlers.CallbackActions))
+	b.Use(limiter.middleware())
 	bot.registerHandlers()
 
 	return bot, nil
@@ -86,6 +136,9 @@ func (b *Bot) registerHandlers() {
 	b.bot.Handle(tele.OnPhoto, b.handlers.HandlePhoto)
 	logger.Debug("Registered OnPhoto handler")
 
+	b.bot.Handle(tele.OnDocument, b.handlers.HandleDocument)
+	logger.Debug("Registered OnDocument handler")
+
 	// Callback query handler
 	b.bot.Handle(tele.OnCallback, b.handlers.HandleCallback)
 	logger.Debug("Registered OnCallback handler")
@@ -95,15 +148,23 @@ func (b *Bot) registerHandlers() {
 
 // Start starts the bot
 func (b *Bot) Start() {
-	logger.Info("Bot polling started")
+	go purgeTrash(b.trash, b.stopPurge)
+
+	logger.Info("Bot update loop started")
 	b.bot.Start()
 }
 
 // Stop stops the bot
 func (b *Bot) Stop() {
+	close(b.stopPurge)
 	b.bot.Stop()
 }
 
+// LastActivity returns when updates were last fetched from or delivered by Telegram
+func (b *Bot) LastActivity() time.Time {
+	return b.activity.time()
+}
+
 // Username returns the bot's username
 func (b *Bot) Username() string {
 	return b.bot.Me.Username
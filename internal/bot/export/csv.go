@@ -0,0 +1,58 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+)
+
+// CompletionsCSV encodes one row per participant × task as CSV
+func CompletionsCSV(data *service.ChallengeExport) ([]byte, error) {
+	return writeCSV(completionsHeader, completionsTable(data))
+}
+
+// SummaryCSV encodes per-participant totals as CSV
+func SummaryCSV(data *service.ChallengeExport) ([]byte, error) {
+	return writeCSV(summaryHeader, summaryTable(data))
+}
+
+// writeCSV writes a header and rows as UTF-8 CSV with a BOM so spreadsheets detect the encoding
+func writeCSV(header []string, rows [][]any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\uFEFF")
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			if str, ok := v.(string); ok {
+				record[i] = escapeFormula(str)
+			} else {
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// escapeFormula prefixes text that spreadsheets would run as a formula with a quote, so
+// participant names and task titles like "=HYPERLINK(...)" stay plain text
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
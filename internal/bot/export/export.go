@@ -0,0 +1,74 @@
+// Package export encodes challenge completion data as CSV and XLSX documents.
+package export
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+)
+
+// TimeLayout is the timestamp format used in exported files
+const TimeLayout = "2006-01-02 15:04"
+
+var (
+	completionsHeader = []string{"Participant", "Emoji", "Telegram ID", "Task #", "Task", "Completed", "Completed At (local)"}
+	summaryHeader     = []string{"Participant", "Emoji", "Telegram ID", "Joined At (local)", "Completed", "Total", "Progress %", "Last Completed At (local)"}
+)
+
+// completionsTable returns the participant × task rows as cells
+func completionsTable(data *service.ChallengeExport) [][]any {
+	rows := make([][]any, 0, len(data.Rows))
+	for _, r := range data.Rows {
+		completed := "no"
+		if r.CompletedAt != nil {
+			completed = "yes"
+		}
+		rows = append(rows, []any{
+			r.ParticipantName,
+			r.Emoji,
+			r.TelegramID,
+			r.TaskNum,
+			r.TaskTitle,
+			completed,
+			formatTime(r.CompletedAt),
+		})
+	}
+	return rows
+}
+
+// summaryTable returns per-participant totals as cells
+func summaryTable(data *service.ChallengeExport) [][]any {
+	rows := make([][]any, 0, len(data.Summary))
+	for _, s := range data.Summary {
+		pct := 0
+		if s.TotalTasks > 0 {
+			pct = s.CompletedTasks * 100 / s.TotalTasks
+		}
+		rows = append(rows, []any{
+			s.ParticipantName,
+			s.Emoji,
+			s.TelegramID,
+			formatTime(&s.JoinedAt),
+			s.CompletedTasks,
+			s.TotalTasks,
+			pct,
+			formatTime(s.LastCompletedAt),
+		})
+	}
+	return rows
+}
+
+// formatTime formats an optional timestamp, empty if nil
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(TimeLayout)
+}
+
+// FileName returns a safe document file name for a challenge export
+func FileName(data *service.ChallengeExport, suffix, ext string) string {
+	date := time.Now().UTC().Format("2006-01-02")
+	return fmt.Sprintf("challenge-%s-%s%s.%s", data.Challenge.ID, date, suffix, ext)
+}
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+)
+
+func testExport() *service.ChallengeExport {
+	at := time.Date(2025, 3, 10, 21, 30, 0, 0, time.UTC)
+	return &service.ChallengeExport{
+		Challenge: &domain.Challenge{ID: "abc123", Name: "Test Challenge"},
+		Rows: []service.ExportRow{
+			{ParticipantName: "John", Emoji: "💪", TelegramID: 1, TaskNum: 1, TaskTitle: "Run, then <rest>", CompletedAt: &at},
+			{ParticipantName: "John", Emoji: "💪", TelegramID: 1, TaskNum: 2, TaskTitle: "Swim"},
+		},
+		Summary: []service.ExportSummary{
+			{ParticipantName: "John", Emoji: "💪", TelegramID: 1, JoinedAt: at, CompletedTasks: 1, TotalTasks: 2, LastCompletedAt: &at},
+		},
+	}
+}
+
+func TestCompletionsCSV(t *testing.T) {
+	b, err := CompletionsCSV(testExport())
+	if err != nil {
+		t.Fatalf("CompletionsCSV() error = %v", err)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(b, []byte("\uFEFF")))).ReadAll()
+	if err != nil {
+		t.Fatalf("Result is not valid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Record count = %d, want 3 (header + 2 rows)", len(records))
+	}
+	if records[1][4] != "Run, then <rest>" {
+		t.Errorf("Task title = %q, want it preserved", records[1][4])
+	}
+	if records[1][6] != "2025-03-10 21:30" {
+		t.Errorf("Completed at = %q, want %q", records[1][6], "2025-03-10 21:30")
+	}
+	if records[2][5] != "no" || records[2][6] != "" {
+		t.Errorf("Uncompleted row = %v, want no completion time", records[2])
+	}
+}
+
+func TestCompletionsCSV_Formulas(t *testing.T) {
+	data := testExport()
+	data.Rows = append(data.Rows,
+		service.ExportRow{ParticipantName: "=HYPERLINK(\"http://evil\")", TelegramID: 2, TaskNum: -1, TaskTitle: "+1"},
+		service.ExportRow{ParticipantName: "@Ann", TelegramID: 3, TaskNum: 1, TaskTitle: "-5 push-ups"},
+	)
+
+	b, err := CompletionsCSV(data)
+	if err != nil {
+		t.Fatalf("CompletionsCSV() error = %v", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(b, []byte("\uFEFF")))).ReadAll()
+	if err != nil {
+		t.Fatalf("Result is not valid CSV: %v", err)
+	}
+
+	tests := []struct {
+		got, want string
+	}{
+		{records[3][0], "'=HYPERLINK(\"http://evil\")"},
+		{records[3][3], "-1"}, // numbers are not text
+		{records[3][4], "'+1"},
+		{records[4][0], "'@Ann"},
+		{records[4][4], "'-5 push-ups"},
+		{records[1][4], "Run, then <rest>"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Cell = %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestSummaryCSV(t *testing.T) {
+	b, err := SummaryCSV(testExport())
+	if err != nil {
+		t.Fatalf("SummaryCSV() error = %v", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(b, []byte("\uFEFF")))).ReadAll()
+	if err != nil {
+		t.Fatalf("Result is not valid CSV: %v", err)
+	}
+	if len(records) != 2 || records[1][6] != "50" {
+		t.Errorf("Summary = %v, want one row at 50%%", records)
+	}
+}
+
+func TestXLSX(t *testing.T) {
+	b, err := XLSX(testExport())
+	if err != nil {
+		t.Fatalf("XLSX() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatalf("Result is not a valid zip: %v", err)
+	}
+
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "xl/workbook.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Workbook is missing %s", name)
+		}
+	}
+	if !strings.Contains(files["xl/workbook.xml"], `name="Summary"`) {
+		t.Error("Workbook should have a Summary sheet")
+	}
+	if !strings.Contains(files["xl/worksheets/sheet1.xml"], "Run, then &lt;rest&gt;") {
+		t.Error("Cell text should be XML-escaped")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 7: "H", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for i, want := range tests {
+		if got := columnName(i); got != want {
+			t.Errorf("columnName(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+)
+
+// sheet is a single worksheet of an XLSX workbook
+type sheet struct {
+	name   string
+	header []string
+	rows   [][]any
+}
+
+// XLSX encodes a challenge export as an Excel workbook with
+// a "Completions" sheet and a "Summary" sheet
+func XLSX(data *service.ChallengeExport) ([]byte, error) {
+	return writeXLSX([]sheet{
+		{name: "Completions", header: completionsHeader, rows: completionsTable(data)},
+		{name: "Summary", header: summaryHeader, rows: summaryTable(data)},
+	})
+}
+
+// writeXLSX writes a minimal SpreadsheetML package using inline strings
+func writeXLSX(sheets []sheet) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	var overrides, workbookSheets, workbookRels strings.Builder
+	for i, s := range sheets {
+		n := i + 1
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbookSheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(s.name), n, n)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xml.Header +
+			`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			overrides.String() +
+			`</Types>`},
+		{"_rels/.rels", xml.Header +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header +
+			`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + workbookSheets.String() + `</sheets>` +
+			`</workbook>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			workbookRels.String() +
+			`</Relationships>`},
+	}
+	for i, s := range sheets {
+		files = append(files, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheetXML(s)})
+	}
+
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(w, f.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sheetXML renders a worksheet; numbers become numeric cells, everything else inline strings
+func sheetXML(s sheet) string {
+	var sb strings.Builder
+	sb.WriteString(xml.Header)
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	header := make([]any, len(s.header))
+	for i, h := range s.header {
+		header[i] = h
+	}
+	all := append([][]any{header}, s.rows...)
+
+	for r, row := range all {
+		fmt.Fprintf(&sb, `<row r="%d">`, r+1)
+		for c, v := range row {
+			ref := fmt.Sprintf("%s%d", columnName(c), r+1)
+			switch v := v.(type) {
+			case int, int64:
+				fmt.Fprintf(&sb, `<c r="%s"><v>%d</v></c>`, ref, v)
+			default:
+				fmt.Fprintf(&sb, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(fmt.Sprint(v)))
+			}
+		}
+		sb.WriteString(`</row>`)
+	}
+
+	sb.WriteString(`</sheetData></worksheet>`)
+	return sb.String()
+}
+
+// columnName converts a zero-based column index to a spreadsheet column name (A, B, ..., AA)
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// xmlEscape escapes text for XML content and attributes
+func xmlEscape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/views"
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	tele "gopkg.in/telebot.v3"
+)
+
+// awardAchievements evaluates badge rules after a completion and announces new badges
+func (h *Handler) awardAchievements(challengeID string, participant *domain.Participant) {
+	awarded, err := h.achievement.Evaluate(participant.ID)
+	if err != nil {
+		logger.Warn("Failed to evaluate achievements", "participant_id", participant.ID, "error", err)
+	}
+
+	for _, badge := range awarded {
+		logger.Info("Achievement awarded",
+			"telegram_id", participant.TelegramID,
+			"challenge_id", challengeID,
+			"code", badge.Code,
+		)
+		go h.notification.NotifyAchievement(
+			challengeID,
+			participant.TelegramID,
+			participant.Emoji,
+			participant.DisplayName,
+			badge,
+		)
+	}
+}
+
+// showMyBadges shows the user's badges for the current challenge and across all challenges
+func (h *Handler) showMyBadges(c tele.Context) error {
+	userID := c.Sender().ID
+
+	userState, _ := h.state.Get(userID)
+	challengeID := userState.CurrentChallenge
+
+	challenge, err := h.challenge.GetByID(challengeID)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	earned, err := h.achievement.GetForChallenge(challengeID, userID)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+	allTime, err := h.achievement.GetAllTimeCounts(userID)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	data := views.AchievementsData{
+		ChallengeName: challenge.Name,
+		Earned:        earned,
+		AllTimeCounts: allTime,
+	}
+
+	text := views.RenderAchievements(data)
+	return c.Send(text, keyboards.BackToSettings(), tele.ModeHTML)
+}
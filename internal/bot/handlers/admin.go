@@ -46,10 +46,21 @@ func (h *Handler) showAdminPanel(c tele.Context, challengeID string) error {
 	} else {
 		msg += "<b>Mode:</b> All Visible\n"
 	}
+	if challenge.ShuffleTasks {
+		msg += "<b>Task Order:</b> Shuffled per participant\n"
+	} else {
+		msg += "<b>Task Order:</b> Same for everyone\n"
+	}
 
 	return c.Send(
 		msg,
-		keyboards.AdminPanel(challenge.DailyTaskLimit, challenge.HideFutureTasks, isObserverMode),
+		keyboards.AdminPanel(
+			challenge.DailyTaskLimit,
+			challenge.HideFutureTasks,
+			challenge.ShuffleTasks,
+			isObserverMode,
+			challenge.TemplateID != 0,
+		),
 		tele.ModeHTML,
 	)
 }
@@ -65,7 +76,7 @@ func (h *Handler) handleEditChallengeName(c tele.Context) error {
 func (h *Handler) processNewChallengeName(c tele.Context, name string) error {
 	userID := c.Sender().ID
 
-	if len(name) == 0 || len(name) > 50 {
+	if len(name) == 0 || len(name) > domain.MaxChallengeNameLength {
 		return c.Send("😅 Keep it between 1-50 characters. Try again:", keyboards.CancelOnly())
 	}
 
@@ -97,7 +108,7 @@ func (h *Handler) handleEditChallengeDescription(c tele.Context) error {
 func (h *Handler) processNewChallengeDescription(c tele.Context, description string) error {
 	userID := c.Sender().ID
 
-	if len(description) > 500 {
+	if len(description) > domain.MaxChallengeDescriptionLength {
 		return c.Send("😅 That's a bit long! Keep it under 500 characters:", keyboards.CancelOnly())
 	}
 
@@ -203,6 +214,27 @@ func (h *Handler) handleToggleHideFutureTasks(c tele.Context) error {
 	return h.showAdminPanel(c, challengeID)
 }
 
+// handleToggleShuffleTasks toggles the per-participant task order setting
+func (h *Handler) handleToggleShuffleTasks(c tele.Context) error {
+	userID := c.Sender().ID
+
+	userState, _ := h.state.Get(userID)
+	challengeID := userState.CurrentChallenge
+
+	isSuperAdmin := h.isSuperAdmin(userID)
+	newValue, err := h.challenge.ToggleShuffleTasks(challengeID, userID, isSuperAdmin)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	if newValue {
+		c.Send("✅ Everyone now gets their own shuffled task order! 🔀")
+	} else {
+		c.Send("✅ Everyone now follows the same task order! 📋")
+	}
+	return h.showAdminPanel(c, challengeID)
+}
+
 // handleDeleteChallenge shows delete challenge confirmation
 func (h *Handler) handleDeleteChallenge(c tele.Context) error {
 	userID := c.Sender().ID
@@ -215,12 +247,11 @@ func (h *Handler) handleDeleteChallenge(c tele.Context) error {
 	participantCount, _ := h.participant.CountByChallengeID(challengeID)
 
 	msg := "🚨 Whoa! Delete this challenge?\n\n"
-	msg += fmt.Sprintf("\"%s\" will be gone forever.\n\n", challenge.Name)
-	msg += "This nukes:\n"
+	msg += fmt.Sprintf("\"%s\" goes to the trash with:\n", challenge.Name)
 	msg += fmt.Sprintf("• %d tasks\n", taskCount)
 	msg += fmt.Sprintf("• %d participants\n", participantCount)
 	msg += "• All progress\n\n"
-	msg += "⚠️ No take-backs!"
+	msg += fmt.Sprintf("⚠️ Nobody can open it anymore. A super admin can restore it within %d days, after that it's gone for good.", h.trash.RetentionDays())
 
 	return c.Send(msg, keyboards.DeleteChallengeConfirm())
 }
@@ -270,7 +301,7 @@ func (h *Handler) handleConfirmDeleteChallenge(c tele.Context) error {
 		return h.sendError(c, "😅 Challenge not found. It may have already been deleted.")
 	}
 
-	// Get participants BEFORE deletion (CASCADE will remove them)
+	// Get participants before deletion, while the challenge can still be looked up
 	participantIDs := h.notification.GetParticipantsForDeletion(challengeID)
 	challengeName := challenge.Name
 
@@ -297,7 +328,7 @@ func (h *Handler) handleConfirmDeleteChallenge(c tele.Context) error {
 	)
 
 	h.state.Reset(userID)
-	c.Send("💨 Poof! Challenge deleted.")
+	c.Send("💨 Poof! Challenge moved to the trash.")
 	return h.showStartMenu(c)
 }
 
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/views"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	tele "gopkg.in/telebot.v3"
+)
+
+// showChallengeAudit shows a page of the current challenge's audit log
+func (h *Handler) showChallengeAudit(c tele.Context, pageStr string) error {
+	userID := c.Sender().ID
+	userState, _ := h.state.Get(userID)
+	if userState == nil || userState.CurrentChallenge == "" {
+		return h.sendError(c, "Challenge not found.")
+	}
+	challengeID := userState.CurrentChallenge
+
+	challenge, err := h.challenge.GetByID(challengeID)
+	if err != nil {
+		return h.sendError(c, "Challenge not found.")
+	}
+
+	page, _ := strconv.Atoi(pageStr)
+	entries, totalPages, err := h.audit.GetChallengePage(challengeID, page)
+	if err != nil {
+		logger.Error("Failed to get challenge audit log", "challenge_id", challengeID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	msg := views.RenderAuditLog(fmt.Sprintf("History of \"%s\"", challenge.Name), entries, false)
+	return c.Send(msg, keyboards.AuditLog("audit_log", page, totalPages, "back_to_admin"), tele.ModeHTML)
+}
+
+// showGlobalAudit shows a page of the audit log across all challenges and templates
+func (h *Handler) showGlobalAudit(c tele.Context, pageStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	page, _ := strconv.Atoi(pageStr)
+	entries, totalPages, err := h.audit.GetGlobalPage(page)
+	if err != nil {
+		logger.Error("Failed to get audit log", "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	msg := views.RenderAuditLog("Audit Log", entries, true)
+	return c.Send(msg, keyboards.AuditLog("sa_audit_log", page, totalPages, "back_to_super_admin"), tele.ModeHTML)
+}
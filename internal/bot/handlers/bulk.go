@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/views"
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+	tele "gopkg.in/telebot.v3"
+)
+
+// bulkAddInstructions explains the bulk-add paste format
+const bulkAddInstructions = `📑 <b>Bulk Add Tasks</b>
+
+Paste your tasks in one message, one per line. Add a description after a <code>|</code>:
+
+<code>Morning run | 5 km at an easy pace
+Drink 2 liters of water
+Read 20 pages</code>
+
+Markdown lists work too (<code>- task</code>, <code>1. task</code>); indented lines under an item become its description.`
+
+// handleBulkAddTasks starts the bulk-add flow for the current challenge
+func (h *Handler) handleBulkAddTasks(c tele.Context) error {
+	userID := c.Sender().ID
+
+	userState, _ := h.state.Get(userID)
+	count, _ := h.task.CountByChallengeID(userState.CurrentChallenge)
+	if count >= domain.MaxTasksPerChallenge {
+		return h.sendError(c, "📋 Maxed out at 50 tasks!")
+	}
+
+	h.setBulkState(userID, domain.StateAwaitingBulkTasks, "")
+	return c.Send(bulkAddInstructions, keyboards.CancelOnly(), tele.ModeHTML)
+}
+
+// processBulkTasks previews a pasted task list for the current challenge
+func (h *Handler) processBulkTasks(c tele.Context, text string) error {
+	userID := c.Sender().ID
+
+	userState, _ := h.state.Get(userID)
+	count, _ := h.task.CountByChallengeID(userState.CurrentChallenge)
+
+	result := service.ParseBulkTasks(text)
+	result.CheckLimit(count)
+
+	// Keep the text for confirmation; a new message replaces it
+	h.setBulkState(userID, domain.StateAwaitingBulkTasks, text)
+
+	return c.Send(
+		views.RenderBulkPreview(bulkPreviewData(result, count)),
+		keyboards.BulkTasksPreview(len(result.Tasks), result.Valid(), "bulk_confirm"),
+		tele.ModeHTML,
+	)
+}
+
+// handleConfirmBulkTasks creates the previewed tasks in the current challenge
+func (h *Handler) handleConfirmBulkTasks(c tele.Context) error {
+	userID := c.Sender().ID
+
+	userState, _ := h.state.Get(userID)
+	if userState.State != domain.StateAwaitingBulkTasks {
+		return h.showAdminPanel(c, userState.CurrentChallenge)
+	}
+
+	result := service.ParseBulkTasks(h.bulkText(userID))
+	if !result.Valid() {
+		return h.sendError(c, "😅 Nothing to add. Send the list again or cancel.")
+	}
+
+	tasks, err := h.task.CreateBulk(userState.CurrentChallenge, result.Tasks, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrMaxTasksReached) {
+			return h.sendError(c, "📋 That's more than 50 tasks in total. Trim the list and send it again.")
+		}
+		logger.Error("Failed to create tasks in bulk", "challenge_id", userState.CurrentChallenge, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	h.restoreIdleState(userID)
+	return c.Send(fmt.Sprintf("✅ Added %d tasks!", len(tasks)), keyboards.AddTaskDone())
+}
+
+// handleBulkAddTemplateTasks starts the bulk-add flow for a template
+func (h *Handler) handleBulkAddTemplateTasks(c tele.Context, templateIDStr string) error {
+	userID := c.Sender().ID
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+
+	count, _ := h.template.GetTaskCount(templateID)
+	if count >= domain.MaxTasksPerChallenge {
+		return h.sendError(c, "📋 Maxed out at 50 tasks!")
+	}
+
+	tempData := map[string]any{TempKeyTemplateID: templateID}
+	h.state.SetStateWithData(userID, domain.StateAwaitingTplBulkTasks, tempData)
+	return c.Send(bulkAddInstructions, keyboards.CancelOnly(), tele.ModeHTML)
+}
+
+// processTplBulkTasks previews a pasted task list for a template
+func (h *Handler) processTplBulkTasks(c tele.Context, text string) error {
+	userID := c.Sender().ID
+
+	templateID := h.bulkTemplateID(userID)
+	if templateID == 0 {
+		h.state.Reset(userID)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	count, _ := h.template.GetTaskCount(templateID)
+
+	result := service.ParseBulkTasks(text)
+	result.CheckLimit(count)
+
+	h.setBulkState(userID, domain.StateAwaitingTplBulkTasks, text)
+
+	return c.Send(
+		views.RenderBulkPreview(bulkPreviewData(result, count)),
+		keyboards.BulkTasksPreview(len(result.Tasks), result.Valid(), "sa_tpl_bulk_confirm"),
+		tele.ModeHTML,
+	)
+}
+
+// handleConfirmTplBulkTasks creates the previewed tasks in a template
+func (h *Handler) handleConfirmTplBulkTasks(c tele.Context) error {
+	userID := c.Sender().ID
+
+	userState, _ := h.state.Get(userID)
+	templateID := h.bulkTemplateID(userID)
+	if userState.State != domain.StateAwaitingTplBulkTasks || templateID == 0 {
+		return h.showTemplatesEditPanel(c)
+	}
+
+	result := service.ParseBulkTasks(h.bulkText(userID))
+	if !result.Valid() {
+		return h.sendError(c, "😅 Nothing to add. Send the list again or cancel.")
+	}
+
+	tasks, err := h.template.CreateTasksBulk(templateID, result.Tasks, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrMaxTasksReached) {
+			return h.sendError(c, "📋 That's more than 50 tasks in total. Trim the list and send it again.")
+		}
+		logger.Error("Failed to create template tasks in bulk", "template_id", templateID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	h.state.Reset(userID)
+	return c.Send(fmt.Sprintf("✅ Added %d tasks to the template!", len(tasks)), keyboards.AddTemplateTaskDone(templateID))
+}
+
+// setBulkState sets a bulk-add state, keeping existing temp data (observer mode, template ID)
+func (h *Handler) setBulkState(userID int64, state, text string) {
+	var tempData map[string]any
+	h.state.GetTempData(userID, &tempData)
+	if tempData == nil {
+		tempData = make(map[string]any)
+	}
+	if text == "" {
+		delete(tempData, TempKeyBulkText)
+	} else {
+		tempData[TempKeyBulkText] = text
+	}
+	h.state.SetStateWithData(userID, state, tempData)
+}
+
+// restoreIdleState leaves the bulk-add flow, keeping observer mode if it was on
+func (h *Handler) restoreIdleState(userID int64) {
+	isObserverMode := h.isInObserverMode(userID)
+	h.state.ResetKeepChallenge(userID)
+	if isObserverMode {
+		h.state.SetStateWithData(userID, domain.StateIdle, map[string]any{TempKeyObserverMode: true})
+	}
+}
+
+// bulkText returns the pasted task list stored in temp data
+func (h *Handler) bulkText(userID int64) string {
+	var tempData map[string]any
+	h.state.GetTempData(userID, &tempData)
+	text, _ := tempData[TempKeyBulkText].(string)
+	return text
+}
+
+// bulkTemplateID returns the template being bulk-edited from temp data
+func (h *Handler) bulkTemplateID(userID int64) int64 {
+	var tempData map[string]any
+	h.state.GetTempData(userID, &tempData)
+	if tid, ok := tempData[TempKeyTemplateID].(float64); ok {
+		return int64(tid)
+	}
+	return 0
+}
+
+// bulkPreviewData converts a parse result into view data
+func bulkPreviewData(result *service.BulkParseResult, existingCount int) views.BulkPreviewData {
+	data := views.BulkPreviewData{
+		Errors:        result.Errors,
+		ExistingCount: existingCount,
+		MaxTasks:      domain.MaxTasksPerChallenge,
+	}
+	for _, t := range result.Tasks {
+		data.Tasks = append(data.Tasks, views.BulkTaskItem{
+			Title:          t.Title,
+			HasDescription: t.Description != "",
+		})
+	}
+	return data
+}
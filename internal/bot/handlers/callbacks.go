@@ -52,6 +52,9 @@ func (h *Handler) HandleCallback(c tele.Context) error {
 		"hide_future_yes":         true,
 		"hide_future_no":          true,
 		"cancel":                  true,
+		"kudos":                   true, // sent from notifications, must not interrupt the current flow
+		"bulk_confirm":            true,
+		"sa_tpl_bulk_confirm":     true,
 		// Template flow state-dependent actions
 		"use_template":       true,
 		"from_scratch":       true,
@@ -59,6 +62,13 @@ func (h *Handler) HandleCallback(c tele.Context) error {
 		"tpl_tasks":          true,
 		"tpl_task_detail":    true,
 		"tpl_create":         true,
+		"tpl_delete":         true,
+		"tpl_del_confirm":    true,
+		"tpl_list":           true,
+		"tpl_categories":     true,
+		"tpl_search":         true,
+		"tpl_share":          true,
+		"tpl_unshare":        true,
 		"back_to_tpl_list":   true,
 		"back_to_tpl_choice": true,
 	}
@@ -88,8 +98,18 @@ func (h *Handler) HandleCallback(c tele.Context) error {
 		"edit_challenge_description": true,
 		"edit_daily_limit":           true,
 		"toggle_hide_future":         true,
+		"toggle_shuffle":             true,
 		"delete_challenge":           true,
 		"confirm_delete_challenge":   true,
+		"export_challenge":           true,
+		"import_tasks":               true,
+		"bulk_add_tasks":             true,
+		"bulk_confirm":               true,
+		"save_my_template":           true,
+		"tpl_upd_preview":            true,
+		"audit_log":                  true,
+		"task_trash":                 true,
+		"restore_task":               true,
 	}
 
 	// Handle super-admin-only actions
@@ -103,6 +123,28 @@ func (h *Handler) HandleCallback(c tele.Context) error {
 		"sa_back_to_observer":   true,
 		"back_to_super_admin":   true,
 		"back_to_sa_challenges": true,
+		"sa_export":             true,
+		"sa_tpl_import":         true,
+		"sa_tpl_export":         true,
+		"sa_tpl_category":       true,
+		"sa_tpl_set_cat":        true,
+		"sa_cat_new":            true,
+		"sa_cat_delete":         true,
+		"sa_cat_del_confirm":    true,
+		"sa_tpl_edit_tags":      true,
+		"sa_tpl_push":           true,
+		"sa_tpl_push_confirm":   true,
+		"sa_tpl_versions":       true,
+		"sa_tpl_version":        true,
+		"sa_tpl_ver_restore":    true,
+		"sa_tpl_ver_restore_ok": true,
+		"sa_audit_log":          true,
+		"sa_trash":              true,
+		"sa_restore_challenge":  true,
+		"sa_tpl_share":          true,
+		"sa_tpl_unshare":        true,
+		"sa_tpl_bulk_add":       true,
+		"sa_tpl_bulk_confirm":   true,
 		// Template management (super admin only)
 		"sa_templates_add":         true,
 		"sa_templates_edit":        true,
@@ -216,6 +258,18 @@ func (h *Handler) HandleCallback(c tele.Context) error {
 		return h.handleCompleteCurrent(c)
 	case "team_progress":
 		return h.showTeamProgress(c)
+	case "progress_card":
+		return h.handleProgressCard(c)
+	case "burnup_chart":
+		return h.handleBurnUpChart(c)
+	case "nudge":
+		if len(parts) > 1 {
+			return h.handleNudge(c, parts[1])
+		}
+	case "open_task":
+		if len(parts) > 2 {
+			return h.handleOpenTask(c, parts[1], parts[2])
+		}
 	case "list_all_tasks":
 		return h.showAllTasks(c)
 	case "share_id":
@@ -246,6 +300,26 @@ func (h *Handler) HandleCallback(c tele.Context) error {
 			return h.handleUncompleteTask(c, parts[1])
 		}
 
+	// Task comments
+	case "add_comment":
+		if len(parts) > 1 {
+			return h.handleAddComment(c, parts[1])
+		}
+	case "task_comments":
+		if len(parts) > 2 {
+			return h.showTaskComments(c, parts[1], parts[2])
+		}
+	case "delete_comment":
+		if len(parts) > 2 {
+			return h.handleDeleteComment(c, parts[1], parts[2])
+		}
+
+	// Kudos (from task completion notifications)
+	case "kudos":
+		if len(parts) > 3 {
+			return h.handleSendKudos(c, parts[1], parts[2], parts[3])
+		}
+
 	// Admin panel actions
 	case "add_task":
 		return h.handleAddTask(c)
@@ -288,6 +362,38 @@ func (h *Handler) HandleCallback(c tele.Context) error {
 		return h.handleEditDailyLimit(c)
 	case "toggle_hide_future":
 		return h.handleToggleHideFutureTasks(c)
+	case "toggle_shuffle":
+		return h.handleToggleShuffleTasks(c)
+	case "export_challenge":
+		return h.showExportFormats(c, userState.CurrentChallenge, "back_to_admin")
+	case "export":
+		if len(parts) > 2 {
+			return h.handleExport(c, parts[1], parts[2])
+		}
+	case "import_tasks":
+		return h.handleImportTasks(c)
+	case "save_my_template":
+		return h.handleSaveAsMyTemplate(c)
+	case "tpl_upd_preview":
+		return h.showTemplateUpdatePreview(c)
+	case "audit_log":
+		if len(parts) > 1 {
+			return h.showChallengeAudit(c, parts[1])
+		}
+	case "task_trash":
+		return h.showTaskTrash(c)
+	case "restore_task":
+		if len(parts) > 1 {
+			return h.handleRestoreTask(c, parts[1])
+		}
+	case "tpl_upd_apply":
+		if len(parts) > 1 {
+			return h.handleApplyTemplateUpdate(c, parts[1])
+		}
+	case "bulk_add_tasks":
+		return h.handleBulkAddTasks(c)
+	case "bulk_confirm":
+		return h.handleConfirmBulkTasks(c)
 	case "delete_challenge":
 		return h.handleDeleteChallenge(c)
 	case "confirm_delete_challenge":
@@ -327,6 +433,10 @@ func (h *Handler) HandleCallback(c tele.Context) error {
 		return h.handleConfirmLeave(c)
 	case "cancel_leave":
 		return h.showSettings(c)
+	case "my_badges":
+		return h.showMyBadges(c)
+	case "activity_heatmap":
+		return h.handleActivityHeatmap(c)
 
 	// Join flow
 	case "start_challenge":
@@ -383,6 +493,88 @@ func (h *Handler) HandleCallback(c tele.Context) error {
 		return h.showSuperAdminMenu(c)
 	case "back_to_sa_challenges":
 		return h.showAllChallengesObserver(c)
+	case "sa_export":
+		if len(parts) > 1 {
+			return h.showExportFormats(c, parts[1], "back_to_sa_challenges")
+		}
+	case "sa_tpl_import":
+		return h.handleImportTemplate(c)
+	case "sa_tpl_bulk_add":
+		if len(parts) > 1 {
+			return h.handleBulkAddTemplateTasks(c, parts[1])
+		}
+	case "sa_tpl_bulk_confirm":
+		return h.handleConfirmTplBulkTasks(c)
+	case "sa_tpl_export":
+		if len(parts) > 2 {
+			return h.handleExportTemplate(c, parts[1], parts[2])
+		}
+	case "sa_tpl_category":
+		if len(parts) > 1 {
+			return h.showTemplateCategoryPicker(c, parts[1])
+		}
+	case "sa_tpl_set_cat":
+		if len(parts) > 2 {
+			return h.handleSetTemplateCategory(c, parts[1], parts[2])
+		}
+	case "sa_cat_new":
+		if len(parts) > 1 {
+			return h.handleNewTemplateCategory(c, parts[1])
+		}
+	case "sa_cat_delete":
+		if len(parts) > 2 {
+			return h.confirmDeleteTemplateCategory(c, parts[1], parts[2])
+		}
+	case "sa_cat_del_confirm":
+		if len(parts) > 2 {
+			return h.handleDeleteTemplateCategory(c, parts[1], parts[2])
+		}
+	case "sa_tpl_edit_tags":
+		if len(parts) > 1 {
+			return h.handleEditTemplateTags(c, parts[1])
+		}
+	case "sa_tpl_push":
+		if len(parts) > 1 {
+			return h.showTemplatePushPreview(c, parts[1])
+		}
+	case "sa_tpl_push_confirm":
+		if len(parts) > 1 {
+			return h.handleTemplatePushConfirm(c, parts[1])
+		}
+	case "sa_audit_log":
+		if len(parts) > 1 {
+			return h.showGlobalAudit(c, parts[1])
+		}
+	case "sa_trash":
+		return h.showChallengeTrash(c)
+	case "sa_restore_challenge":
+		if len(parts) > 1 {
+			return h.handleRestoreChallenge(c, parts[1])
+		}
+	case "sa_tpl_versions":
+		if len(parts) > 2 {
+			return h.showTemplateVersions(c, parts[1], parts[2])
+		}
+	case "sa_tpl_version":
+		if len(parts) > 2 {
+			return h.showTemplateVersion(c, parts[1], parts[2])
+		}
+	case "sa_tpl_ver_restore":
+		if len(parts) > 2 {
+			return h.confirmRestoreTemplateVersion(c, parts[1], parts[2])
+		}
+	case "sa_tpl_ver_restore_ok":
+		if len(parts) > 1 {
+			return h.handleRestoreTemplateVersion(c, parts[1])
+		}
+	case "sa_tpl_share":
+		if len(parts) > 1 {
+			return h.handleShareTemplate(c, parts[1], true)
+		}
+	case "sa_tpl_unshare":
+		if len(parts) > 1 {
+			return h.handleRevokeTemplateShare(c, parts[1], true)
+		}
 
 	// Super Admin Template actions
 	case "sa_templates_add":
@@ -495,24 +687,50 @@ func (h *Handler) HandleCallback(c tele.Context) error {
 		return h.handleFromScratch(c)
 	case "tpl_select":
 		if len(parts) > 1 {
-			return h.showTemplateDetails(c, parts[1])
+			return h.showTemplateDetails(c, parts[1], callbackArg(parts, 2))
 		}
 	case "tpl_tasks":
 		if len(parts) > 1 {
-			return h.showTemplateTasksList(c, parts[1])
+			return h.showTemplateTasksList(c, parts[1], callbackArg(parts, 2))
 		}
 	case "tpl_task_detail":
 		if len(parts) > 2 {
-			return h.showTplTaskDetail(c, parts[1], parts[2])
+			return h.showTplTaskDetail(c, parts[1], parts[2], callbackArg(parts, 3))
 		}
 	case "tpl_create":
 		if len(parts) > 1 {
-			return h.handleCreateFromTemplate(c, parts[1])
+			return h.handleCreateFromTemplate(c, parts[1], callbackArg(parts, 2))
+		}
+	case "tpl_delete":
+		if len(parts) > 1 {
+			return h.confirmDeletePersonalTemplate(c, parts[1])
+		}
+	case "tpl_del_confirm":
+		if len(parts) > 1 {
+			return h.handleDeletePersonalTemplate(c, parts[1])
+		}
+	case "tpl_share":
+		if len(parts) > 1 {
+			return h.handleShareTemplate(c, parts[1], false)
+		}
+	case "tpl_unshare":
+		if len(parts) > 1 {
+			return h.handleRevokeTemplateShare(c, parts[1], false)
 		}
 	case "back_to_tpl_choice":
 		return h.showTemplateOrScratchChoice(c)
 	case "back_to_tpl_list":
-		return h.showTemplatesList(c)
+		return h.showTemplateCatalog(c, h.lastTemplateCatalogView(userID))
+	case "tpl_list":
+		return h.showTemplateCatalog(c, parseTemplateCatalogView(parts[1:]))
+	case "tpl_categories":
+		if len(parts) > 1 {
+			return h.showTemplateCategories(c, parts[1])
+		}
+	case "tpl_search":
+		if len(parts) > 1 {
+			return h.handleTemplateSearch(c, parts[1])
+		}
 	case "skip_template_sync_time":
 		return h.skipTemplateSyncTime(c)
 
@@ -555,7 +773,8 @@ func (h *Handler) handleCancel(c tele.Context) error {
 	userState, _ := h.state.Get(userID)
 
 	// Check if we're in super admin flow
-	if userState.State == domain.StateAwaitingSuperAdminID {
+	if userState.State == domain.StateAwaitingSuperAdminID ||
+		userState.State == domain.StateAwaitingImportTemplate {
 		h.state.Reset(userID)
 		return h.showSuperAdminMenu(c)
 	}
@@ -651,6 +870,12 @@ func (h *Handler) handleCancel(c tele.Context) error {
 				return h.handleEditTask(c, fmt.Sprintf("%d", taskID))
 			}
 			return h.handleEditTasks(c)
+		case domain.StateAwaitingTaskComment:
+			// Return to the task being commented on
+			if taskID > 0 {
+				return h.showTaskDetail(c, fmt.Sprintf("%d", taskID))
+			}
+			return h.showMainChallengeView(c, userState.CurrentChallenge)
 		case domain.StateAwaitingTaskTitle,
 			domain.StateAwaitingTaskImage,
 			domain.StateAwaitingTaskDescription,
@@ -658,7 +883,9 @@ func (h *Handler) handleCancel(c tele.Context) error {
 			domain.StateReorderSelectPosition,
 			domain.StateAwaitingNewChallengeName,
 			domain.StateAwaitingNewChallengeDescription,
-			domain.StateAwaitingNewDailyLimit:
+			domain.StateAwaitingNewDailyLimit,
+			domain.StateAwaitingImportTasks,
+			domain.StateAwaitingBulkTasks:
 			return h.showAdminPanel(c, userState.CurrentChallenge)
 		case domain.StateAwaitingNewName,
 			domain.StateAwaitingNewEmoji,
@@ -695,3 +922,167 @@ func (h *Handler) handleSkip(c tele.Context) error {
 
 	return nil
 }
+
+// callbackArg returns the optional callback parameter at index i, or "" when it is missing
+func callbackArg(parts []string, i int) string {
+	if len(parts) > i {
+		return parts[i]
+	}
+	return ""
+}
+
+// CallbackActions lists every action HandleCallback dispatches, in the order of its switch.
+// TestCallbackActions keeps the two in sync
+var CallbackActions = []string{
+	"create_challenge",
+	"join_challenge",
+	"open_challenge",
+	"back_to_main",
+	"back_to_admin",
+	"back_to_tasks",
+	"exit_challenge",
+	"complete_current",
+	"team_progress",
+	"progress_card",
+	"burnup_chart",
+	"nudge",
+	"open_task",
+	"list_all_tasks",
+	"share_id",
+	"settings",
+	"admin_panel",
+	"task_detail",
+	"complete_task",
+	"uncomplete_task",
+	"add_comment",
+	"task_comments",
+	"delete_comment",
+	"kudos",
+	"add_task",
+	"edit_tasks",
+	"edit_task",
+	"edit_task_title",
+	"edit_task_description",
+	"edit_task_image",
+	"delete_task",
+	"confirm_delete_task",
+	"cancel_delete_task",
+	"edit_challenge_name",
+	"edit_challenge_description",
+	"edit_daily_limit",
+	"toggle_hide_future",
+	"toggle_shuffle",
+	"export_challenge",
+	"export",
+	"import_tasks",
+	"save_my_template",
+	"tpl_upd_preview",
+	"audit_log",
+	"task_trash",
+	"restore_task",
+	"tpl_upd_apply",
+	"bulk_add_tasks",
+	"bulk_confirm",
+	"delete_challenge",
+	"confirm_delete_challenge",
+	"cancel_delete_challenge",
+	"reorder_tasks",
+	"reorder_select",
+	"reorder_move",
+	"reorder_cancel",
+	"randomize_tasks",
+	"toggle_notifications",
+	"change_name",
+	"change_emoji",
+	"sync_time",
+	"leave_challenge",
+	"confirm_leave",
+	"cancel_leave",
+	"my_badges",
+	"activity_heatmap",
+	"start_challenge",
+	"select_emoji",
+	"cancel",
+	"skip",
+	"skip_daily_limit",
+	"hide_future_yes",
+	"hide_future_no",
+	"skip_creator_sync_time",
+	"skip_sync_time",
+	"skip_name",
+	"super_admin_menu",
+	"sa_all_challenges",
+	"sa_observe",
+	"sa_grant",
+	"sa_manage",
+	"sa_revoke",
+	"sa_back_to_observer",
+	"back_to_super_admin",
+	"back_to_sa_challenges",
+	"sa_export",
+	"sa_tpl_import",
+	"sa_tpl_bulk_add",
+	"sa_tpl_bulk_confirm",
+	"sa_tpl_export",
+	"sa_tpl_category",
+	"sa_tpl_set_cat",
+	"sa_cat_new",
+	"sa_cat_delete",
+	"sa_cat_del_confirm",
+	"sa_tpl_edit_tags",
+	"sa_tpl_push",
+	"sa_tpl_push_confirm",
+	"sa_audit_log",
+	"sa_trash",
+	"sa_restore_challenge",
+	"sa_tpl_versions",
+	"sa_tpl_version",
+	"sa_tpl_ver_restore",
+	"sa_tpl_ver_restore_ok",
+	"sa_tpl_share",
+	"sa_tpl_unshare",
+	"sa_templates_add",
+	"sa_templates_edit",
+	"sa_tpl_select",
+	"sa_tpl_tasks",
+	"sa_tpl_create",
+	"sa_tpl_task_detail",
+	"sa_tpl_del_select",
+	"sa_tpl_del_confirm",
+	"back_to_sa_tpl_add",
+	"back_to_sa_tpl_edit",
+	"sa_tpl_edit_select",
+	"sa_tpl_add_task",
+	"sa_tpl_edit_tasks",
+	"sa_tpl_edit_task",
+	"sa_tpl_edit_name",
+	"sa_tpl_edit_desc",
+	"sa_tpl_edit_limit",
+	"sa_tpl_toggle_hide",
+	"sa_tpl_task_title",
+	"sa_tpl_task_desc",
+	"sa_tpl_task_image",
+	"sa_tpl_task_delete",
+	"sa_tpl_task_del_confirm",
+	"sa_tpl_reorder",
+	"sa_tpl_reorder_select",
+	"sa_tpl_reorder_move",
+	"sa_tpl_randomize",
+	"use_template",
+	"from_scratch",
+	"tpl_select",
+	"tpl_tasks",
+	"tpl_task_detail",
+	"tpl_create",
+	"tpl_delete",
+	"tpl_del_confirm",
+	"tpl_share",
+	"tpl_unshare",
+	"back_to_tpl_choice",
+	"back_to_tpl_list",
+	"tpl_list",
+	"tpl_categories",
+	"tpl_search",
+	"skip_template_sync_time",
+	"noop",
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/images"
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	tele "gopkg.in/telebot.v3"
+)
+
+// handleProgressCard sends the current user's progress card on demand
+func (h *Handler) handleProgressCard(c tele.Context) error {
+	userID := c.Sender().ID
+
+	userState, _ := h.state.Get(userID)
+	challengeID := userState.CurrentChallenge
+
+	participant, err := h.participant.GetByChallengeAndUser(challengeID, userID)
+	if err != nil || participant == nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	if err := h.sendProgressCard(c, challengeID, participant); err != nil {
+		logger.Error("Failed to send progress card", "participant_id", participant.ID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+	return nil
+}
+
+// sendProgressCard renders and sends a participant's progress card as a photo
+func (h *Handler) sendProgressCard(c tele.Context, challengeID string, participant *domain.Participant) error {
+	challenge, err := h.challenge.GetByID(challengeID)
+	if err != nil {
+		return err
+	}
+
+	tasks, _ := h.task.GetByChallengeID(challengeID)
+	participants, _ := h.participant.GetByChallengeID(challengeID)
+	completed, _ := h.completion.CountByParticipantID(participant.ID)
+
+	// Rank is 1 + number of teammates strictly ahead, so ties share a rank
+	rank := 1
+	for _, p := range participants {
+		if p.ID == participant.ID {
+			continue
+		}
+		if n, _ := h.completion.CountByParticipantID(p.ID); n > completed {
+			rank++
+		}
+	}
+
+	// A finished participant's time stops at their last completion
+	var finishedAt time.Time
+	if len(tasks) > 0 && completed >= len(tasks) {
+		completions, err := h.completion.GetByParticipantID(participant.ID)
+		if err != nil {
+			return err
+		}
+		if len(completions) > 0 {
+			finishedAt = completions[len(completions)-1].CompletedAt
+		}
+	}
+
+	png, err := images.RenderProgressCard(images.ProgressCardData{
+		ChallengeName:  challenge.Name,
+		Emoji:          participant.Emoji,
+		Name:           participant.DisplayName,
+		CompletedTasks: completed,
+		TotalTasks:     len(tasks),
+		JoinedAt:       participant.JoinedAt,
+		FinishedAt:     finishedAt,
+		Rank:           rank,
+		SquadSize:      len(participants),
+	})
+	if err != nil {
+		return err
+	}
+
+	photo := &tele.Photo{
+		File:    tele.FromReader(bytes.NewReader(png)),
+		Caption: fmt.Sprintf("%s %s — <b>%d/%d</b> tasks in <b>%s</b>", participant.Emoji, html.EscapeString(participant.DisplayName), completed, len(tasks), html.EscapeString(challenge.Name)),
+	}
+	return c.Send(photo, tele.ModeHTML)
+}
@@ -50,19 +50,13 @@ func (h *Handler) showMainChallengeView(c tele.Context, challengeID string) erro
 	// Calculate current task for each participant
 	participantEmojis := make(map[int64][]string)
 	for _, p := range participants {
-		currentTaskNum := h.completion.GetCurrentTaskNum(p.ID, tasks)
-		if currentTaskNum > 0 {
-			// Find task ID for this order number
-			for _, t := range tasks {
-				if t.OrderNum == currentTaskNum {
-					participantEmojis[t.ID] = append(participantEmojis[t.ID], p.Emoji)
-					break
-				}
-			}
+		if t := h.currentTask(challenge, p.ID, tasks); t != nil {
+			participantEmojis[t.ID] = append(participantEmojis[t.ID], p.Emoji)
 		}
 	}
 
-	// Build view data
+	// Build view data, numbering tasks in this participant's own order
+	tasks = service.ParticipantTaskOrder(tasks, challenge, participant.ID)
 	currentTaskNum := h.completion.GetCurrentTaskNum(participant.ID, tasks)
 
 	data := views.TaskListData{
@@ -127,7 +121,7 @@ func (h *Handler) handleCreateChallenge(c tele.Context) error {
 	}
 
 	// Check if any templates exist
-	templateCount, _ := h.template.Count()
+	templateCount, _ := h.template.CountAvailable(userID)
 	if templateCount > 0 {
 		// Show template or scratch choice
 		logger.Debug(
@@ -180,7 +174,7 @@ func (h *Handler) handleJoinChallenge(c tele.Context) error {
 func (h *Handler) processChallengeName(c tele.Context, name string) error {
 	userID := c.Sender().ID
 
-	if len(name) == 0 || len(name) > 50 {
+	if len(name) == 0 || len(name) > domain.MaxChallengeNameLength {
 		return c.Send("😬 Keep it between 1-50 characters, please!", keyboards.CancelOnly())
 	}
 
@@ -196,7 +190,7 @@ func (h *Handler) processChallengeName(c tele.Context, name string) error {
 func (h *Handler) processChallengeDescription(c tele.Context, description string) error {
 	userID := c.Sender().ID
 
-	if len(description) > 500 {
+	if len(description) > domain.MaxChallengeDescriptionLength {
 		return c.Send("😬 That's a bit long! Keep it under 500 characters.", keyboards.SkipCancel())
 	}
 
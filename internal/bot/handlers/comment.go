@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/views"
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+	tele "gopkg.in/telebot.v3"
+)
+
+// handleAddComment starts the add comment flow for a task
+func (h *Handler) handleAddComment(c tele.Context, taskIDStr string) error {
+	userID := c.Sender().ID
+	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	userState, _ := h.state.Get(userID)
+	participant, err := h.participant.GetByChallengeAndUser(userState.CurrentChallenge, userID)
+	if err != nil || participant == nil {
+		return h.sendError(c, "😕 You're not in this challenge.")
+	}
+
+	tempData := map[string]any{
+		TempKeyTaskID: taskID,
+	}
+	h.state.SetStateWithData(userID, domain.StateAwaitingTaskComment, tempData)
+
+	return c.Send(
+		fmt.Sprintf("💬 What would you like to say? (up to %d characters)", domain.MaxCommentLength),
+		keyboards.CancelOnly(),
+	)
+}
+
+// processTaskComment processes comment text input
+func (h *Handler) processTaskComment(c tele.Context, text string) error {
+	userID := c.Sender().ID
+
+	var tempData map[string]any
+	h.state.GetTempData(userID, &tempData)
+	taskID := int64(tempData[TempKeyTaskID].(float64))
+
+	userState, _ := h.state.Get(userID)
+	challengeID := userState.CurrentChallenge
+
+	participant, err := h.participant.GetByChallengeAndUser(challengeID, userID)
+	if err != nil || participant == nil {
+		h.state.ResetKeepChallenge(userID)
+		return h.sendError(c, "😕 You're not in this challenge.")
+	}
+
+	task, err := h.task.GetByID(taskID)
+	if err != nil || task.ChallengeID != challengeID {
+		h.state.ResetKeepChallenge(userID)
+		return h.sendError(c, "🤔 Can't find that task.")
+	}
+
+	if _, err := h.comment.Add(taskID, participant.ID, text); err != nil {
+		switch err {
+		case service.ErrEmptyComment, service.ErrCommentTooLong:
+			return c.Send(
+				fmt.Sprintf("😅 Keep it between 1-%d characters:", domain.MaxCommentLength),
+				keyboards.CancelOnly(),
+			)
+		}
+		h.state.ResetKeepChallenge(userID)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	h.state.ResetKeepChallenge(userID)
+
+	// Let teammates who already finished this task know about the new comment
+	go h.notification.NotifyTaskComment(
+		taskID,
+		participant.Emoji,
+		participant.DisplayName,
+		task.Title,
+		text,
+		userID,
+	)
+
+	c.Send("✅ Comment posted!")
+	return h.showTaskDetail(c, fmt.Sprintf("%d", taskID))
+}
+
+// showTaskComments shows a page of comments for a task
+func (h *Handler) showTaskComments(c tele.Context, taskIDStr, pageStr string) error {
+	userID := c.Sender().ID
+	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+	page, _ := strconv.Atoi(pageStr)
+
+	userState, _ := h.state.Get(userID)
+	challengeID := userState.CurrentChallenge
+
+	task, err := h.task.GetByID(taskID)
+	if err != nil || task.ChallengeID != challengeID {
+		return h.sendError(c, "🤔 Can't find that task.")
+	}
+
+	participant, _ := h.participant.GetByChallengeAndUser(challengeID, userID)
+	if participant == nil && !h.isInObserverMode(userID) {
+		return h.sendError(c, "😕 You're not in this challenge.")
+	}
+
+	comments, totalPages, err := h.comment.GetPage(taskID, page)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	challenge, err := h.challenge.GetByID(challengeID)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+	canDelete := challenge.CreatorID == userID || h.isSuperAdmin(userID)
+
+	offset := 0
+	if participant != nil {
+		offset = participant.TimeOffsetMinutes
+
+		// Number the task in this participant's own order
+		tasks, _ := h.task.GetForParticipant(challenge, participant.ID)
+		if own := service.FindTask(tasks, taskID); own != nil {
+			task = own
+		}
+	}
+
+	items := h.commentItems(challengeID, comments, offset)
+	var deletableIDs []int64
+	if canDelete {
+		for _, comment := range comments {
+			deletableIDs = append(deletableIDs, comment.ID)
+		}
+	}
+
+	data := views.TaskCommentsData{
+		TaskOrderNum: task.OrderNum,
+		TaskTitle:    task.Title,
+		Comments:     items,
+		Page:         page,
+		TotalPages:   totalPages,
+		ShowNumbers:  canDelete,
+	}
+
+	text := views.RenderTaskComments(data)
+	return c.Send(text, keyboards.TaskComments(taskID, page, totalPages, deletableIDs), tele.ModeHTML)
+}
+
+// handleDeleteComment deletes a comment (admin only) and re-renders the comments page
+func (h *Handler) handleDeleteComment(c tele.Context, commentIDStr, pageStr string) error {
+	userID := c.Sender().ID
+	commentID, err := strconv.ParseInt(commentIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	comment, err := h.repo.Comment().GetByID(commentID)
+	if err != nil || comment == nil {
+		return h.sendError(c, "🤔 That comment is already gone.")
+	}
+
+	if err := h.comment.Delete(commentID, userID, h.isSuperAdmin(userID)); err != nil {
+		if err == service.ErrNotAdmin {
+			return h.sendError(c, "🔒 Sorry, only the admin can do that!")
+		}
+		logger.Error("Failed to delete comment", "comment_id", commentID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	c.Send("🗑 Comment deleted.")
+	return h.showTaskComments(c, fmt.Sprintf("%d", comment.TaskID), pageStr)
+}
+
+// commentItems converts comments to view items with author info and the viewer's local time
+func (h *Handler) commentItems(challengeID string, comments []*domain.TaskComment, offsetMinutes int) []*views.CommentItem {
+	participants, _ := h.participant.GetByChallengeID(challengeID)
+	byID := make(map[int64]*domain.Participant, len(participants))
+	for _, p := range participants {
+		byID[p.ID] = p
+	}
+
+	items := make([]*views.CommentItem, 0, len(comments))
+	for i, comment := range comments {
+		item := &views.CommentItem{
+			Number:    i + 1,
+			Text:      comment.Text,
+			CreatedAt: comment.CreatedAt.UTC().Add(time.Duration(offsetMinutes) * time.Minute),
+		}
+		if p, ok := byID[comment.ParticipantID]; ok {
+			item.Emoji = p.Emoji
+			item.Name = p.DisplayName
+		}
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/export"
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+	tele "gopkg.in/telebot.v3"
+)
+
+// showExportFormats asks which file format to export a challenge in
+func (h *Handler) showExportFormats(c tele.Context, challengeID, backUnique string) error {
+	challenge, err := h.challenge.GetByID(challengeID)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	msg := fmt.Sprintf(`📤 <b>Export "%s"</b>
+
+<b>Excel/CSV</b>: one row per participant and task, with completion times in each participant's local time, plus a summary.
+<b>JSON/YAML</b>: the challenge settings and tasks, for importing elsewhere.
+
+Choose a format:`, html.EscapeString(challenge.Name))
+
+	return c.Send(msg, keyboards.ExportFormats(challengeID, backUnique), tele.ModeHTML)
+}
+
+// handleExport builds a challenge export and sends it as documents
+func (h *Handler) handleExport(c tele.Context, format, challengeID string) error {
+	userID := c.Sender().ID
+
+	// Portable formats describe the challenge itself, not completion data
+	if format == service.PortableFormatJSON || format == service.PortableFormatYAML {
+		return h.handleExportChallengeDocument(c, format, challengeID)
+	}
+
+	data, err := h.export.Build(challengeID, userID, h.isSuperAdmin(userID))
+	if errors.Is(err, service.ErrNotAdmin) {
+		return h.sendError(c, "🔒 Sorry, only the admin can do that!")
+	}
+	if err != nil {
+		logger.Error("Failed to build export", "challenge_id", challengeID, "user_id", userID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	caption := fmt.Sprintf("📤 %s — %d participants", html.EscapeString(data.Challenge.Name), len(data.Summary))
+
+	switch format {
+	case "xlsx":
+		b, err := export.XLSX(data)
+		if err != nil {
+			logger.Error("Failed to encode XLSX export", "challenge_id", challengeID, "error", err)
+			return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+		}
+		return c.Send(&tele.Document{
+			File:     tele.FromReader(bytes.NewReader(b)),
+			FileName: export.FileName(data, "", "xlsx"),
+			MIME:     "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			Caption:  caption,
+		}, tele.ModeHTML)
+
+	case "csv":
+		completions, err := export.CompletionsCSV(data)
+		if err != nil {
+			logger.Error("Failed to encode CSV export", "challenge_id", challengeID, "error", err)
+			return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+		}
+		summary, err := export.SummaryCSV(data)
+		if err != nil {
+			logger.Error("Failed to encode CSV summary", "challenge_id", challengeID, "error", err)
+			return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+		}
+		if err := c.Send(&tele.Document{
+			File:     tele.FromReader(bytes.NewReader(completions)),
+			FileName: export.FileName(data, "", "csv"),
+			MIME:     "text/csv",
+			Caption:  caption,
+		}, tele.ModeHTML); err != nil {
+			return err
+		}
+		return c.Send(&tele.Document{
+			File:     tele.FromReader(bytes.NewReader(summary)),
+			FileName: export.FileName(data, "-summary", "csv"),
+			MIME:     "text/csv",
+		})
+	}
+
+	return nil
+}
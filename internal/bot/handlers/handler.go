@@ -13,11 +13,14 @@ const (
 	TempKeySuperAdminMode = "super_admin_mode"
 	TempKeyChallengeID    = "challenge_id"
 	TempKeyTaskID         = "task_id"
+	TempKeyBulkText       = "bulk_text"
 
 	// Template-related temp data keys
-	TempKeyTemplateID   = "template_id"
-	TempKeyTemplateName = "template_name"
-	TempKeyFromTemplate = "from_template"
+	TempKeyTemplateID    = "template_id"
+	TempKeyTemplateName  = "template_name"
+	TempKeyFromTemplate  = "from_template"
+	TempKeyTemplateView  = "template_view"
+	TempKeyTemplateQuery = "template_query"
 )
 
 // Handler holds all bot handlers and services
@@ -31,6 +34,15 @@ type Handler struct {
 	notification *service.NotificationService
 	superAdmin   *service.SuperAdminService
 	template     *service.TemplateService
+	comment      *service.CommentService
+	kudos        *service.KudosService
+	nudge        *service.NudgeService
+	achievement  *service.AchievementService
+	stats        *service.StatsService
+	export       *service.ExportService
+	portable     *service.PortableService
+	audit        *service.AuditService
+	trash        *service.TrashService
 	bot          *tele.Bot
 }
 
@@ -45,6 +57,15 @@ func NewHandler(
 	notification *service.NotificationService,
 	superAdmin *service.SuperAdminService,
 	template *service.TemplateService,
+	comment *service.CommentService,
+	kudos *service.KudosService,
+	nudge *service.NudgeService,
+	achievement *service.AchievementService,
+	stats *service.StatsService,
+	export *service.ExportService,
+	portable *service.PortableService,
+	audit *service.AuditService,
+	trash *service.TrashService,
 	bot *tele.Bot,
 ) *Handler {
 	return &Handler{
@@ -57,6 +78,15 @@ func NewHandler(
 		notification: notification,
 		superAdmin:   superAdmin,
 		template:     template,
+		comment:      comment,
+		kudos:        kudos,
+		nudge:        nudge,
+		achievement:  achievement,
+		stats:        stats,
+		export:       export,
+		portable:     portable,
+		audit:        audit,
+		trash:        trash,
 		bot:          bot,
 	}
 }
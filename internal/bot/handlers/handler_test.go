@@ -1,10 +1,16 @@
 package handlers
 
 import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
@@ -49,6 +55,15 @@ func testHandler(t *testing.T) (*Handler, func()) {
 		nil, // notification service not needed for tests
 		service.NewSuperAdminService(repo),
 		service.NewTemplateService(repo),
+		service.NewCommentService(repo),
+		service.NewKudosService(repo),
+		service.NewNudgeService(repo),
+		service.NewAchievementService(repo),
+		service.NewStatsService(repo),
+		service.NewExportService(repo),
+		service.NewPortableService(repo),
+		service.NewAuditService(repo),
+		service.NewTrashService(repo, 30*24*time.Hour),
 		nil, // bot not needed for tests
 	)
 
@@ -494,3 +509,257 @@ func TestSuperAdmin_AdminPanel_ShowsSuperAdminLabel(t *testing.T) {
 		t.Errorf("Expected 'Super Admin' label in admin panel, got: %s", msg)
 	}
 }
+
+func TestHandleStart_TemplateDeepLink(t *testing.T) {
+	h, cleanup := testHandler(t)
+	defer cleanup()
+
+	ownerID := int64(111)
+	friendID := int64(222)
+
+	challenge, _ := h.challenge.Create("Morning Routine", "", ownerID, 0, false)
+	template, err := h.template.SaveAsPersonal(challenge.ID, ownerID, false)
+	if err != nil {
+		t.Fatalf("SaveAsPersonal failed: %v", err)
+	}
+	code, err := h.template.Share(template.ID, ownerID, false)
+	if err != nil {
+		t.Fatalf("Share failed: %v", err)
+	}
+
+	ctx := testutil.NewMockContext(friendID).WithPayload("tpl_" + code)
+	if err := h.HandleStart(ctx); err != nil {
+		t.Fatalf("HandleStart failed: %v", err)
+	}
+	if msg := ctx.LastMessage(); !strings.Contains(msg, "Morning Routine") {
+		t.Errorf("Expected template preview, got: %s", msg)
+	}
+
+	// The buttons carry the code; the template ID alone doesn't open it
+	ctx = testutil.NewMockContext(friendID).WithCallback(fmt.Sprintf("tpl_tasks|%d|%s", template.ID, code))
+	h.HandleCallback(ctx)
+	if msg := ctx.LastMessage(); strings.Contains(msg, "not found") {
+		t.Errorf("Expected template tasks with the share code, got: %s", msg)
+	}
+	for _, action := range []string{"tpl_select", "tpl_tasks", "tpl_create"} {
+		ctx = testutil.NewMockContext(333).WithCallback(fmt.Sprintf("%s|%d", action, template.ID))
+		h.HandleCallback(ctx)
+		if msg := ctx.LastMessage(); !strings.Contains(msg, "Template not found") {
+			t.Errorf("%s without share code: expected not found, got: %s", action, msg)
+		}
+	}
+
+	// Revoked links no longer open the template
+	if err := h.template.RevokeShare(template.ID, ownerID, false); err != nil {
+		t.Fatalf("RevokeShare failed: %v", err)
+	}
+	ctx = testutil.NewMockContext(friendID).WithPayload("tpl_" + code)
+	if err := h.HandleStart(ctx); err != nil {
+		t.Fatalf("HandleStart failed: %v", err)
+	}
+	if msg := ctx.LastMessage(); !strings.Contains(msg, "invalid or was revoked") {
+		t.Errorf("Expected revoked link error, got: %s", msg)
+	}
+}
+
+func TestTemplateCatalog_SearchAndBack(t *testing.T) {
+	h, cleanup := testHandler(t)
+	defer cleanup()
+
+	userID := int64(12345)
+	for _, name := range []string{"Morning Yoga", "Reading Club"} {
+		challenge, _ := h.challenge.Create(name, "", 999, 0, false)
+		if _, err := h.template.CreateFromChallenge(challenge.ID, 0); err != nil {
+			t.Fatalf("CreateFromChallenge failed: %v", err)
+		}
+	}
+
+	ctx := testutil.NewMockContext(userID).WithCallback("tpl_search|pop")
+	if err := h.HandleCallback(ctx); err != nil {
+		t.Fatalf("HandleCallback failed: %v", err)
+	}
+	state, _ := h.state.Get(userID)
+	if state.State != domain.StateAwaitingTemplateSearch {
+		t.Fatalf("State = %q, want %q", state.State, domain.StateAwaitingTemplateSearch)
+	}
+
+	ctx = testutil.NewMockContext(userID).WithMessage("yoga")
+	if err := h.HandleText(ctx); err != nil {
+		t.Fatalf("HandleText failed: %v", err)
+	}
+	msg := ctx.LastMessage()
+	if !strings.Contains(msg, "Results for <b>yoga</b>") || !strings.Contains(msg, "Most popular first") {
+		t.Errorf("Expected popular search results, got: %s", msg)
+	}
+
+	// Back from a template preview returns to the same search
+	ctx = testutil.NewMockContext(userID).WithCallback("back_to_tpl_list")
+	if err := h.HandleCallback(ctx); err != nil {
+		t.Fatalf("HandleCallback failed: %v", err)
+	}
+	if msg := ctx.LastMessage(); !strings.Contains(msg, "Results for <b>yoga</b>") {
+		t.Errorf("Expected search results after back, got: %s", msg)
+	}
+}
+
+func TestShuffledSequentialOrder_PerParticipant(t *testing.T) {
+	h, cleanup := testHandler(t)
+	defer cleanup()
+
+	adminID := int64(999)
+	userID := int64(12345)
+
+	challenge, _ := h.challenge.Create("Scavenger Hunt", "", adminID, 0, true)
+	for i := 1; i <= 6; i++ {
+		h.task.Create(challenge.ID, fmt.Sprintf("Clue %d", i), "", "", adminID)
+	}
+	h.challenge.ToggleShuffleTasks(challenge.ID, adminID, false)
+	challenge, _ = h.challenge.GetByID(challenge.ID)
+
+	participant, _ := h.participant.Join(challenge.ID, userID, "Alice", "🔥", 0)
+	h.state.SetCurrentChallenge(userID, challenge.ID)
+
+	own, _ := h.task.GetForParticipant(challenge, participant.ID)
+
+	// The participant's first task is shown as Task #1
+	ctx := testutil.NewMockContext(userID).WithCallback(fmt.Sprintf("task_detail|%d", own[0].ID))
+	if err := h.HandleCallback(ctx); err != nil {
+		t.Fatalf("HandleCallback failed: %v", err)
+	}
+	if msg := ctx.LastMessage(); !strings.Contains(msg, "Task #1: <b>"+own[0].Title) {
+		t.Errorf("Expected own first task as Task #1, got: %s", msg)
+	}
+
+	// Their third task is still locked
+	ctx = testutil.NewMockContext(userID).WithCallback(fmt.Sprintf("complete_task|%d", own[2].ID))
+	if err := h.HandleCallback(ctx); err != nil {
+		t.Fatalf("HandleCallback failed: %v", err)
+	}
+	if msg := ctx.LastMessage(); !strings.Contains(msg, "locked") {
+		t.Errorf("Expected locked message, got: %s", msg)
+	}
+
+	if done, _ := h.completion.IsCompleted(own[2].ID, participant.ID); done {
+		t.Error("Locked task should not be completed")
+	}
+}
+
+func TestHandleCallback_AuditLog(t *testing.T) {
+	h, cleanup := testHandler(t)
+	defer cleanup()
+
+	adminID := int64(12345)
+	challenge, _ := h.challenge.Create("Old Name", "", adminID, 0, false)
+	h.challenge.UpdateName(challenge.ID, "New Name", adminID, false)
+	h.state.SetCurrentChallenge(adminID, challenge.ID)
+
+	ctx := testutil.NewMockContext(adminID).WithCallback("audit_log|0")
+	if err := h.HandleCallback(ctx); err != nil {
+		t.Fatalf("HandleCallback failed: %v", err)
+	}
+
+	msg := ctx.LastMessage()
+	if !strings.Contains(msg, "Renamed challenge") || !strings.Contains(msg, "Old Name") {
+		t.Errorf("Audit log = %q, want the rename", msg)
+	}
+
+	// Other users can't see the history
+	ctx = testutil.NewMockContext(777).WithCallback("audit_log|0")
+	h.state.SetCurrentChallenge(777, challenge.ID)
+	h.HandleCallback(ctx)
+	if strings.Contains(ctx.LastMessage(), "Old Name") {
+		t.Error("Non-admin should not see the audit log")
+	}
+}
+
+func TestHandleCallback_RestoreTask(t *testing.T) {
+	h, cleanup := testHandler(t)
+	defer cleanup()
+
+	adminID := int64(12345)
+	challenge, _ := h.challenge.Create("Test", "", adminID, 0, false)
+	task, _ := h.task.Create(challenge.ID, "Push-ups", "", "", adminID)
+	h.task.Delete(task.ID, challenge.ID, adminID)
+	h.state.SetCurrentChallenge(adminID, challenge.ID)
+
+	ctx := testutil.NewMockContext(adminID).WithCallback("task_trash")
+	if err := h.HandleCallback(ctx); err != nil {
+		t.Fatalf("HandleCallback failed: %v", err)
+	}
+	if !strings.Contains(ctx.LastMessage(), "Push-ups") {
+		t.Errorf("Trash = %q, want the deleted task", ctx.LastMessage())
+	}
+
+	ctx = testutil.NewMockContext(adminID).WithCallback(fmt.Sprintf("restore_task|%d", task.ID))
+	if err := h.HandleCallback(ctx); err != nil {
+		t.Fatalf("HandleCallback failed: %v", err)
+	}
+	tasks, _ := h.task.GetByChallengeID(challenge.ID)
+	if len(tasks) != 1 || tasks[0].ID != task.ID {
+		t.Errorf("Tasks after restore = %v, want Push-ups back", tasks)
+	}
+	if !strings.Contains(ctx.LastMessage(), "Nothing here") {
+		t.Errorf("Trash after restore = %q, want it empty", ctx.LastMessage())
+	}
+}
+
+func TestHandleCallback_KudosUnknownReaction(t *testing.T) {
+	h, cleanup := testHandler(t)
+	defer cleanup()
+
+	challenge, _ := h.challenge.Create("Test", "", 12345, 0, false)
+	task, _ := h.task.Create(challenge.ID, "Push-ups", "", "", 12345)
+	receiver, _ := h.participant.Join(challenge.ID, 12345, "Admin", "💪", 0)
+	h.participant.Join(challenge.ID, 67890, "Friend", "🔥", 0)
+	h.completion.Complete(task.ID, receiver.ID)
+
+	ctx := testutil.NewMockContext(67890).WithCallback(fmt.Sprintf("kudos|%d|%d|💩", task.ID, receiver.ID))
+	if err := h.HandleCallback(ctx); err != nil {
+		t.Fatalf("HandleCallback failed: %v", err)
+	}
+	if !strings.Contains(ctx.LastMessage(), "Unknown reaction") {
+		t.Errorf("Reply = %q, want an unknown reaction notice", ctx.LastMessage())
+	}
+}
+
+// TestCallbackActions checks that CallbackActions matches the cases of HandleCallback's switch,
+// since metrics only label callbacks with the actions on that list
+func TestCallbackActions(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "callbacks.go", nil, 0)
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+
+	var cases []string
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != "HandleCallback" {
+			continue
+		}
+		ast.Inspect(fn, func(n ast.Node) bool {
+			sw, ok := n.(*ast.SwitchStmt)
+			if !ok {
+				return true
+			}
+			if tag, ok := sw.Tag.(*ast.Ident); !ok || tag.Name != "action" {
+				return true
+			}
+			for _, stmt := range sw.Body.List {
+				for _, expr := range stmt.(*ast.CaseClause).List {
+					if lit, ok := expr.(*ast.BasicLit); ok {
+						action, _ := strconv.Unquote(lit.Value)
+						cases = append(cases, action)
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	if len(cases) == 0 {
+		t.Fatal("No cases found in HandleCallback")
+	}
+	if strings.Join(cases, ",") != strings.Join(CallbackActions, ",") {
+		t.Errorf("CallbackActions = %v\nwant the HandleCallback cases %v", CallbackActions, cases)
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+	tele "gopkg.in/telebot.v3"
+)
+
+// handleSendKudos sends kudos for a teammate's task completion (from a notification button)
+func (h *Handler) handleSendKudos(c tele.Context, taskIDStr, toParticipantIDStr, emoji string) error {
+	userID := c.Sender().ID
+	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+	toParticipantID, err := strconv.ParseInt(toParticipantIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	// The notification may come from any of the user's challenges,
+	// so resolve the challenge from the receiver rather than from state
+	receiver, err := h.participant.GetByID(toParticipantID)
+	if err != nil {
+		return h.sendError(c, "🤔 That teammate is no longer in the challenge.")
+	}
+
+	sender, err := h.participant.GetByChallengeAndUser(receiver.ChallengeID, userID)
+	if err != nil || sender == nil {
+		return h.sendError(c, "😕 You're not in this challenge.")
+	}
+
+	task, err := h.task.GetByID(taskID)
+	if err != nil {
+		return h.sendError(c, "🤔 Can't find that task.")
+	}
+
+	if _, err := h.kudos.Send(taskID, sender.ID, receiver.ID, emoji); err != nil {
+		switch {
+		case errors.Is(err, service.ErrKudosAlreadySent):
+			return c.Send("🙌 You've already sent kudos for this one!")
+		case errors.Is(err, service.ErrSelfKudos):
+			return c.Send("😄 Nice try, but you can't cheer for yourself!")
+		case errors.Is(err, service.ErrTaskNotCompleted):
+			return c.Send("🤔 Looks like that task isn't completed anymore.")
+		case errors.Is(err, service.ErrInvalidKudos):
+			return c.Send("🤷 Unknown reaction. Pick one of the kudos buttons instead.")
+		}
+		logger.Error("Failed to send kudos", "task_id", taskID, "to_participant_id", toParticipantID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	go h.notification.NotifyKudos(receiver.ID, sender.Emoji, sender.DisplayName, emoji, task.Title)
+
+	return c.Send(fmt.Sprintf("%s Kudos sent to %s %s!", emoji, receiver.Emoji, receiver.DisplayName))
+}
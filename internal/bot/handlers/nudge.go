@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+	tele "gopkg.in/telebot.v3"
+)
+
+// handleNudge sends a nudge to a lagging teammate
+func (h *Handler) handleNudge(c tele.Context, participantIDStr string) error {
+	userID := c.Sender().ID
+	toParticipantID, err := strconv.ParseInt(participantIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	userState, _ := h.state.Get(userID)
+	challengeID := userState.CurrentChallenge
+
+	sender, err := h.participant.GetByChallengeAndUser(challengeID, userID)
+	if err != nil || sender == nil {
+		return h.sendError(c, "😕 You're not in this challenge.")
+	}
+
+	recipient, err := h.participant.GetByID(toParticipantID)
+	if err != nil {
+		return h.sendError(c, "🤔 That teammate is no longer in the challenge.")
+	}
+
+	if _, err := h.nudge.Send(sender.ID, recipient.ID); err != nil {
+		switch err {
+		case service.ErrNudgeTooSoon:
+			wait, _ := h.nudge.CooldownRemaining(sender.ID, recipient.ID)
+			return c.Send(fmt.Sprintf(
+				"⏳ You already nudged %s %s recently. Try again in %s.",
+				recipient.Emoji, recipient.DisplayName, formatDuration(wait),
+			))
+		case service.ErrNudgesMuted:
+			return c.Send(fmt.Sprintf("🔕 %s %s has notifications turned off.", recipient.Emoji, recipient.DisplayName))
+		case service.ErrNothingToNudge:
+			return c.Send(fmt.Sprintf("🏆 %s %s has already finished everything!", recipient.Emoji, recipient.DisplayName))
+		case service.ErrSelfNudge:
+			return c.Send("😄 You can't nudge yourself!")
+		case service.ErrParticipantNotFound:
+			return h.sendError(c, "🤔 That teammate is no longer in the challenge.")
+		}
+		logger.Error("Failed to nudge", "from_participant_id", sender.ID, "to_participant_id", recipient.ID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	challenge, err := h.challenge.GetByID(challengeID)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	// Attach a button to the recipient's current task
+	var markup *tele.ReplyMarkup
+	tasks, _ := h.task.GetByChallengeID(challengeID)
+	if t := h.currentTask(challenge, recipient.ID, tasks); t != nil {
+		markup = keyboards.OpenTask(challengeID, t.ID)
+	}
+
+	go h.notification.NotifyNudge(recipient.TelegramID, sender.Emoji, sender.DisplayName, challenge.Name, markup)
+
+	c.Send(fmt.Sprintf("👋 Nudged %s %s!", recipient.Emoji, recipient.DisplayName))
+	return h.showTeamProgress(c)
+}
+
+// handleOpenTask switches to a challenge and opens a task (used from notification buttons)
+func (h *Handler) handleOpenTask(c tele.Context, challengeID, taskIDStr string) error {
+	userID := c.Sender().ID
+
+	participant, err := h.participant.GetByChallengeAndUser(challengeID, userID)
+	if err != nil || participant == nil {
+		return h.sendError(c, "😕 You're not in this challenge.")
+	}
+
+	h.state.SetCurrentChallenge(userID, challengeID)
+	return h.showTaskDetail(c, taskIDStr)
+}
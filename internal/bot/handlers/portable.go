@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+	tele "gopkg.in/telebot.v3"
+)
+
+// portableFormatHint explains what files can be imported
+const portableFormatHint = "Send a <b>.json</b> or <b>.yaml</b> file exported from this bot (or another instance of it)."
+
+// sendPortableDocument encodes a portable document and sends it as a file
+func (h *Handler) sendPortableDocument(c tele.Context, doc *service.PortableDocument, format, baseName string) error {
+	b, err := service.EncodePortable(doc, format)
+	if err != nil {
+		logger.Error("Failed to encode portable document", "format", format, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	mime := "application/json"
+	if format == service.PortableFormatYAML {
+		mime = "application/yaml"
+	}
+
+	return c.Send(&tele.Document{
+		File:     tele.FromReader(bytes.NewReader(b)),
+		FileName: fmt.Sprintf("%s.%s", baseName, format),
+		MIME:     mime,
+		Caption:  fmt.Sprintf("🧩 %s — %d tasks (format v%d)", html.EscapeString(doc.Name), len(doc.Tasks), doc.Version),
+	}, tele.ModeHTML)
+}
+
+// handleExportChallengeDocument exports a challenge as a portable document
+func (h *Handler) handleExportChallengeDocument(c tele.Context, format, challengeID string) error {
+	userID := c.Sender().ID
+
+	doc, err := h.portable.ExportChallenge(challengeID, userID, h.isSuperAdmin(userID))
+	if errors.Is(err, service.ErrNotAdmin) {
+		return h.sendError(c, "🔒 Sorry, only the admin can do that!")
+	}
+	if err != nil {
+		logger.Error("Failed to export challenge document", "challenge_id", challengeID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	return h.sendPortableDocument(c, doc, format, "challenge-"+challengeID)
+}
+
+// handleExportTemplate exports a template as a portable document (super admin only)
+func (h *Handler) handleExportTemplate(c tele.Context, templateIDStr, format string) error {
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+
+	doc, err := h.portable.ExportTemplate(templateID)
+	if err != nil {
+		logger.Error("Failed to export template document", "template_id", templateID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	return h.sendPortableDocument(c, doc, format, fmt.Sprintf("template-%d", templateID))
+}
+
+// handleImportTasks asks a challenge admin to upload a document with tasks
+func (h *Handler) handleImportTasks(c tele.Context) error {
+	userID := c.Sender().ID
+	h.state.SetState(userID, domain.StateAwaitingImportTasks)
+
+	msg := "📥 <b>Import Tasks</b>\n\n" + portableFormatHint +
+		"\n\nIts tasks will be added to the end of this challenge; challenge settings stay as they are."
+	return c.Send(msg, keyboards.CancelOnly(), tele.ModeHTML)
+}
+
+// processImportTasks appends tasks from an uploaded document to the current challenge
+func (h *Handler) processImportTasks(c tele.Context, file *tele.Document) error {
+	userID := c.Sender().ID
+	userState, _ := h.state.Get(userID)
+	challengeID := userState.CurrentChallenge
+
+	doc, err := h.readPortableDocument(c, file)
+	if err != nil {
+		return h.sendImportError(c, err)
+	}
+
+	count, err := h.portable.ImportTasks(challengeID, doc, userID, h.isSuperAdmin(userID))
+	if err != nil {
+		if errors.Is(err, service.ErrMaxTasksReached) {
+			return c.Send(
+				fmt.Sprintf("⚠️ That would exceed the limit of %d tasks per challenge. Try a smaller file.", domain.MaxTasksPerChallenge),
+				keyboards.CancelOnly(),
+			)
+		}
+		if errors.Is(err, service.ErrNotAdmin) {
+			h.state.ResetKeepChallenge(userID)
+			return h.sendError(c, "🔒 Sorry, only the admin can do that!")
+		}
+		logger.Error("Failed to import tasks", "challenge_id", challengeID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	h.state.ResetKeepChallenge(userID)
+	if err := c.Send(fmt.Sprintf("✅ Imported %d tasks from \"%s\"!", count, doc.Name)); err != nil {
+		return err
+	}
+	return h.showAdminPanel(c, challengeID)
+}
+
+// handleImportTemplate asks a super admin to upload a document to create a template from
+func (h *Handler) handleImportTemplate(c tele.Context) error {
+	userID := c.Sender().ID
+	h.state.SetState(userID, domain.StateAwaitingImportTemplate)
+
+	msg := "📥 <b>Import Template</b>\n\n" + portableFormatHint +
+		"\n\nBoth challenge and template exports can be imported as a new template."
+	return c.Send(msg, keyboards.CancelOnly(), tele.ModeHTML)
+}
+
+// processImportTemplate creates a template from an uploaded document
+func (h *Handler) processImportTemplate(c tele.Context, file *tele.Document) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		h.state.Reset(userID)
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	doc, err := h.readPortableDocument(c, file)
+	if err != nil {
+		return h.sendImportError(c, err)
+	}
+
+	template, err := h.portable.ImportTemplate(doc, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrTemplateNameExists) {
+			return c.Send(
+				"⚠️ A template with this name already exists. Rename it in the file or delete the existing template first.",
+				keyboards.CancelOnly(),
+			)
+		}
+		logger.Error("Failed to import template", "error", err)
+		return h.sendError(c, "Failed to create template.")
+	}
+
+	h.state.Reset(userID)
+	msg := fmt.Sprintf("✅ Template '<b>%s</b>' imported with %d tasks!", html.EscapeString(template.Name), len(doc.Tasks))
+	return c.Send(msg, keyboards.BackToSuperAdmin(), tele.ModeHTML)
+}
+
+// readPortableDocument downloads and decodes an uploaded portable document
+func (h *Handler) readPortableDocument(c tele.Context, file *tele.Document) (*service.PortableDocument, error) {
+	if file.FileSize > service.MaxPortableFileSize {
+		return nil, fmt.Errorf("%w: file is too large", service.ErrInvalidDocument)
+	}
+
+	rc, err := c.Bot().File(&file.File)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, service.MaxPortableFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > service.MaxPortableFileSize {
+		return nil, fmt.Errorf("%w: file is too large", service.ErrInvalidDocument)
+	}
+
+	return service.DecodePortable(data, file.FileName)
+}
+
+// sendImportError explains why an uploaded document was rejected; the user stays in the import state
+func (h *Handler) sendImportError(c tele.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnsupportedDocumentFormat):
+		return c.Send("⚠️ Unsupported file type. "+portableFormatHint, keyboards.CancelOnly(), tele.ModeHTML)
+	case errors.Is(err, service.ErrUnsupportedDocumentVersion):
+		return c.Send(
+			fmt.Sprintf("⚠️ This file was made by a newer version of the bot. Supported format version: %d.", service.PortableFormatVersion),
+			keyboards.CancelOnly(),
+		)
+	case errors.Is(err, service.ErrInvalidDocument):
+		reason := strings.TrimPrefix(err.Error(), service.ErrInvalidDocument.Error()+": ")
+		return c.Send("⚠️ This file can't be imported: "+html.EscapeString(reason), keyboards.CancelOnly(), tele.ModeHTML)
+	}
+	logger.Error("Failed to read uploaded document", "user_id", c.Sender().ID, "error", err)
+	return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+}
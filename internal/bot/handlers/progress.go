@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -49,39 +50,11 @@ func (h *Handler) handleCompleteTask(c tele.Context, taskIDStr string) error {
 		return h.sendError(c, "😕 You're not in this challenge.")
 	}
 
-	// Check daily limit
 	challenge, err := h.challenge.GetByID(challengeID)
 	if err != nil {
 		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
 	}
 
-	logger.Debug("handleCompleteTask",
-		"challenge_id", challengeID,
-		"task_id", taskID,
-		"participant_id", participant.ID,
-		"daily_task_limit", challenge.DailyTaskLimit,
-		"time_offset_minutes", participant.TimeOffsetMinutes,
-	)
-
-	if challenge.DailyTaskLimit > 0 {
-		limitInfo, err := h.completion.CheckDailyLimit(participant, challenge.DailyTaskLimit)
-		if err != nil {
-			logger.Debug("CheckDailyLimit error", "error", err)
-			return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
-		}
-
-		logger.Debug("PRE-completion limitInfo",
-			"allowed", limitInfo.Allowed,
-			"completed", limitInfo.Completed,
-			"limit", limitInfo.Limit,
-		)
-
-		if !limitInfo.Allowed {
-			logger.Debug("Daily limit reached BEFORE completion, blocking")
-			return h.showDailyLimitReached(c, limitInfo)
-		}
-	}
-
 	task, err := h.task.GetByID(taskID)
 	if err != nil {
 		return h.sendError(c, "🤔 Can't find that task.")
@@ -89,7 +62,10 @@ func (h *Handler) handleCompleteTask(c tele.Context, taskIDStr string) error {
 
 	// Check if task is hidden (cannot complete hidden tasks)
 	if challenge.HideFutureTasks {
-		tasks, _ := h.task.GetByChallengeID(challengeID)
+		tasks, _ := h.task.GetForParticipant(challenge, participant.ID)
+		if own := service.FindTask(tasks, taskID); own != nil {
+			task = own
+		}
 		currentTaskNum := h.completion.GetCurrentTaskNum(participant.ID, tasks)
 		// Only check if there's a current task (currentTaskNum > 0 means not all completed)
 		if currentTaskNum > 0 && task.OrderNum > currentTaskNum {
@@ -100,33 +76,27 @@ func (h *Handler) handleCompleteTask(c tele.Context, taskIDStr string) error {
 		}
 	}
 
-	logger.Debug("About to call Complete", "task_id", taskID, "participant_id", participant.ID)
-	completion, err := h.completion.Complete(taskID, participant.ID)
+	// Complete checks the daily limit and records the completion atomically
+	_, err = h.completion.Complete(taskID, participant.ID)
+	var limitErr *service.DailyLimitError
+	if errors.As(err, &limitErr) {
+		return h.showDailyLimitReached(c, limitErr.Info)
+	}
 	if err != nil {
-		logger.Debug("Complete() error", "error", err)
+		logger.Error(
+			"handleCompleteTask: failed to complete",
+			"task_id",
+			taskID,
+			"participant_id",
+			participant.ID,
+			"error",
+			err,
+		)
 		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
 	}
-	logger.Debug("Complete() returned", "completion_id", completion.ID, "completed_at", completion.CompletedAt)
 
-	// Post-completion check for daily limit (handles race conditions)
-	// If limit exceeded after completion, uncomplete and show limit message
-	if challenge.DailyTaskLimit > 0 {
-		limitInfo, err := h.completion.CheckDailyLimit(participant, challenge.DailyTaskLimit)
-		logger.Debug("POST-completion limitInfo",
-			"error", err,
-			"allowed", limitInfo.Allowed,
-			"completed", limitInfo.Completed,
-			"limit", limitInfo.Limit,
-		)
-		if err == nil && limitInfo != nil && limitInfo.Completed > challenge.DailyTaskLimit {
-			// Limit exceeded due to race condition - rollback
-			logger.Debug("Race condition detected! Rolling back completion")
-			h.completion.Uncomplete(taskID, participant.ID)
-			limitInfo.Completed = challenge.DailyTaskLimit // Show correct count
-			limitInfo.Allowed = false
-			return h.showDailyLimitReached(c, limitInfo)
-		}
-	}
+	// Award any badges earned by this completion
+	h.awardAchievements(challengeID, participant)
 
 	// Check if all tasks completed
 	tasks, _ := h.task.GetByChallengeID(challengeID)
@@ -139,6 +109,7 @@ func (h *Handler) handleCompleteTask(c tele.Context, taskIDStr string) error {
 		participant.DisplayName,
 		task.Title,
 		userID,
+		keyboards.Kudos(task.ID, participant.ID),
 	)
 
 	if allCompleted {
@@ -187,26 +158,35 @@ func (h *Handler) handleCompleteCurrent(c tele.Context) error {
 		return h.sendError(c, "😕 You're not in this challenge.")
 	}
 
+	challenge, err := h.challenge.GetByID(challengeID)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
 	tasks, err := h.task.GetByChallengeID(challengeID)
 	if err != nil {
 		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
 	}
 
-	currentTaskNum := h.completion.GetCurrentTaskNum(participant.ID, tasks)
-	if currentTaskNum == 0 {
+	current := h.currentTask(challenge, participant.ID, tasks)
+	if current == nil {
 		return h.sendError(c, "🎉 You've already crushed all the tasks!")
 	}
 
-	// Find task ID for current task number
-	var taskID int64
+	return h.handleCompleteTask(c, fmt.Sprintf("%d", current.ID))
+}
+
+// currentTask returns a participant's current task in their own task order,
+// or nil when they have completed everything
+func (h *Handler) currentTask(challenge *domain.Challenge, participantID int64, tasks []*domain.Task) *domain.Task {
+	tasks = service.ParticipantTaskOrder(tasks, challenge, participantID)
+	currentTaskNum := h.completion.GetCurrentTaskNum(participantID, tasks)
 	for _, t := range tasks {
 		if t.OrderNum == currentTaskNum {
-			taskID = t.ID
-			break
+			return t
 		}
 	}
-
-	return h.handleCompleteTask(c, fmt.Sprintf("%d", taskID))
+	return nil
 }
 
 // handleUncompleteTask uncompletes a task
@@ -260,7 +240,11 @@ func (h *Handler) showTaskDetail(c tele.Context, taskIDStr string) error {
 		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
 	}
 
-	tasks, _ := h.task.GetByChallengeID(challengeID)
+	// Number the task in this participant's own order
+	tasks, _ := h.task.GetForParticipant(challenge, participant.ID)
+	if own := service.FindTask(tasks, taskID); own != nil {
+		task = own
+	}
 	currentTaskNum := h.completion.GetCurrentTaskNum(participant.ID, tasks)
 
 	// Only show hidden view if there's a current task to work on (currentTaskNum > 0)
@@ -298,11 +282,21 @@ func (h *Handler) showTaskDetail(c tele.Context, taskIDStr string) error {
 		}
 	}
 
+	// Latest comments
+	commentCount, _ := h.comment.Count(taskID)
+	var commentItems []*views.CommentItem
+	if commentCount > 0 {
+		comments, _ := h.comment.GetLatest(taskID, domain.CommentsPreviewCount)
+		commentItems = h.commentItems(challengeID, comments, participant.TimeOffsetMinutes)
+	}
+
 	data := views.TaskDetailData{
-		Task:        task,
-		IsCompleted: isCompleted,
-		CompletedBy: completedBy,
-		NotYet:      notYet,
+		Task:         task,
+		IsCompleted:  isCompleted,
+		CompletedBy:  completedBy,
+		NotYet:       notYet,
+		Comments:     commentItems,
+		CommentCount: commentCount,
 	}
 
 	text := views.RenderTaskDetail(data)
@@ -336,15 +330,25 @@ func (h *Handler) showTeamProgress(c tele.Context) error {
 		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
 	}
 
+	kudosCounts, _ := h.kudos.CountReceivedByChallenge(challengeID)
+
+	// Only participants can nudge; observers just watch
+	viewer, _ := h.participant.GetByChallengeAndUser(challengeID, userID)
+
 	var progressList []*views.ParticipantProgress
+	var nudgeTargets []*domain.Participant
 	for _, p := range participants {
 		completed, _ := h.completion.CountByParticipantID(p.ID)
+		if viewer != nil && p.ID != viewer.ID && p.NotifyEnabled && completed < totalTasks {
+			nudgeTargets = append(nudgeTargets, p)
+		}
 		progressList = append(progressList, &views.ParticipantProgress{
 			Emoji:          p.Emoji,
 			Name:           p.DisplayName,
 			IsAdmin:        challenge.CreatorID == p.TelegramID,
 			CompletedTasks: completed,
 			TotalTasks:     totalTasks,
+			Kudos:          kudosCounts[p.ID],
 		})
 	}
 
@@ -354,7 +358,7 @@ func (h *Handler) showTeamProgress(c tele.Context) error {
 	}
 
 	text := views.RenderTeamProgress(data)
-	return c.Send(text, keyboards.TeamProgress(), tele.ModeHTML)
+	return c.Send(text, keyboards.TeamProgress(nudgeTargets), tele.ModeHTML)
 }
 
 // showAllTasks shows the full list of all tasks
@@ -392,6 +396,7 @@ func (h *Handler) showAllTasks(c tele.Context) error {
 		for _, id := range completedIDs {
 			completedSet[id] = true
 		}
+		tasks = service.ParticipantTaskOrder(tasks, challenge, participant.ID)
 		currentTaskNum = h.completion.GetCurrentTaskNum(participant.ID, tasks)
 	}
 
@@ -404,7 +409,7 @@ func (h *Handler) showAllTasks(c tele.Context) error {
 	}
 
 	text := views.RenderAllTasks(data)
-	return c.Send(text, keyboards.TeamProgress(), tele.ModeHTML) // reuse back button
+	return c.Send(text, keyboards.BackToMain(), tele.ModeHTML)
 }
 
 // showCelebration shows the celebration view
@@ -418,6 +423,7 @@ func (h *Handler) showCelebration(
 	totalTasks := len(tasks)
 
 	participants, _ := h.participant.GetByChallengeID(challengeID)
+	kudosCounts, _ := h.kudos.CountReceivedByChallenge(challengeID)
 
 	var teamStatus []*views.TeamMemberStatus
 	for _, p := range participants {
@@ -429,21 +435,30 @@ func (h *Handler) showCelebration(
 			IsCompleted:    isCompleted,
 			CompletedTasks: completed,
 			TotalTasks:     totalTasks,
+			Kudos:          kudosCounts[p.ID],
 		})
 	}
 
 	timeTaken := time.Since(participant.JoinedAt)
+	badges, _ := h.achievement.GetForChallenge(challengeID, participant.TelegramID)
 
 	data := views.CelebrationData{
 		ChallengeName:  challenge.Name,
 		TotalTasks:     totalTasks,
 		CompletedTasks: totalTasks,
 		TimeTaken:      timeTaken,
+		KudosReceived:  kudosCounts[participant.ID],
+		Badges:         badges,
 		TeamStatus:     teamStatus,
 	}
 
 	text := views.RenderCelebration(data)
 
+	// Shareable card first, so the celebration with its keyboard stays last
+	if err := h.sendProgressCard(c, challengeID, participant); err != nil {
+		logger.Warn("Failed to send progress card", "participant_id", participant.ID, "error", err)
+	}
+
 	animation := &tele.Animation{
 		File:     tele.FromReader(bytes.NewReader(assets.ChallengeCompletedGIF)),
 		FileName: "challenge-completed.gif",
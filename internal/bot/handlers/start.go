@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
@@ -22,6 +23,11 @@ func (h *Handler) HandleStart(c tele.Context) error {
 	// Check for deep link parameter
 	payload := c.Message().Payload
 	logger.Debug("Start payload", "user_id", userID, "payload", payload)
+	if code, ok := strings.CutPrefix(payload, templateLinkPrefix); ok {
+		// Template link: t.me/bot?start=tpl_CODE
+		logger.Info("Template deep link detected", "user_id", userID, "code", code)
+		return h.handleTemplateDeepLink(c, code)
+	}
 	if payload != "" {
 		// Deep link: t.me/bot?start=CHALLENGE_ID
 		logger.Info("Deep link detected", "user_id", userID, "payload", payload)
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/images"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	tele "gopkg.in/telebot.v3"
+)
+
+// handleBurnUpChart sends the squad's burn-up chart for the current challenge
+func (h *Handler) handleBurnUpChart(c tele.Context) error {
+	userID := c.Sender().ID
+
+	userState, _ := h.state.Get(userID)
+	challengeID := userState.CurrentChallenge
+
+	challenge, err := h.challenge.GetByID(challengeID)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	burnUp, err := h.stats.BurnUp(challengeID, time.Now())
+	if err != nil {
+		logger.Error("Failed to compute burn-up", "challenge_id", challengeID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	data := images.BurnUpChartData{
+		ChallengeName: challenge.Name,
+		Start:         burnUp.Start,
+		TotalTasks:    burnUp.TotalTasks,
+	}
+	for _, s := range burnUp.Series {
+		data.Series = append(data.Series, images.ChartSeries{
+			Name:   s.Participant.DisplayName,
+			Values: s.Cumulative,
+		})
+	}
+
+	png, err := images.RenderBurnUpChart(data)
+	if err != nil {
+		logger.Error("Failed to render burn-up chart", "challenge_id", challengeID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	photo := &tele.Photo{
+		File:    tele.FromReader(bytes.NewReader(png)),
+		Caption: fmt.Sprintf("📈 <b>%s</b> — squad pace over %d days", html.EscapeString(challenge.Name), burnUp.Days),
+	}
+	return c.Send(photo, tele.ModeHTML)
+}
+
+// handleActivityHeatmap sends the current user's daily completions heatmap
+func (h *Handler) handleActivityHeatmap(c tele.Context) error {
+	userID := c.Sender().ID
+
+	userState, _ := h.state.Get(userID)
+	challengeID := userState.CurrentChallenge
+
+	challenge, err := h.challenge.GetByID(challengeID)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	participant, err := h.participant.GetByChallengeAndUser(challengeID, userID)
+	if err != nil || participant == nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	counts, err := h.stats.DailyCompletions(participant.ID)
+	if err != nil {
+		logger.Error("Failed to compute daily completions", "participant_id", participant.ID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	png, err := images.RenderHeatmap(images.HeatmapData{
+		Title:  challenge.Name,
+		Name:   participant.DisplayName,
+		Today:  time.Now().UTC().Add(time.Duration(participant.TimeOffsetMinutes) * time.Minute),
+		Counts: counts,
+	})
+	if err != nil {
+		logger.Error("Failed to render heatmap", "participant_id", participant.ID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	photo := &tele.Photo{
+		File:    tele.FromReader(bytes.NewReader(png)),
+		Caption: fmt.Sprintf("🗓 %s %s — your activity in <b>%s</b>", participant.Emoji, html.EscapeString(participant.DisplayName), html.EscapeString(challenge.Name)),
+	}
+	return c.Send(photo, tele.ModeHTML)
+}
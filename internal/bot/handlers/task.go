@@ -99,7 +99,7 @@ func (h *Handler) createTask(c tele.Context, description string) error {
 		imageFileID = img.(string)
 	}
 
-	task, err := h.task.Create(challengeID, title, description, imageFileID)
+	task, err := h.task.Create(challengeID, title, description, imageFileID, userID)
 	if err != nil {
 		h.state.ResetKeepChallenge(userID)
 		if err == service.ErrMaxTasksReached {
@@ -194,7 +194,7 @@ func (h *Handler) processEditTitle(c tele.Context, title string) error {
 	}
 
 	task.Title = title
-	if err := h.task.Update(task); err != nil {
+	if err := h.task.Update(task, userID); err != nil {
 		h.state.ResetKeepChallenge(userID)
 		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
 	}
@@ -242,7 +242,7 @@ func (h *Handler) processEditDescription(c tele.Context, description string) err
 	}
 
 	task.Description = description
-	if err := h.task.Update(task); err != nil {
+	if err := h.task.Update(task, userID); err != nil {
 		h.state.ResetKeepChallenge(userID)
 		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
 	}
@@ -280,7 +280,7 @@ func (h *Handler) processEditImage(c tele.Context, fileID string) error {
 	}
 
 	task.ImageFileID = fileID
-	if err := h.task.Update(task); err != nil {
+	if err := h.task.Update(task, userID); err != nil {
 		h.state.ResetKeepChallenge(userID)
 		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
 	}
@@ -299,7 +299,10 @@ func (h *Handler) handleDeleteTask(c tele.Context, taskIDStr string) error {
 		return h.sendError(c, "🤔 Can't find that task.")
 	}
 
-	msg := fmt.Sprintf("🗑 Delete \"%s\"?\n\nEveryone's progress on this will be gone!", task.Title)
+	msg := fmt.Sprintf(
+		"🗑 Delete \"%s\"?\n\nIt goes to the trash with everyone's progress on it. You can restore it from the admin panel within %d days.",
+		task.Title, h.trash.RetentionDays(),
+	)
 	return c.Send(msg, keyboards.DeleteTaskConfirm(taskID))
 }
 
@@ -311,14 +314,14 @@ func (h *Handler) handleConfirmDeleteTask(c tele.Context, taskIDStr string) erro
 	userState, _ := h.state.Get(userID)
 	challengeID := userState.CurrentChallenge
 
-	if err := h.task.Delete(taskID, challengeID); err != nil {
+	if err := h.task.Delete(taskID, challengeID, userID); err != nil {
 		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
 	}
 
 	// Check if any participants now completed all tasks due to this deletion
 	go h.checkCompletionsAfterTaskDelete(challengeID, userID)
 
-	c.Send("✅ Gone! Task deleted.")
+	c.Send("✅ Gone! Task moved to the trash.")
 	return h.handleEditTasks(c)
 }
 
@@ -408,7 +411,7 @@ func (h *Handler) handleReorderMove(c tele.Context, taskIDStr, positionStr strin
 	userState, _ := h.state.Get(userID)
 	challengeID := userState.CurrentChallenge
 
-	if err := h.task.MoveTask(taskID, challengeID, newPosition); err != nil {
+	if err := h.task.MoveTask(taskID, challengeID, newPosition, userID); err != nil {
 		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
 	}
 
@@ -429,7 +432,7 @@ func (h *Handler) handleRandomizeTasks(c tele.Context) error {
 	userState, _ := h.state.Get(userID)
 	challengeID := userState.CurrentChallenge
 
-	if err := h.task.RandomizeOrder(challengeID); err != nil {
+	if err := h.task.RandomizeOrder(challengeID, userID); err != nil {
 		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
 	}
 
@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"html"
 	"strconv"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/bot/assets"
 	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
 	"github.com/rgeraskin/squad-challenge-bot/internal/service"
 	tele "gopkg.in/telebot.v3"
 )
@@ -165,7 +168,7 @@ func (h *Handler) handleCreateTemplate(c tele.Context, challengeID string) error
 		return h.sendError(c, "You don't have super admin privileges.")
 	}
 
-	template, err := h.template.CreateFromChallenge(challengeID)
+	template, err := h.template.CreateFromChallenge(challengeID, userID)
 	if err != nil {
 		if err == service.ErrTemplateNameExists {
 			return c.Send(
@@ -182,6 +185,166 @@ func (h *Handler) handleCreateTemplate(c tele.Context, challengeID string) error
 	return c.Send(msg, keyboards.BackToSuperAdmin(), tele.ModeHTML)
 }
 
+// ===== Personal Templates =====
+
+// handleSaveAsMyTemplate saves the current challenge as the admin's personal template
+func (h *Handler) handleSaveAsMyTemplate(c tele.Context) error {
+	userID := c.Sender().ID
+	userState, _ := h.state.Get(userID)
+
+	template, err := h.template.SaveAsPersonal(userState.CurrentChallenge, userID, h.isSuperAdmin(userID))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotAdmin):
+			return h.sendError(c, "🔒 Sorry, only the admin can do that!")
+		case errors.Is(err, service.ErrTemplateNameExists):
+			return c.Send(
+				"⚠️ You already have a template with this name. Delete it first or rename the challenge.",
+				keyboards.BackToAdmin(),
+			)
+		case errors.Is(err, service.ErrMaxTemplatesReached):
+			return c.Send(
+				fmt.Sprintf("😬 You've hit the limit of %d personal templates. Delete one to save a new one.", domain.MaxTemplatesPerUser),
+				keyboards.BackToAdmin(),
+			)
+		}
+		logger.Error("Failed to save personal template", "user_id", userID, "challenge_id", userState.CurrentChallenge, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	taskCount, _ := h.template.GetTaskCount(template.ID)
+
+	msg := fmt.Sprintf(
+		"💾 Saved '<b>%s</b>' as your template with %d tasks!\n\nYou'll find it under <i>My templates</i> when you create your next challenge.",
+		html.EscapeString(template.Name),
+		taskCount,
+	)
+	return c.Send(msg, keyboards.BackToAdmin(), tele.ModeHTML)
+}
+
+// confirmDeletePersonalTemplate asks the owner to confirm deleting their template
+func (h *Handler) confirmDeletePersonalTemplate(c tele.Context, templateIDStr string) error {
+	userID := c.Sender().ID
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+
+	template, err := h.template.GetForUser(templateID, userID, "")
+	if err != nil || template.OwnerID != userID {
+		return h.sendError(c, "Template not found.")
+	}
+
+	msg := fmt.Sprintf(
+		"🗑 <b>Delete Template?</b>\n\nDelete '<b>%s</b>'? Challenges created from it are not affected.",
+		html.EscapeString(template.Name),
+	)
+	return c.Send(msg, keyboards.DeletePersonalTemplateConfirm(templateID), tele.ModeHTML)
+}
+
+// handleDeletePersonalTemplate deletes the user's own template
+func (h *Handler) handleDeletePersonalTemplate(c tele.Context, templateIDStr string) error {
+	userID := c.Sender().ID
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+
+	if err := h.template.DeletePersonal(templateID, userID); err != nil {
+		if errors.Is(err, service.ErrNotTemplateOwner) || errors.Is(err, service.ErrTemplateNotFound) {
+			return h.sendError(c, "Template not found.")
+		}
+		logger.Error("Failed to delete personal template", "user_id", userID, "template_id", templateID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	c.Send("✅ Template deleted.")
+	return h.showTemplatesList(c)
+}
+
+// ===== Template Sharing =====
+
+// templateLinkPrefix marks template share codes in /start payloads (t.me/bot?start=tpl_CODE)
+const templateLinkPrefix = "tpl_"
+
+// handleTemplateDeepLink opens the preview of a template shared via link
+func (h *Handler) handleTemplateDeepLink(c tele.Context, code string) error {
+	template, err := h.template.GetByShareCode(code)
+	if err != nil {
+		if errors.Is(err, service.ErrShareCodeNotFound) {
+			return h.sendError(c, "🤔 Hmm, this template link is invalid or was revoked.")
+		}
+		logger.Error("Failed to get template by share code", "code", code, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	return h.showTemplateDetails(c, strconv.FormatInt(template.ID, 10), template.ShareCode)
+}
+
+// handleShareTemplate shows the template's share link, creating a share code if needed.
+// fromAdminPanel selects the super admin template panel as the way back.
+func (h *Handler) handleShareTemplate(c tele.Context, templateIDStr string, fromAdminPanel bool) error {
+	userID := c.Sender().ID
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+
+	code, err := h.template.Share(templateID, userID, h.isSuperAdmin(userID))
+	if err != nil {
+		if errors.Is(err, service.ErrNotTemplateOwner) || errors.Is(err, service.ErrTemplateNotFound) {
+			return h.sendError(c, "Template not found.")
+		}
+		logger.Error("Failed to share template", "user_id", userID, "template_id", templateID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	template, err := h.template.GetByID(templateID)
+	if err != nil {
+		return h.sendError(c, "Template not found.")
+	}
+
+	msg := "🔗 <i>Share this template</i>\n\n"
+	msg += fmt.Sprintf("<b>Template:</b> %s\n", html.EscapeString(template.Name))
+	msg += fmt.Sprintf("<b>Share code:</b> <code>%s</code>\n\n", code)
+	msg += "Send this link:\n"
+	msg += fmt.Sprintf("<code>t.me/%s?start=%s%s</code>\n\n", h.bot.Me.Username, templateLinkPrefix, code)
+	msg += "<i>Anyone with the link can preview the template and create their own challenge from it. Revoke the link to stop sharing.</i>"
+
+	kb := keyboards.TemplateShareLink(templateID, "tpl_unshare", "tpl_select")
+	if fromAdminPanel {
+		kb = keyboards.TemplateShareLink(templateID, "sa_tpl_unshare", "sa_tpl_edit_select")
+	}
+	return c.Send(msg, kb, tele.ModeHTML)
+}
+
+// handleRevokeTemplateShare removes the template's share code so old links stop working
+func (h *Handler) handleRevokeTemplateShare(c tele.Context, templateIDStr string, fromAdminPanel bool) error {
+	userID := c.Sender().ID
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+
+	if err := h.template.RevokeShare(templateID, userID, h.isSuperAdmin(userID)); err != nil {
+		if errors.Is(err, service.ErrNotTemplateOwner) || errors.Is(err, service.ErrTemplateNotFound) {
+			return h.sendError(c, "Template not found.")
+		}
+		logger.Error("Failed to revoke template share", "user_id", userID, "template_id", templateID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	c.Send("🚫 Share link revoked. Old links no longer work.")
+	if fromAdminPanel {
+		return h.showTemplateAdminPanel(c, templateIDStr)
+	}
+	return h.showTemplateDetails(c, templateIDStr, "")
+}
+
 // ===== Super Admin - Templates Delete Flow =====
 
 // showTemplatesDeletePanel shows list of templates for deletion
@@ -256,7 +419,7 @@ func (h *Handler) handleConfirmDeleteTemplate(c tele.Context, templateIDStr stri
 		return h.sendError(c, "Invalid template ID.")
 	}
 
-	err = h.template.Delete(templateID)
+	err = h.template.Delete(templateID, userID)
 	if err != nil {
 		return h.sendError(c, "Failed to delete template.")
 	}
@@ -277,36 +440,31 @@ func (h *Handler) showTemplateOrScratchChoice(c tele.Context) error {
 	return c.Send(msg, keyboards.TemplateOrScratchChoice(), tele.ModeHTML)
 }
 
-// showTemplatesList shows available templates for selection
+// showTemplatesList shows the template catalog from its default view
 func (h *Handler) showTemplatesList(c tele.Context) error {
 	userID := c.Sender().ID
 
-	templates, err := h.template.GetAll()
+	globalCount, err := h.template.Count()
 	if err != nil {
 		return h.sendError(c, "Failed to load templates.")
 	}
 
-	if len(templates) == 0 {
-		// No templates - go directly to scratch flow
-		return h.handleFromScratch(c)
+	myTemplates, err := h.template.GetByOwner(userID)
+	if err != nil {
+		return h.sendError(c, "Failed to load templates.")
 	}
 
-	h.state.SetState(userID, domain.StateSelectTemplate)
-
-	// Build task counts map
-	taskCounts := make(map[int64]int)
-	for _, tpl := range templates {
-		count, _ := h.template.GetTaskCount(tpl.ID)
-		taskCounts[tpl.ID] = count
+	if globalCount == 0 && len(myTemplates) == 0 {
+		// No templates - go directly to scratch flow
+		return h.handleFromScratch(c)
 	}
 
-	msg := "📋 <b>Select Template</b>\n\nChoose a template for your challenge:"
-
-	return c.Send(msg, keyboards.TemplatesList(templates, taskCounts), tele.ModeHTML)
+	return h.showTemplateCatalog(c, templateCatalogView{Sort: domain.TemplateSortNewest})
 }
 
-// showTemplateDetails shows template details for user
-func (h *Handler) showTemplateDetails(c tele.Context, templateIDStr string) error {
+// showTemplateDetails shows template details for user. shareCode opens a template shared via
+// link and is carried along in the template's buttons
+func (h *Handler) showTemplateDetails(c tele.Context, templateIDStr, shareCode string) error {
 	userID := c.Sender().ID
 
 	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
@@ -314,7 +472,7 @@ func (h *Handler) showTemplateDetails(c tele.Context, templateIDStr string) erro
 		return h.sendError(c, "Invalid template ID.")
 	}
 
-	template, err := h.template.GetByID(templateID)
+	template, err := h.template.GetForUser(templateID, userID, shareCode)
 	if err != nil {
 		return h.sendError(c, "Template not found.")
 	}
@@ -341,17 +499,41 @@ func (h *Handler) showTemplateDetails(c tele.Context, templateIDStr string) erro
 	}
 
 	msg += fmt.Sprintf("<b>Tasks:</b> %d\n", taskCount)
+	if template.CategoryID > 0 {
+		if category, err := h.template.GetCategory(template.CategoryID); err == nil {
+			msg += fmt.Sprintf("<b>Category:</b> %s\n", html.EscapeString(category.Name))
+		}
+	}
+	if template.Tags != "" {
+		msg += fmt.Sprintf("<b>Tags:</b> %s\n", formatTemplateTags(template))
+	}
+	switch {
+	case template.OwnerID == userID:
+		msg += "\n<i>👤 Your personal template</i>\n"
+		if template.ShareCode != "" {
+			msg += "<i>🔗 Shared via link</i>\n"
+		}
+	case template.OwnerID != 0:
+		msg += "\n<i>🔗 Shared with you via link</i>\n"
+	}
 
-	return c.Send(msg, keyboards.TemplateDetails(templateID), tele.ModeHTML)
+	return c.Send(msg, keyboards.TemplateDetails(templateID, shareCode, template.OwnerID == userID), tele.ModeHTML)
 }
 
 // showTemplateTasksList shows template tasks (read-only)
-func (h *Handler) showTemplateTasksList(c tele.Context, templateIDStr string) error {
+func (h *Handler) showTemplateTasksList(c tele.Context, templateIDStr, shareCode string) error {
+	userID := c.Sender().ID
+
 	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
 	if err != nil {
 		return h.sendError(c, "Invalid template ID.")
 	}
 
+	template, err := h.template.GetForUser(templateID, userID, shareCode)
+	if err != nil {
+		return h.sendError(c, "Template not found.")
+	}
+
 	tasks, err := h.template.GetTasks(templateID)
 	if err != nil {
 		return h.sendError(c, "Failed to load tasks.")
@@ -360,17 +542,17 @@ func (h *Handler) showTemplateTasksList(c tele.Context, templateIDStr string) er
 	if len(tasks) == 0 {
 		return c.Send(
 			"No tasks in this template.",
-			keyboards.TemplateDetails(templateID),
+			keyboards.TemplateDetails(templateID, shareCode, template.OwnerID == userID),
 		)
 	}
 
 	msg := "📋 <b>Template Tasks</b>\n\nTasks that will be included:"
 
-	return c.Send(msg, keyboards.TemplateTasksList(tasks, templateID), tele.ModeHTML)
+	return c.Send(msg, keyboards.TemplateTasksList(tasks, templateID, shareCode), tele.ModeHTML)
 }
 
 // showTplTaskDetail shows task detail for user (template task preview)
-func (h *Handler) showTplTaskDetail(c tele.Context, templateIDStr string, taskIDStr string) error {
+func (h *Handler) showTplTaskDetail(c tele.Context, templateIDStr, taskIDStr, shareCode string) error {
 	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
 	if err != nil {
 		return h.sendError(c, "Invalid template ID.")
@@ -381,8 +563,12 @@ func (h *Handler) showTplTaskDetail(c tele.Context, templateIDStr string, taskID
 		return h.sendError(c, "Invalid task ID.")
 	}
 
+	if _, err := h.template.GetForUser(templateID, c.Sender().ID, shareCode); err != nil {
+		return h.sendError(c, "Template not found.")
+	}
+
 	task, err := h.template.GetTaskByID(taskID)
-	if err != nil || task == nil {
+	if err != nil || task == nil || task.TemplateID != templateID {
 		return h.sendError(c, "Task not found.")
 	}
 
@@ -392,7 +578,7 @@ func (h *Handler) showTplTaskDetail(c tele.Context, templateIDStr string, taskID
 		msg += fmt.Sprintf("\n%s\n", task.Description)
 	}
 
-	kb := keyboards.BackToTplTasks(templateID)
+	kb := keyboards.BackToTplTasks(templateID, shareCode)
 
 	if task.ImageFileID != "" {
 		photo := &tele.Photo{
@@ -409,7 +595,7 @@ func (h *Handler) showTplTaskDetail(c tele.Context, templateIDStr string, taskID
 }
 
 // handleCreateFromTemplate starts the template-based challenge creation
-func (h *Handler) handleCreateFromTemplate(c tele.Context, templateIDStr string) error {
+func (h *Handler) handleCreateFromTemplate(c tele.Context, templateIDStr, shareCode string) error {
 	userID := c.Sender().ID
 
 	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
@@ -417,7 +603,7 @@ func (h *Handler) handleCreateFromTemplate(c tele.Context, templateIDStr string)
 		return h.sendError(c, "Invalid template ID.")
 	}
 
-	template, err := h.template.GetByID(templateID)
+	template, err := h.template.GetForUser(templateID, userID, shareCode)
 	if err != nil {
 		return h.sendError(c, "Template not found.")
 	}
@@ -454,7 +640,7 @@ func (h *Handler) handleFromScratch(c tele.Context) error {
 func (h *Handler) processTemplateChallengeName(c tele.Context, name string) error {
 	userID := c.Sender().ID
 
-	if len(name) == 0 || len(name) > 50 {
+	if len(name) == 0 || len(name) > domain.MaxChallengeNameLength {
 		return c.Send("😬 Keep it between 1-50 characters, please!", keyboards.CancelOnly())
 	}
 
@@ -662,6 +848,22 @@ func (h *Handler) showTemplateAdminPanel(c tele.Context, templateIDStr string) e
 	} else {
 		msg += "<b>Mode:</b> All Visible\n"
 	}
+	category := "Uncategorized"
+	if template.CategoryID > 0 {
+		if cat, err := h.template.GetCategory(template.CategoryID); err == nil {
+			category = html.EscapeString(cat.Name)
+		}
+	}
+	msg += fmt.Sprintf("<b>Category:</b> %s\n", category)
+	if template.Tags != "" {
+		msg += fmt.Sprintf("<b>Tags:</b> %s\n", formatTemplateTags(template))
+	} else {
+		msg += "<b>Tags:</b>\n"
+	}
+	msg += fmt.Sprintf("<b>Used:</b> %d challenges\n", template.UseCount)
+	if version := h.currentTemplateVersion(templateID); version > 0 {
+		msg += fmt.Sprintf("<b>Version:</b> %d\n", version)
+	}
 
 	return c.Send(
 		msg,
@@ -701,7 +903,7 @@ func (h *Handler) handleEditTemplateName(c tele.Context, templateIDStr string) e
 func (h *Handler) processNewTemplateName(c tele.Context, name string) error {
 	userID := c.Sender().ID
 
-	if len(name) == 0 || len(name) > 50 {
+	if len(name) == 0 || len(name) > domain.MaxChallengeNameLength {
 		return c.Send("😬 Keep it between 1-50 characters, please!", keyboards.CancelOnly())
 	}
 
@@ -709,7 +911,7 @@ func (h *Handler) processNewTemplateName(c tele.Context, name string) error {
 	h.state.GetTempData(userID, &tempData)
 	templateID := int64(tempData[TempKeyTemplateID].(float64))
 
-	err := h.template.UpdateName(templateID, name)
+	err := h.template.UpdateName(templateID, name, userID)
 	if err != nil {
 		return h.sendError(c, "Failed to update name.")
 	}
@@ -757,7 +959,7 @@ func (h *Handler) handleEditTemplateDescription(c tele.Context, templateIDStr st
 func (h *Handler) processNewTemplateDescription(c tele.Context, description string) error {
 	userID := c.Sender().ID
 
-	if len(description) > 500 {
+	if len(description) > domain.MaxChallengeDescriptionLength {
 		return c.Send("😬 Keep it under 500 characters, please!", keyboards.CancelOnly())
 	}
 
@@ -765,7 +967,7 @@ func (h *Handler) processNewTemplateDescription(c tele.Context, description stri
 	h.state.GetTempData(userID, &tempData)
 	templateID := int64(tempData[TempKeyTemplateID].(float64))
 
-	err := h.template.UpdateDescription(templateID, description)
+	err := h.template.UpdateDescription(templateID, description, userID)
 	if err != nil {
 		return h.sendError(c, "Failed to update description.")
 	}
@@ -822,7 +1024,7 @@ func (h *Handler) processNewTemplateDailyLimit(c tele.Context, input string) err
 	h.state.GetTempData(userID, &tempData)
 	templateID := int64(tempData[TempKeyTemplateID].(float64))
 
-	err = h.template.UpdateDailyLimit(templateID, limit)
+	err = h.template.UpdateDailyLimit(templateID, limit, userID)
 	if err != nil {
 		return h.sendError(c, "Failed to update daily limit.")
 	}
@@ -851,7 +1053,7 @@ func (h *Handler) handleToggleTemplateHideFutureTasks(c tele.Context, templateID
 	}
 
 	newValue := !template.HideFutureTasks
-	err = h.template.UpdateHideFutureTasks(templateID, newValue)
+	err = h.template.UpdateHideFutureTasks(templateID, newValue, userID)
 	if err != nil {
 		return h.sendError(c, "Failed to update setting.")
 	}
@@ -1010,7 +1212,7 @@ func (h *Handler) createTemplateTask(c tele.Context, description string) error {
 		ImageFileID: imageFileID,
 	}
 
-	err := h.template.CreateTask(task)
+	err := h.template.CreateTask(task, userID)
 	if err != nil {
 		h.state.Reset(userID)
 		return h.sendError(c, "Failed to create task.")
@@ -1105,7 +1307,7 @@ func (h *Handler) processTplEditTitle(c tele.Context, title string) error {
 	templateID := int64(tempData[TempKeyTemplateID].(float64))
 	taskID := int64(tempData[TempKeyTaskID].(float64))
 
-	err := h.template.UpdateTaskTitle(taskID, title)
+	err := h.template.UpdateTaskTitle(taskID, title, userID)
 	if err != nil {
 		return h.sendError(c, "Failed to update title.")
 	}
@@ -1174,7 +1376,7 @@ func (h *Handler) processTplEditDescription(c tele.Context, description string)
 	templateID := int64(tempData[TempKeyTemplateID].(float64))
 	taskID := int64(tempData[TempKeyTaskID].(float64))
 
-	err := h.template.UpdateTaskDescription(taskID, description)
+	err := h.template.UpdateTaskDescription(taskID, description, userID)
 	if err != nil {
 		return h.sendError(c, "Failed to update description.")
 	}
@@ -1224,7 +1426,7 @@ func (h *Handler) processTplEditImage(c tele.Context, imageFileID string) error
 	templateID := int64(tempData[TempKeyTemplateID].(float64))
 	taskID := int64(tempData[TempKeyTaskID].(float64))
 
-	err := h.template.UpdateTaskImage(taskID, imageFileID)
+	err := h.template.UpdateTaskImage(taskID, imageFileID, userID)
 	if err != nil {
 		return h.sendError(c, "Failed to update image.")
 	}
@@ -1285,7 +1487,7 @@ func (h *Handler) handleConfirmDeleteTemplateTask(
 		return h.sendError(c, "Invalid task ID.")
 	}
 
-	err = h.template.DeleteTask(taskID, templateID)
+	err = h.template.DeleteTask(taskID, templateID, userID)
 	if err != nil {
 		return h.sendError(c, "Failed to delete task.")
 	}
@@ -1385,7 +1587,7 @@ func (h *Handler) handleTplReorderMove(
 		return h.sendError(c, "Invalid position.")
 	}
 
-	if err := h.template.MoveTask(taskID, templateID, newPos); err != nil {
+	if err := h.template.MoveTask(taskID, templateID, newPos, userID); err != nil {
 		return h.sendError(c, "Failed to move task.")
 	}
 
@@ -1416,7 +1618,7 @@ func (h *Handler) handleTplRandomize(c tele.Context, templateIDStr string) error
 		return h.sendError(c, "Invalid template ID.")
 	}
 
-	err = h.template.RandomizeTaskOrder(templateID)
+	err = h.template.RandomizeTaskOrder(templateID, userID)
 	if err != nil {
 		return h.sendError(c, "Failed to randomize tasks.")
 	}
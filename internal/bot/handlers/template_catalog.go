@@ -0,0 +1,443 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+	tele "gopkg.in/telebot.v3"
+)
+
+// maxTemplateSearchLength is the maximum character length of a template search query
+const maxTemplateSearchLength = 50
+
+// templateCatalogView is the state of the template catalog: sort order, category,
+// page and whether the stored search query applies
+type templateCatalogView struct {
+	Sort       domain.TemplateSort
+	CategoryID int64
+	Page       int
+	Search     bool
+}
+
+// parseTemplateCatalogView parses "sort|category|page|search" callback arguments
+func parseTemplateCatalogView(args []string) templateCatalogView {
+	view := templateCatalogView{Sort: domain.TemplateSortNewest}
+	if len(args) > 0 && domain.TemplateSort(args[0]) == domain.TemplateSortPopular {
+		view.Sort = domain.TemplateSortPopular
+	}
+	if len(args) > 1 {
+		view.CategoryID, _ = strconv.ParseInt(args[1], 10, 64)
+	}
+	if len(args) > 2 {
+		view.Page, _ = strconv.Atoi(args[2])
+	}
+	if len(args) > 3 {
+		view.Search = args[3] == "1"
+	}
+	return view
+}
+
+// encode returns the view in the same form as its callback arguments
+func (v templateCatalogView) encode() string {
+	search := "0"
+	if v.Search {
+		search = "1"
+	}
+	return fmt.Sprintf("%s|%d|%d|%s", v.Sort, v.CategoryID, v.Page, search)
+}
+
+// lastTemplateCatalogView returns the catalog view the user saw last, so "Back" from a
+// template preview returns to the same page, category or search
+func (h *Handler) lastTemplateCatalogView(userID int64) templateCatalogView {
+	var tempData map[string]any
+	h.state.GetTempData(userID, &tempData)
+	encoded, _ := tempData[TempKeyTemplateView].(string)
+	return parseTemplateCatalogView(strings.Split(encoded, "|"))
+}
+
+// setTemplateCatalogState sets the state, merging the catalog keys into the temp data
+func (h *Handler) setTemplateCatalogState(userID int64, state string, view templateCatalogView, query *string) {
+	var tempData map[string]any
+	h.state.GetTempData(userID, &tempData)
+	if tempData == nil {
+		tempData = make(map[string]any)
+	}
+	tempData[TempKeyTemplateView] = view.encode()
+	if query != nil {
+		tempData[TempKeyTemplateQuery] = *query
+	}
+	h.state.SetStateWithData(userID, state, tempData)
+}
+
+// showTemplateCatalog shows one page of the template catalog
+func (h *Handler) showTemplateCatalog(c tele.Context, view templateCatalogView) error {
+	userID := c.Sender().ID
+
+	filter := domain.TemplateFilter{CategoryID: view.CategoryID, Sort: view.Sort}
+	if view.Search {
+		var tempData map[string]any
+		h.state.GetTempData(userID, &tempData)
+		filter.Query, _ = tempData[TempKeyTemplateQuery].(string)
+		view.Search = filter.Query != ""
+	}
+
+	templates, totalPages, err := h.template.GetCatalogPage(filter, view.Page)
+	if err != nil {
+		logger.Error("Failed to load template catalog", "user_id", userID, "error", err)
+		return h.sendError(c, "Failed to load templates.")
+	}
+	if view.Page >= totalPages {
+		view.Page = totalPages - 1
+	}
+	if view.Page < 0 {
+		view.Page = 0
+	}
+
+	// Personal templates are shown on the first page of the unfiltered catalog
+	var myTemplates []*domain.Template
+	filtered := view.CategoryID > 0 || view.Search
+	if !filtered && view.Page == 0 {
+		myTemplates, err = h.template.GetByOwner(userID)
+		if err != nil {
+			return h.sendError(c, "Failed to load templates.")
+		}
+	}
+
+	h.setTemplateCatalogState(userID, domain.StateSelectTemplate, view, nil)
+
+	// Build task counts map
+	taskCounts := make(map[int64]int)
+	for _, tpl := range append(templates, myTemplates...) {
+		count, _ := h.template.GetTaskCount(tpl.ID)
+		taskCounts[tpl.ID] = count
+	}
+
+	msg := "📋 <b>Select Template</b>\n\n"
+	if view.CategoryID > 0 {
+		if category, err := h.template.GetCategory(view.CategoryID); err == nil {
+			msg += fmt.Sprintf("🗂 Category: <b>%s</b>\n", html.EscapeString(category.Name))
+		}
+	}
+	if view.Search {
+		msg += fmt.Sprintf("🔍 Results for <b>%s</b>\n", html.EscapeString(filter.Query))
+	}
+	if view.Sort == domain.TemplateSortPopular {
+		msg += "<i>Most popular first</i>\n\n"
+	} else {
+		msg += "<i>Newest first</i>\n\n"
+	}
+	if len(templates) == 0 && len(myTemplates) == 0 {
+		msg += "Nothing found. Try another search or category."
+	} else {
+		msg += "Choose a template for your challenge:"
+	}
+
+	nav := keyboards.TemplateListNav{
+		Sort:       string(view.Sort),
+		CategoryID: view.CategoryID,
+		Search:     view.Search,
+		Page:       view.Page,
+		TotalPages: totalPages,
+	}
+	return c.Send(msg, keyboards.TemplatesList(templates, myTemplates, taskCounts, nav), tele.ModeHTML)
+}
+
+// showTemplateCategories shows the category browser of the template catalog
+func (h *Handler) showTemplateCategories(c tele.Context, sort string) error {
+	categories, err := h.template.GetCategories()
+	if err != nil {
+		return h.sendError(c, "Failed to load categories.")
+	}
+	counts, err := h.template.CountByCategory()
+	if err != nil {
+		return h.sendError(c, "Failed to load categories.")
+	}
+
+	msg := "🗂 <b>Categories</b>\n\nPick a category:"
+	nonEmpty := 0
+	for _, category := range categories {
+		if counts[category.ID] > 0 {
+			nonEmpty++
+		}
+	}
+	if nonEmpty == 0 {
+		msg = "🗂 <b>Categories</b>\n\nNo categories yet."
+	}
+
+	return c.Send(msg, keyboards.TemplateCategories(categories, counts, sort), tele.ModeHTML)
+}
+
+// handleTemplateSearch asks for a template search query
+func (h *Handler) handleTemplateSearch(c tele.Context, sort string) error {
+	userID := c.Sender().ID
+
+	view := parseTemplateCatalogView([]string{sort})
+	h.setTemplateCatalogState(userID, domain.StateAwaitingTemplateSearch, view, nil)
+
+	msg := "🔍 <b>Search Templates</b>\n\nSend a word to look for in template names, descriptions and tags:"
+	return c.Send(msg, keyboards.TemplateSearchPrompt(string(view.Sort)), tele.ModeHTML)
+}
+
+// processTemplateSearch shows the catalog filtered by the search query
+func (h *Handler) processTemplateSearch(c tele.Context, query string) error {
+	userID := c.Sender().ID
+
+	query = strings.TrimSpace(query)
+	view := h.lastTemplateCatalogView(userID)
+	if query == "" || utf8.RuneCountInString(query) > maxTemplateSearchLength {
+		return c.Send(
+			fmt.Sprintf("😬 Keep it between 1-%d characters, please!", maxTemplateSearchLength),
+			keyboards.TemplateSearchPrompt(string(view.Sort)),
+		)
+	}
+
+	view = templateCatalogView{Sort: view.Sort, Search: true}
+	h.setTemplateCatalogState(userID, domain.StateSelectTemplate, view, &query)
+	return h.showTemplateCatalog(c, view)
+}
+
+// ===== Super Admin - Template Categories and Tags =====
+
+// showTemplateCategoryPicker shows the category choice for a template
+func (h *Handler) showTemplateCategoryPicker(c tele.Context, templateIDStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+
+	template, err := h.template.GetByID(templateID)
+	if err != nil {
+		return h.sendError(c, "Template not found.")
+	}
+
+	categories, err := h.template.GetCategories()
+	if err != nil {
+		return h.sendError(c, "Failed to load categories.")
+	}
+
+	msg := fmt.Sprintf(
+		"🗂 <b>Category</b>\n\n<b>Template:</b> %s\n\nPick a category or create a new one:",
+		html.EscapeString(template.Name),
+	)
+	return c.Send(
+		msg,
+		keyboards.TemplateCategoryPicker(templateID, categories, template.CategoryID),
+		tele.ModeHTML,
+	)
+}
+
+// handleSetTemplateCategory assigns a template to a category
+func (h *Handler) handleSetTemplateCategory(c tele.Context, templateIDStr, categoryIDStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+	categoryID, err := strconv.ParseInt(categoryIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid category ID.")
+	}
+
+	if err := h.template.SetCategory(templateID, categoryID); err != nil {
+		if errors.Is(err, service.ErrCategoryNotFound) {
+			return h.sendError(c, "Category not found.")
+		}
+		return h.sendError(c, "Failed to update category.")
+	}
+
+	c.Send("✅ Category updated!")
+	return h.showTemplateAdminPanel(c, templateIDStr)
+}
+
+// handleNewTemplateCategory asks for the name of a new category
+func (h *Handler) handleNewTemplateCategory(c tele.Context, templateIDStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+
+	tempData := map[string]interface{}{
+		TempKeyTemplateID: templateID,
+	}
+	h.state.SetStateWithData(userID, domain.StateAwaitingTemplateCategoryName, tempData)
+
+	msg := "🗂 <b>New Category</b>\n\nEnter the category name. The template will be moved to it:"
+	return c.Send(msg, keyboards.BackToTemplateAdmin(templateID), tele.ModeHTML)
+}
+
+// processTemplateCategoryName creates a category and assigns the template to it
+func (h *Handler) processTemplateCategoryName(c tele.Context, name string) error {
+	userID := c.Sender().ID
+
+	var tempData map[string]interface{}
+	h.state.GetTempData(userID, &tempData)
+	templateID := int64(tempData[TempKeyTemplateID].(float64))
+
+	category, err := h.template.CreateCategory(name)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidCategoryName):
+			return c.Send(
+				fmt.Sprintf("😬 Keep it between 1-%d characters, please!", domain.MaxCategoryNameLength),
+				keyboards.CancelOnly(),
+			)
+		case errors.Is(err, service.ErrCategoryExists):
+			return c.Send("⚠️ A category with this name already exists. Try another name:", keyboards.CancelOnly())
+		}
+		return h.sendError(c, "Failed to create category.")
+	}
+
+	if err := h.template.SetCategory(templateID, category.ID); err != nil {
+		return h.sendError(c, "Failed to update category.")
+	}
+
+	h.state.Reset(userID)
+	c.Send(fmt.Sprintf("✅ Category '%s' created!", category.Name))
+	return h.showTemplateAdminPanel(c, fmt.Sprintf("%d", templateID))
+}
+
+// confirmDeleteTemplateCategory asks to confirm deleting a category
+func (h *Handler) confirmDeleteTemplateCategory(c tele.Context, templateIDStr, categoryIDStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+	categoryID, err := strconv.ParseInt(categoryIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid category ID.")
+	}
+
+	category, err := h.template.GetCategory(categoryID)
+	if err != nil {
+		return h.sendError(c, "Category not found.")
+	}
+
+	msg := fmt.Sprintf(
+		"🗑 <b>Delete Category?</b>\n\nDelete '<b>%s</b>'? Its templates are kept and become uncategorized.",
+		html.EscapeString(category.Name),
+	)
+	return c.Send(msg, keyboards.DeleteCategoryConfirm(templateID, categoryID), tele.ModeHTML)
+}
+
+// handleDeleteTemplateCategory deletes a category
+func (h *Handler) handleDeleteTemplateCategory(c tele.Context, templateIDStr, categoryIDStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	categoryID, err := strconv.ParseInt(categoryIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid category ID.")
+	}
+
+	if err := h.template.DeleteCategory(categoryID); err != nil {
+		logger.Error("Failed to delete template category", "category_id", categoryID, "error", err)
+		return h.sendError(c, "Failed to delete category.")
+	}
+
+	c.Send("✅ Category deleted.")
+	return h.showTemplateCategoryPicker(c, templateIDStr)
+}
+
+// handleEditTemplateTags asks for the template tags
+func (h *Handler) handleEditTemplateTags(c tele.Context, templateIDStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+
+	template, err := h.template.GetByID(templateID)
+	if err != nil {
+		return h.sendError(c, "Template not found.")
+	}
+
+	tempData := map[string]interface{}{
+		TempKeyTemplateID: templateID,
+	}
+	h.state.SetStateWithData(userID, domain.StateAwaitingTemplateTags, tempData)
+
+	current := "none"
+	if template.Tags != "" {
+		current = html.EscapeString(strings.Join(template.TagList(), ", "))
+	}
+	msg := fmt.Sprintf(
+		"🏷 <b>Edit Tags</b>\n\nCurrent: %s\n\nSend tags separated by commas (e.g. <i>fitness, morning, beginner</i>), or <code>-</code> to clear them:",
+		current,
+	)
+	return c.Send(msg, keyboards.BackToTemplateAdmin(templateID), tele.ModeHTML)
+}
+
+// processTemplateTags stores the template tags
+func (h *Handler) processTemplateTags(c tele.Context, input string) error {
+	userID := c.Sender().ID
+
+	var tempData map[string]interface{}
+	h.state.GetTempData(userID, &tempData)
+	templateID := int64(tempData[TempKeyTemplateID].(float64))
+
+	if _, err := h.template.SetTags(templateID, input); err != nil {
+		if errors.Is(err, service.ErrInvalidTags) {
+			return c.Send(
+				fmt.Sprintf(
+					"😬 Up to %d tags, each up to %d characters, please!",
+					domain.MaxTemplateTags,
+					domain.MaxTemplateTagLength,
+				),
+				keyboards.CancelOnly(),
+			)
+		}
+		return h.sendError(c, "Failed to update tags.")
+	}
+
+	h.state.Reset(userID)
+	c.Send("✅ Template tags updated!")
+	return h.showTemplateAdminPanel(c, fmt.Sprintf("%d", templateID))
+}
+
+// formatTemplateTags formats tags as hashtags for display
+func formatTemplateTags(template *domain.Template) string {
+	tags := template.TagList()
+	for i, tag := range tags {
+		tags[i] = "#" + html.EscapeString(tag)
+	}
+	return strings.Join(tags, " ")
+}
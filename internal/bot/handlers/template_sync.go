@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/views"
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+	tele "gopkg.in/telebot.v3"
+)
+
+// templateUpdateData converts a template diff into view data
+func (h *Handler) templateUpdateData(diff *service.TemplateSyncDiff) views.TemplateUpdateData {
+	data := views.TemplateUpdateData{
+		ChallengeName: diff.Challenge.Name,
+		TemplateName:  diff.Template.Name,
+		FromVersion:   diff.Challenge.TemplateVersion,
+		ToVersion:     h.currentTemplateVersion(diff.Template.ID),
+	}
+	for _, tt := range diff.Added {
+		data.Added = append(data.Added, tt.Title)
+	}
+	for _, change := range diff.Changed {
+		data.Changed = append(data.Changed, views.TemplateUpdateChange{
+			OrderNum:           change.Task.OrderNum,
+			OldTitle:           change.Task.Title,
+			NewTitle:           change.TemplateTask.Title,
+			DescriptionChanged: change.DescriptionChanged(),
+		})
+	}
+	return data
+}
+
+// showTemplatePushPreview shows the pending changes of every challenge created from a template
+func (h *Handler) showTemplatePushPreview(c tele.Context, templateIDStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+
+	diffs, err := h.template.PreviewPush(templateID)
+	if err != nil {
+		if errors.Is(err, service.ErrTemplateNotFound) {
+			return h.sendError(c, "Template not found.")
+		}
+		logger.Error("Failed to preview template push", "template_id", templateID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	template, err := h.template.GetByID(templateID)
+	if err != nil {
+		return h.sendError(c, "Template not found.")
+	}
+
+	items := make([]views.TemplatePushItem, 0, len(diffs))
+	pending := 0
+	for _, diff := range diffs {
+		items = append(items, views.TemplatePushItem{
+			ChallengeName: diff.Challenge.Name,
+			ChallengeID:   diff.Challenge.ID,
+			Added:         len(diff.Added),
+			Changed:       len(diff.Changed),
+		})
+		if !diff.Empty() {
+			pending++
+		}
+	}
+
+	msg := views.RenderTemplatePush(template.Name, items)
+	return c.Send(msg, keyboards.TemplatePushPreview(templateID, pending), tele.ModeHTML)
+}
+
+// handleTemplatePushConfirm sends the update preview to the admin of each challenge with pending changes
+func (h *Handler) handleTemplatePushConfirm(c tele.Context, templateIDStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+
+	diffs, err := h.template.PreviewPush(templateID)
+	if err != nil {
+		logger.Error("Failed to preview template push", "template_id", templateID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	sent := 0
+	for _, diff := range diffs {
+		if diff.Empty() {
+			continue
+		}
+		msg := views.RenderTemplateUpdate(h.templateUpdateData(diff)) +
+			"\n\nApply it now or later from the admin panel (🔄 Template Updates)."
+		h.notification.NotifyTemplateUpdate(
+			diff.Challenge.CreatorID,
+			msg,
+			keyboards.TemplateUpdateOffer(diff.Challenge.ID, false),
+		)
+		sent++
+	}
+
+	logger.Info("Template update offered", "template_id", templateID, "challenges", sent)
+	return c.Send(
+		fmt.Sprintf("📨 Update offered to the admins of %d challenges.", sent),
+		keyboards.BackToTemplateAdmin(templateID),
+	)
+}
+
+// showTemplateUpdatePreview shows the pending template update for the current challenge
+func (h *Handler) showTemplateUpdatePreview(c tele.Context) error {
+	userID := c.Sender().ID
+	userState, _ := h.state.Get(userID)
+	challengeID := userState.CurrentChallenge
+
+	diff, err := h.template.DiffChallenge(challengeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrChallengeNotLinked):
+			return c.Send("This challenge wasn't created from a template.", keyboards.BackToAdmin())
+		case errors.Is(err, service.ErrTemplateNotFound):
+			return c.Send("🤷 The template this challenge was created from no longer exists.", keyboards.BackToAdmin())
+		}
+		logger.Error("Failed to diff challenge with template", "challenge_id", challengeID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	if diff.Empty() {
+		return c.Send(
+			fmt.Sprintf("✅ Up to date with the template <b>%s</b>.", html.EscapeString(diff.Template.Name)),
+			keyboards.BackToAdmin(),
+			tele.ModeHTML,
+		)
+	}
+
+	msg := views.RenderTemplateUpdate(h.templateUpdateData(diff))
+	return c.Send(msg, keyboards.TemplateUpdateOffer(challengeID, true), tele.ModeHTML)
+}
+
+// handleApplyTemplateUpdate applies the pending template update to a challenge
+func (h *Handler) handleApplyTemplateUpdate(c tele.Context, challengeID string) error {
+	userID := c.Sender().ID
+
+	diff, err := h.template.ApplyUpdate(challengeID, userID, h.isSuperAdmin(userID))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotAdmin):
+			return h.sendError(c, "🔒 Sorry, only the admin can do that!")
+		case errors.Is(err, service.ErrMaxTasksReached):
+			return h.sendError(c, fmt.Sprintf(
+				"⚠️ The new tasks would exceed the limit of %d tasks per challenge.",
+				domain.MaxTasksPerChallenge,
+			))
+		case errors.Is(err, service.ErrChallengeNotFound):
+			return h.sendError(c, "🤔 Hmm, can't find that challenge.")
+		case errors.Is(err, service.ErrTemplateNotFound):
+			return h.sendError(c, "🤷 The template this challenge was created from no longer exists.")
+		}
+		logger.Error("Failed to apply template update", "challenge_id", challengeID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	if diff.Empty() {
+		return c.Send("✅ Already up to date — nothing to apply.")
+	}
+
+	logger.Info("Template update applied",
+		"challenge_id", challengeID,
+		"template_id", diff.Template.ID,
+		"added", len(diff.Added),
+		"changed", len(diff.Changed),
+	)
+
+	h.state.SetCurrentChallenge(userID, challengeID)
+	c.Send(fmt.Sprintf(
+		"✅ Update applied: %d new tasks, %d edited. Everyone's progress is kept.",
+		len(diff.Added),
+		len(diff.Changed),
+	))
+	return h.showAdminPanel(c, challengeID)
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/views"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+	tele "gopkg.in/telebot.v3"
+)
+
+// currentTemplateVersion returns the latest version number of a template (0 if none was recorded)
+func (h *Handler) currentTemplateVersion(templateID int64) int {
+	latest, err := h.template.GetLatestVersion(templateID)
+	if err != nil || latest == nil {
+		return 0
+	}
+	return latest.Version
+}
+
+// showTemplateVersions shows a page of the template's version history
+func (h *Handler) showTemplateVersions(c tele.Context, templateIDStr, pageStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	templateID, err := strconv.ParseInt(templateIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid template ID.")
+	}
+	page, _ := strconv.Atoi(pageStr)
+
+	template, err := h.template.GetByID(templateID)
+	if err != nil {
+		return h.sendError(c, "Template not found.")
+	}
+
+	versions, totalPages, err := h.template.GetVersionsPage(templateID, page)
+	if err != nil {
+		logger.Error("Failed to get template versions", "template_id", templateID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+
+	current := h.currentTemplateVersion(templateID)
+
+	msg := fmt.Sprintf("🕘 <b>History of \"%s\"</b>\n\n", html.EscapeString(template.Name))
+	if len(versions) == 0 {
+		msg += "No versions recorded yet."
+	} else {
+		for _, v := range versions {
+			msg += fmt.Sprintf("v%d · %s UTC · by %s\n",
+				v.Version, v.CreatedAt.UTC().Format("2006-01-02 15:04"), views.FormatEditor(v.EditorID))
+		}
+		msg += "\nA version is saved after every edit. Tap one to view or restore it."
+	}
+
+	return c.Send(msg, keyboards.TemplateVersions(templateID, versions, current, page, totalPages), tele.ModeHTML)
+}
+
+// showTemplateVersion shows a version's settings and task list
+func (h *Handler) showTemplateVersion(c tele.Context, versionIDStr, pageStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	versionID, err := strconv.ParseInt(versionIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid version ID.")
+	}
+	page, _ := strconv.Atoi(pageStr)
+
+	version, err := h.template.GetVersion(versionID)
+	if err != nil {
+		return h.sendError(c, "Version not found.")
+	}
+
+	tasks, err := h.template.GetVersionTasks(versionID)
+	if err != nil {
+		logger.Error("Failed to get template version tasks", "version_id", versionID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	isCurrent := version.Version == h.currentTemplateVersion(version.TemplateID)
+	msg := views.RenderTemplateVersion(version, tasks, isCurrent)
+	return c.Send(msg, keyboards.TemplateVersionView(version, page, isCurrent), tele.ModeHTML)
+}
+
+// confirmRestoreTemplateVersion asks before restoring a template version
+func (h *Handler) confirmRestoreTemplateVersion(c tele.Context, versionIDStr, pageStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	versionID, err := strconv.ParseInt(versionIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid version ID.")
+	}
+	page, _ := strconv.Atoi(pageStr)
+
+	version, err := h.template.GetVersion(versionID)
+	if err != nil {
+		return h.sendError(c, "Version not found.")
+	}
+
+	msg := fmt.Sprintf(
+		"♻️ Restore <b>%s</b> to version %d?\n\n"+
+			"The name, settings and task list go back to how they were. "+
+			"The current state stays in the history, so you can undo this.\n\n"+
+			"Challenges created from this template are not changed until their admins apply an update.",
+		html.EscapeString(version.Name), version.Version,
+	)
+	return c.Send(msg, keyboards.RestoreTemplateVersionConfirm(versionID, page), tele.ModeHTML)
+}
+
+// handleRestoreTemplateVersion restores a template version
+func (h *Handler) handleRestoreTemplateVersion(c tele.Context, versionIDStr string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	versionID, err := strconv.ParseInt(versionIDStr, 10, 64)
+	if err != nil {
+		return h.sendError(c, "Invalid version ID.")
+	}
+
+	version, err := h.template.GetVersion(versionID)
+	if err != nil {
+		return h.sendError(c, "Version not found.")
+	}
+
+	restored, err := h.template.RestoreVersion(versionID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTemplateNameExists):
+			return h.sendError(c, "Another template already uses this version's name. Rename it first.")
+		case errors.Is(err, service.ErrTemplateNotFound):
+			return h.sendError(c, "Template not found.")
+		}
+		logger.Error("Failed to restore template version", "version_id", versionID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	logger.Info("Template version restored",
+		"template_id", version.TemplateID,
+		"restored_version", version.Version,
+		"new_version", restored.Version,
+		"user_id", userID,
+	)
+
+	c.Send(fmt.Sprintf("✅ Restored version %d (saved as version %d).", version.Version, restored.Version))
+	return h.showTemplateAdminPanel(c, strconv.FormatInt(version.TemplateID, 10))
+}
@@ -44,6 +44,12 @@ func (h *Handler) HandleText(c tele.Context) error {
 	case domain.StateAwaitingEditDescription:
 		return h.processEditDescription(c, text)
 
+	// Task comments
+	case domain.StateAwaitingTaskComment:
+		return h.processTaskComment(c, text)
+	case domain.StateAwaitingBulkTasks:
+		return h.processBulkTasks(c, text)
+
 	// Joining challenge
 	case domain.StateAwaitingChallengeID:
 		return h.processChallengeID(c, text)
@@ -90,6 +96,8 @@ func (h *Handler) HandleText(c tele.Context) error {
 		return c.Send("🎨 Just one emoji please!")
 	case domain.StateAwaitingTemplateCreatorSyncTime:
 		return h.processTemplateCreatorSyncTime(c, text)
+	case domain.StateAwaitingTemplateSearch:
+		return h.processTemplateSearch(c, text)
 
 	// Template admin editing (Super Admin)
 	case domain.StateAwaitingNewTemplateName:
@@ -100,6 +108,12 @@ func (h *Handler) HandleText(c tele.Context) error {
 		return h.processNewTemplateDailyLimit(c, text)
 	case domain.StateAwaitingTplTaskTitle:
 		return h.processTplTaskTitle(c, text)
+	case domain.StateAwaitingTplBulkTasks:
+		return h.processTplBulkTasks(c, text)
+	case domain.StateAwaitingTemplateCategoryName:
+		return h.processTemplateCategoryName(c, text)
+	case domain.StateAwaitingTemplateTags:
+		return h.processTemplateTags(c, text)
 	case domain.StateAwaitingTplTaskDescription:
 		if text == "skip" || text == "Skip" {
 			return h.skipTplTaskDescription(c)
@@ -155,3 +169,27 @@ func (h *Handler) HandlePhoto(c tele.Context) error {
 		return nil
 	}
 }
+
+// HandleDocument handles document uploads (portable challenge/template imports)
+func (h *Handler) HandleDocument(c tele.Context) error {
+	userID := c.Sender().ID
+
+	userState, err := h.state.Get(userID)
+	if err != nil {
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	doc := c.Message().Document
+	if doc == nil {
+		return nil
+	}
+
+	switch userState.State {
+	case domain.StateAwaitingImportTasks:
+		return h.processImportTasks(c, doc)
+	case domain.StateAwaitingImportTemplate:
+		return h.processImportTemplate(c, doc)
+	default:
+		return c.Send("📄 To import a file, use 📥 Import Tasks in the admin panel first.")
+	}
+}
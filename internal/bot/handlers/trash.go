@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/bot/keyboards"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+	tele "gopkg.in/telebot.v3"
+)
+
+// showTaskTrash shows the deleted tasks of the current challenge
+func (h *Handler) showTaskTrash(c tele.Context) error {
+	userID := c.Sender().ID
+	userState, _ := h.state.Get(userID)
+	if userState == nil || userState.CurrentChallenge == "" {
+		return h.sendError(c, "Challenge not found.")
+	}
+	challengeID := userState.CurrentChallenge
+
+	tasks, err := h.trash.GetTasks(challengeID)
+	if err != nil {
+		logger.Error("Failed to get trashed tasks", "challenge_id", challengeID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	msg := "🗑 <b>Trash</b>\n\n"
+	if len(tasks) == 0 {
+		msg += fmt.Sprintf("Nothing here. Deleted tasks stay here for %d days before they're gone for good.", h.trash.RetentionDays())
+		return c.Send(msg, keyboards.TaskTrash(nil), tele.ModeHTML)
+	}
+	for _, t := range tasks {
+		msg += fmt.Sprintf("• <b>%s</b> — deleted %s, purged %s\n",
+			html.EscapeString(t.Title),
+			t.DeletedAt.UTC().Format("Jan 2"),
+			h.trash.PurgeAt(*t.DeletedAt).UTC().Format("Jan 2"),
+		)
+	}
+	msg += "\nTap a task to restore it with everyone's progress."
+
+	return c.Send(msg, keyboards.TaskTrash(tasks), tele.ModeHTML)
+}
+
+// handleRestoreTask restores a deleted task of the current challenge
+func (h *Handler) handleRestoreTask(c tele.Context, taskIDStr string) error {
+	userID := c.Sender().ID
+	taskID, _ := strconv.ParseInt(taskIDStr, 10, 64)
+
+	userState, _ := h.state.Get(userID)
+	if userState == nil || userState.CurrentChallenge == "" {
+		return h.sendError(c, "Challenge not found.")
+	}
+	challengeID := userState.CurrentChallenge
+
+	task, err := h.trash.RestoreTask(taskID, challengeID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrTaskNotFound):
+			return h.sendError(c, "🤔 Can't find that task in the trash.")
+		case errors.Is(err, service.ErrMaxTasksReached):
+			return h.sendError(c, "📋 Maxed out at 50 tasks!")
+		case errors.Is(err, service.ErrTemplateTaskExists):
+			return h.sendError(c, "🤔 This challenge already has this task from its template, so there is nothing to restore.")
+		}
+		logger.Error("Failed to restore task", "task_id", taskID, "challenge_id", challengeID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	logger.Info("Task restored", "task_id", taskID, "challenge_id", challengeID, "user_id", userID)
+
+	c.Send(fmt.Sprintf("♻️ \"%s\" is back as task #%d, progress included.", task.Title, task.OrderNum))
+	return h.showTaskTrash(c)
+}
+
+// showChallengeTrash shows all deleted challenges
+func (h *Handler) showChallengeTrash(c tele.Context) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	challenges, err := h.trash.GetChallenges()
+	if err != nil {
+		logger.Error("Failed to get trashed challenges", "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	msg := "🗑 <b>Trash</b>\n\n"
+	if len(challenges) == 0 {
+		msg += fmt.Sprintf("Nothing here. Deleted challenges stay here for %d days before they're gone for good.", h.trash.RetentionDays())
+		return c.Send(msg, keyboards.ChallengeTrash(nil), tele.ModeHTML)
+	}
+	for _, ch := range challenges {
+		msg += fmt.Sprintf("• <b>%s</b> (<code>%s</code>) — deleted %s, purged %s\n",
+			html.EscapeString(ch.Name),
+			ch.ID,
+			ch.DeletedAt.UTC().Format("Jan 2"),
+			h.trash.PurgeAt(*ch.DeletedAt).UTC().Format("Jan 2"),
+		)
+	}
+	msg += "\nTap a challenge to restore it with its tasks, members and progress."
+
+	return c.Send(msg, keyboards.ChallengeTrash(challenges), tele.ModeHTML)
+}
+
+// handleRestoreChallenge restores a deleted challenge
+func (h *Handler) handleRestoreChallenge(c tele.Context, challengeID string) error {
+	userID := c.Sender().ID
+
+	if !h.isSuperAdmin(userID) {
+		return h.sendError(c, "You don't have super admin privileges.")
+	}
+
+	challenge, err := h.trash.RestoreChallenge(challengeID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrChallengeNotFound) {
+			return h.sendError(c, "🤔 Can't find that challenge in the trash.")
+		}
+		logger.Error("Failed to restore challenge", "challenge_id", challengeID, "error", err)
+		return h.sendError(c, "😅 Oops, something went wrong. Give it another try!")
+	}
+
+	logger.Info("Challenge restored", "challenge_id", challengeID, "user_id", userID)
+
+	c.Send(fmt.Sprintf("♻️ \"%s\" is back. Its members can open it from the start menu again.", challenge.Name))
+	return h.showChallengeTrash(c)
+}
@@ -0,0 +1,121 @@
+package images
+
+import (
+	"fmt"
+	"image"
+	"time"
+)
+
+const (
+	cardWidth  = 800
+	cardHeight = 420
+)
+
+// ProgressCardData holds data for the progress card image
+type ProgressCardData struct {
+	ChallengeName  string
+	Emoji          string
+	Name           string
+	CompletedTasks int
+	TotalTasks     int
+	JoinedAt       time.Time
+	FinishedAt     time.Time // last completion once all tasks are done, zero before
+	Rank           int       // 1-based squad rank
+	SquadSize      int
+}
+
+// RenderProgressCard renders a shareable progress card as PNG
+// When all tasks are completed the card doubles as the celebration image
+func RenderProgressCard(data ProgressCardData) ([]byte, error) {
+	if err := loadFonts(); err != nil {
+		return nil, err
+	}
+
+	titleFace, err := newFace(boldFont, 34)
+	if err != nil {
+		return nil, err
+	}
+	nameFace, err := newFace(boldFont, 30)
+	if err != nil {
+		return nil, err
+	}
+	bodyFace, err := newFace(regularFont, 24)
+	if err != nil {
+		return nil, err
+	}
+	pctFace, err := newFace(boldFont, 48)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, cardWidth, cardHeight))
+	fillRect(img, img.Bounds(), colorBackground)
+	fillRect(img, image.Rect(24, 96, cardWidth-24, cardHeight-24), colorPanel)
+
+	isComplete := data.TotalTasks > 0 && data.CompletedTasks >= data.TotalTasks
+
+	// Header
+	title := truncate(titleFace, printable(regularFont, data.ChallengeName), cardWidth-64)
+	drawText(img, titleFace, colorText, 32, 56, title)
+	if isComplete {
+		drawText(img, bodyFace, colorGold, 32, 86, "Challenge complete!")
+	} else {
+		drawText(img, bodyFace, colorMuted, 32, 86, "Progress report")
+	}
+
+	// Progress ring
+	var progress float64
+	if data.TotalTasks > 0 {
+		progress = float64(data.CompletedTasks) / float64(data.TotalTasks)
+	}
+	ringColor := colorAccent
+	if isComplete {
+		ringColor = colorGold
+	}
+	ringX, ringY := 190, 258
+	drawRing(img, ringX, ringY, 120, 24, progress, ringColor, colorTrack)
+	drawTextCentered(img, pctFace, colorText, ringX, ringY+16, fmt.Sprintf("%d%%", int(progress*100)))
+
+	// Stats
+	x := 360
+	nameX := x
+	if data.Emoji != "" {
+		drawEmoji(img, x, 140, 36, data.Emoji)
+		nameX += 48
+	}
+	name := truncate(nameFace, printable(regularFont, data.Name), cardWidth-nameX-48)
+	drawText(img, nameFace, colorText, nameX, 170, name)
+	drawText(img, bodyFace, colorMuted, x, 230, "Tasks")
+	drawText(img, bodyFace, colorText, x+120, 230, fmt.Sprintf("%d / %d", data.CompletedTasks, data.TotalTasks))
+	drawText(img, bodyFace, colorMuted, x, 280, "Time")
+	drawText(img, bodyFace, colorText, x+120, 280, formatElapsed(data.elapsed(time.Now())))
+	if data.Rank > 0 && data.SquadSize > 0 {
+		drawText(img, bodyFace, colorMuted, x, 330, "Rank")
+		drawText(img, bodyFace, colorText, x+120, 330, fmt.Sprintf("#%d of %d", data.Rank, data.SquadSize))
+	}
+
+	return encodePNG(img)
+}
+
+// elapsed is the time taken to finish the challenge, or the time since joining while in progress
+func (d ProgressCardData) elapsed(now time.Time) time.Duration {
+	if !d.FinishedAt.IsZero() {
+		return d.FinishedAt.Sub(d.JoinedAt)
+	}
+	return now.Sub(d.JoinedAt)
+}
+
+// formatElapsed formats a duration in days, hours or minutes
+func formatElapsed(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	switch {
+	case days == 1:
+		return "1 day"
+	case days > 1:
+		return fmt.Sprintf("%d days", days)
+	case int(d.Hours()) > 0:
+		return fmt.Sprintf("%d hours", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%d minutes", int(d.Minutes()))
+	}
+}
@@ -0,0 +1,101 @@
+package images
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestRenderProgressCard(t *testing.T) {
+	data := ProgressCardData{
+		ChallengeName:  "🏃 Summer Running Challenge",
+		Name:           "John 💪",
+		CompletedTasks: 7,
+		TotalTasks:     10,
+		JoinedAt:       time.Now().Add(-3 * 24 * time.Hour),
+		Rank:           2,
+		SquadSize:      5,
+	}
+
+	b, err := RenderProgressCard(data)
+	if err != nil {
+		t.Fatalf("RenderProgressCard() error = %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("Result is not a valid PNG: %v", err)
+	}
+	if img.Bounds().Dx() != cardWidth || img.Bounds().Dy() != cardHeight {
+		t.Errorf("Card size = %dx%d, want %dx%d", img.Bounds().Dx(), img.Bounds().Dy(), cardWidth, cardHeight)
+	}
+}
+
+func TestRenderProgressCard_NoTasks(t *testing.T) {
+	if _, err := RenderProgressCard(ProgressCardData{ChallengeName: "Empty", Name: "Solo"}); err != nil {
+		t.Fatalf("RenderProgressCard() error = %v", err)
+	}
+}
+
+func TestRenderProgressCard_Emoji(t *testing.T) {
+	data := ProgressCardData{ChallengeName: "Summer", Name: "John", CompletedTasks: 3, TotalTasks: 10}
+	without, err := RenderProgressCard(data)
+	if err != nil {
+		t.Fatalf("RenderProgressCard() error = %v", err)
+	}
+
+	data.Emoji = "💪"
+	with, err := RenderProgressCard(data)
+	if err != nil {
+		t.Fatalf("RenderProgressCard() error = %v", err)
+	}
+	if bytes.Equal(with, without) {
+		t.Error("Card with an emoji is the same as the card without it")
+	}
+}
+
+func TestPrintable(t *testing.T) {
+	if err := loadFonts(); err != nil {
+		t.Fatalf("loadFonts() error = %v", err)
+	}
+	if got := printable(regularFont, "🔥 Fire Squad 🔥"); got != "Fire Squad" {
+		t.Errorf("printable() = %q, want %q", got, "Fire Squad")
+	}
+}
+
+func TestProgressCardData_Elapsed(t *testing.T) {
+	joined := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	now := joined.Add(30 * 24 * time.Hour)
+
+	inProgress := ProgressCardData{CompletedTasks: 3, TotalTasks: 10, JoinedAt: joined}
+	if got := inProgress.elapsed(now); got != 30*24*time.Hour {
+		t.Errorf("elapsed() in progress = %v, want the time since joining", got)
+	}
+
+	// Asked for weeks after finishing, the card still shows the time it took
+	finished := ProgressCardData{CompletedTasks: 10, TotalTasks: 10, JoinedAt: joined, FinishedAt: joined.Add(5 * 24 * time.Hour)}
+	if got := finished.elapsed(now); got != 5*24*time.Hour {
+		t.Errorf("elapsed() when finished = %v, want the time until the last completion", got)
+	}
+	if got := formatElapsed(finished.elapsed(now)); got != "5 days" {
+		t.Errorf("Card time when finished = %q, want %q", got, "5 days")
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Minute, "30 minutes"},
+		{5 * time.Hour, "5 hours"},
+		{24 * time.Hour, "1 day"},
+		{72 * time.Hour, "3 days"},
+	}
+	for _, tt := range tests {
+		if got := formatElapsed(tt.d); got != tt.want {
+			t.Errorf("formatElapsed(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
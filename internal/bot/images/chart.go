@@ -0,0 +1,155 @@
+package images
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+)
+
+const (
+	chartWidth  = 900
+	chartHeight = 520
+	// maxLegendEntries caps the legend; extra series are still plotted
+	maxLegendEntries = 12
+)
+
+// seriesColors is the palette for chart lines, reused cyclically
+var seriesColors = []color.RGBA{
+	{0x4c, 0xc9, 0x7a, 0xff},
+	{0x5b, 0x9c, 0xf5, 0xff},
+	{0xf5, 0x8a, 0x42, 0xff},
+	{0xe0, 0x5c, 0xa8, 0xff},
+	{0xf5, 0xc2, 0x42, 0xff},
+	{0x9b, 0x7b, 0xf0, 0xff},
+	{0x42, 0xd6, 0xd6, 0xff},
+	{0xf0, 0x5b, 0x5b, 0xff},
+}
+
+// ChartSeries is one line on the burn-up chart
+type ChartSeries struct {
+	Name   string
+	Values []int // cumulative value per day
+}
+
+// BurnUpChartData holds data for the burn-up chart image
+type BurnUpChartData struct {
+	ChallengeName string
+	Start         time.Time
+	TotalTasks    int
+	Series        []ChartSeries
+}
+
+// RenderBurnUpChart renders cumulative completions per participant per day as PNG
+func RenderBurnUpChart(data BurnUpChartData) ([]byte, error) {
+	if err := loadFonts(); err != nil {
+		return nil, err
+	}
+	titleFace, err := newFace(boldFont, 28)
+	if err != nil {
+		return nil, err
+	}
+	labelFace, err := newFace(regularFont, 16)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	fillRect(img, img.Bounds(), colorBackground)
+
+	title := truncate(titleFace, printable(regularFont, data.ChallengeName), chartWidth-48)
+	drawText(img, titleFace, colorText, 24, 44, title)
+	drawText(img, labelFace, colorMuted, 24, 70, "Completed tasks per day (burn-up)")
+
+	// Plot area
+	left, top, right, bottom := 64, 96, chartWidth-220, chartHeight-56
+	fillRect(img, image.Rect(left, top, right, bottom), colorPanel)
+
+	days := 0
+	maxY := data.TotalTasks
+	for _, s := range data.Series {
+		if len(s.Values) > days {
+			days = len(s.Values)
+		}
+		for _, v := range s.Values {
+			if v > maxY {
+				maxY = v
+			}
+		}
+	}
+	if maxY == 0 {
+		maxY = 1
+	}
+
+	xOf := func(day int) float64 {
+		if days <= 1 {
+			return float64(left + (right-left)/2)
+		}
+		return float64(left) + float64(day)*float64(right-left)/float64(days-1)
+	}
+	yOf := func(v int) float64 {
+		return float64(bottom) - float64(v)*float64(bottom-top)/float64(maxY)
+	}
+
+	// Horizontal grid with labels
+	for _, v := range gridValues(maxY, 5) {
+		y := yOf(v)
+		drawLine(img, float64(left), y, float64(right), y, 1, colorTrack)
+		label := fmt.Sprintf("%d", v)
+		drawText(img, labelFace, colorMuted, left-10-len(label)*9, int(y)+6, label)
+	}
+
+	// Day labels: first, last and a few in between
+	if days > 0 {
+		for _, d := range gridValues(days-1, 6) {
+			label := data.Start.AddDate(0, 0, d).Format("Jan 2")
+			drawTextCentered(img, labelFace, colorMuted, int(xOf(d)), bottom+24, label)
+		}
+	}
+
+	// Series lines
+	for i, s := range data.Series {
+		c := seriesColors[i%len(seriesColors)]
+		for d := 1; d < len(s.Values); d++ {
+			drawLine(img, xOf(d-1), yOf(s.Values[d-1]), xOf(d), yOf(s.Values[d]), 3, c)
+		}
+		if len(s.Values) == 1 {
+			x, y := int(xOf(0)), int(yOf(s.Values[0]))
+			fillRect(img, image.Rect(x-3, y-3, x+3, y+3), c)
+		}
+	}
+
+	// Legend
+	legendX := right + 24
+	for i, s := range data.Series {
+		if i >= maxLegendEntries {
+			drawText(img, labelFace, colorMuted, legendX, top+12+i*26, fmt.Sprintf("+%d more", len(data.Series)-i))
+			break
+		}
+		y := top + 12 + i*26
+		fillRect(img, image.Rect(legendX, y-10, legendX+14, y+2), seriesColors[i%len(seriesColors)])
+		name := printable(regularFont, s.Name)
+		if len(s.Values) > 0 {
+			name = fmt.Sprintf("%s (%d)", name, s.Values[len(s.Values)-1])
+		}
+		drawText(img, labelFace, colorText, legendX+22, y+2, truncate(labelFace, name, chartWidth-legendX-34))
+	}
+
+	return encodePNG(img)
+}
+
+// gridValues returns up to n+1 evenly spaced integer values from 0 to max inclusive
+func gridValues(max, n int) []int {
+	if max <= 0 {
+		return []int{0}
+	}
+	step := (max + n - 1) / n
+	if step < 1 {
+		step = 1
+	}
+	var values []int
+	for v := 0; v < max; v += step {
+		values = append(values, v)
+	}
+	return append(values, max)
+}
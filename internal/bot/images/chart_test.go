@@ -0,0 +1,67 @@
+package images
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestRenderBurnUpChart(t *testing.T) {
+	data := BurnUpChartData{
+		ChallengeName: "Test Challenge",
+		Start:         time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		TotalTasks:    10,
+		Series: []ChartSeries{
+			{Name: "John 💪", Values: []int{1, 3, 3, 6, 8}},
+			{Name: "Sarah", Values: []int{0, 1, 2, 2, 4}},
+		},
+	}
+
+	b, err := RenderBurnUpChart(data)
+	if err != nil {
+		t.Fatalf("RenderBurnUpChart() error = %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("Result is not a valid PNG: %v", err)
+	}
+	if img.Bounds().Dx() != chartWidth || img.Bounds().Dy() != chartHeight {
+		t.Errorf("Chart size = %dx%d, want %dx%d", img.Bounds().Dx(), img.Bounds().Dy(), chartWidth, chartHeight)
+	}
+}
+
+func TestRenderBurnUpChart_SingleDay(t *testing.T) {
+	data := BurnUpChartData{
+		ChallengeName: "Fresh",
+		Series:        []ChartSeries{{Name: "Solo", Values: []int{0}}},
+	}
+	if _, err := RenderBurnUpChart(data); err != nil {
+		t.Fatalf("RenderBurnUpChart() error = %v", err)
+	}
+}
+
+func TestGridValues(t *testing.T) {
+	tests := []struct {
+		max, n int
+		want   []int
+	}{
+		{0, 5, []int{0}},
+		{3, 5, []int{0, 1, 2, 3}},
+		{10, 5, []int{0, 2, 4, 6, 8, 10}},
+		{7, 3, []int{0, 3, 6, 7}},
+	}
+	for _, tt := range tests {
+		got := gridValues(tt.max, tt.n)
+		if len(got) != len(tt.want) {
+			t.Errorf("gridValues(%d, %d) = %v, want %v", tt.max, tt.n, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("gridValues(%d, %d) = %v, want %v", tt.max, tt.n, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Package images renders shareable PNG images (progress cards, charts)
+// using the standard library image packages and the embedded Go fonts.
+package images
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Palette shared by all images
+var (
+	colorBackground = color.RGBA{0x1e, 0x1e, 0x2e, 0xff}
+	colorPanel      = color.RGBA{0x2a, 0x2a, 0x3d, 0xff}
+	colorText       = color.RGBA{0xf2, 0xf2, 0xf7, 0xff}
+	colorMuted      = color.RGBA{0x9a, 0x9a, 0xb0, 0xff}
+	colorAccent     = color.RGBA{0x4c, 0xc9, 0x7a, 0xff}
+	colorGold       = color.RGBA{0xf5, 0xc2, 0x42, 0xff}
+	colorTrack      = color.RGBA{0x3a, 0x3a, 0x52, 0xff}
+)
+
+var (
+	fontsOnce   sync.Once
+	regularFont *opentype.Font
+	boldFont    *opentype.Font
+	fontsErr    error
+)
+
+// loadFonts parses the embedded Go fonts once
+func loadFonts() error {
+	fontsOnce.Do(func() {
+		regularFont, fontsErr = opentype.Parse(goregular.TTF)
+		if fontsErr != nil {
+			return
+		}
+		boldFont, fontsErr = opentype.Parse(gobold.TTF)
+	})
+	return fontsErr
+}
+
+// newFace creates a font face of the given size
+func newFace(f *opentype.Font, size float64) (font.Face, error) {
+	return opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+}
+
+// printable drops runes the font has no glyph for (e.g. emoji) and trims the result
+func printable(f *opentype.Font, s string) string {
+	var buf sfnt.Buffer
+	var sb strings.Builder
+	for _, r := range s {
+		if idx, err := f.GlyphIndex(&buf, r); err == nil && idx != 0 {
+			sb.WriteRune(r)
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// truncate shortens text with an ellipsis so it fits into maxWidth pixels
+func truncate(face font.Face, s string, maxWidth int) string {
+	if font.MeasureString(face, s).Ceil() <= maxWidth {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if font.MeasureString(face, candidate).Ceil() <= maxWidth {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// drawText draws text with its baseline at (x, y)
+func drawText(dst draw.Image, face font.Face, c color.Color, x, y int, s string) {
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(s)
+}
+
+// drawTextCentered draws text horizontally centered on cx with its baseline at y
+func drawTextCentered(dst draw.Image, face font.Face, c color.Color, cx, y int, s string) {
+	w := font.MeasureString(face, s).Ceil()
+	drawText(dst, face, c, cx-w/2, y, s)
+}
+
+// fillRect fills a rectangle with a solid color
+func fillRect(dst draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(dst, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// drawLine draws a line of the given thickness between two points
+func drawLine(dst draw.Image, x0, y0, x1, y1 float64, thickness int, c color.Color) {
+	steps := int(math.Max(math.Abs(x1-x0), math.Abs(y1-y0)))
+	if steps == 0 {
+		steps = 1
+	}
+	half := thickness / 2
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x := int(math.Round(x0 + (x1-x0)*t))
+		y := int(math.Round(y0 + (y1-y0)*t))
+		fillRect(dst, image.Rect(x-half, y-half, x-half+thickness, y-half+thickness), c)
+	}
+}
+
+// drawRing draws a progress ring centered at (cx, cy); progress is in [0, 1], starting at 12 o'clock
+func drawRing(dst draw.Image, cx, cy, radius, thickness int, progress float64, fg, bg color.Color) {
+	inner := float64(radius - thickness)
+	outer := float64(radius)
+	sweep := progress * 2 * math.Pi
+
+	for y := cy - radius; y <= cy+radius; y++ {
+		for x := cx - radius; x <= cx+radius; x++ {
+			dx := float64(x - cx)
+			dy := float64(y - cy)
+			d := math.Hypot(dx, dy)
+			if d < inner || d > outer {
+				continue
+			}
+			// Angle measured clockwise from the top
+			angle := math.Atan2(dx, -dy)
+			if angle < 0 {
+				angle += 2 * math.Pi
+			}
+			if angle <= sweep {
+				dst.Set(x, y, fg)
+			} else {
+				dst.Set(x, y, bg)
+			}
+		}
+	}
+}
+
+// encodePNG encodes an image as PNG bytes
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,268 @@
+package images
+
+import (
+	"hash/fnv"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// The Go fonts have no emoji glyphs, so participant emoji are drawn as simple vector icons.
+// Icons cover util.SuggestedEmojis; any other emoji gets a round badge colored after it
+
+// emojiShape is a filled circle or polygon in icon space, where the icon is the unit square
+type emojiShape struct {
+	color  color.RGBA // zero alpha cuts the shape out of the ones below
+	circle bool       // points are cx, cy, r
+	points []float64  // polygon x0, y0, x1, y1, ...
+}
+
+var (
+	emojiCut       = color.RGBA{}
+	emojiDark      = color.RGBA{0x2b, 0x2b, 0x36, 0xff}
+	emojiWhite     = color.RGBA{0xf7, 0xf7, 0xf7, 0xff}
+	emojiLightGray = color.RGBA{0xd0, 0xd4, 0xdc, 0xff}
+	emojiGray      = color.RGBA{0x8c, 0x92, 0x9e, 0xff}
+	emojiYellow    = color.RGBA{0xff, 0xcc, 0x33, 0xff}
+	emojiOrange    = color.RGBA{0xff, 0x8c, 0x1a, 0xff}
+	emojiRed       = color.RGBA{0xe8, 0x3a, 0x3a, 0xff}
+	emojiGreen     = color.RGBA{0x4c, 0xc9, 0x7a, 0xff}
+	emojiBlue      = color.RGBA{0x3d, 0x8b, 0xe8, 0xff}
+	emojiLightBlue = color.RGBA{0x9c, 0xd8, 0xf5, 0xff}
+	emojiPurple    = color.RGBA{0x9b, 0x59, 0xd6, 0xff}
+	emojiBrown     = color.RGBA{0x8b, 0x5a, 0x2b, 0xff}
+	emojiSkin      = color.RGBA{0xff, 0xc8, 0x3d, 0xff}
+)
+
+// emojiBadgeColors are the colors of badges drawn for emoji without an icon
+var emojiBadgeColors = []color.RGBA{emojiRed, emojiOrange, emojiYellow, emojiGreen, emojiBlue, emojiPurple}
+
+// emojiIcons maps emoji, without variation selectors, to their icon
+var emojiIcons = map[string][]emojiShape{
+	"💪": {
+		poly(emojiSkin, 0.04, 0.96, 0.04, 0.66, 0.5, 0.6, 0.86, 0.72, 0.86, 0.96),
+		circle(emojiSkin, 0.42, 0.6, 0.22),
+		poly(emojiSkin, 0.6, 0.72, 0.56, 0.3, 0.84, 0.26, 0.9, 0.72),
+		circle(emojiSkin, 0.7, 0.24, 0.18),
+	},
+	"🔥": {
+		circle(emojiOrange, 0.5, 0.66, 0.32),
+		poly(emojiOrange, 0.2, 0.6, 0.5, 0.02, 0.8, 0.6),
+		circle(emojiYellow, 0.5, 0.76, 0.17),
+		poly(emojiYellow, 0.34, 0.72, 0.5, 0.38, 0.66, 0.72),
+	},
+	"⭐": {
+		star(emojiYellow, 0.5, 0.54, 0.48, 0.2, 5),
+	},
+	"🎯": {
+		circle(emojiRed, 0.5, 0.5, 0.48),
+		circle(emojiWhite, 0.5, 0.5, 0.36),
+		circle(emojiRed, 0.5, 0.5, 0.24),
+		circle(emojiWhite, 0.5, 0.5, 0.12),
+	},
+	"🚀": {
+		poly(emojiRed, 0.3, 0.48, 0.12, 0.8, 0.3, 0.76),
+		poly(emojiRed, 0.7, 0.48, 0.88, 0.8, 0.7, 0.76),
+		poly(emojiOrange, 0.38, 0.74, 0.5, 1, 0.62, 0.74),
+		poly(emojiLightGray, 0.5, 0, 0.7, 0.3, 0.7, 0.76, 0.3, 0.76, 0.3, 0.3),
+		circle(emojiBlue, 0.5, 0.36, 0.1),
+	},
+	"💎": {
+		poly(emojiLightBlue, 0.22, 0.1, 0.78, 0.1, 1, 0.36, 0, 0.36),
+		poly(emojiBlue, 0, 0.36, 1, 0.36, 0.5, 0.95),
+	},
+	"🌟": {
+		star(emojiOrange, 0.5, 0.54, 0.5, 0.24, 5),
+		star(emojiYellow, 0.5, 0.54, 0.34, 0.14, 5),
+	},
+	"⚡": {
+		poly(emojiYellow, 0.6, 0, 0.15, 0.58, 0.45, 0.58, 0.35, 1, 0.85, 0.4, 0.55, 0.4, 0.72, 0),
+	},
+	"🏆": {
+		circle(emojiYellow, 0.17, 0.26, 0.13),
+		circle(emojiYellow, 0.83, 0.26, 0.13),
+		circle(emojiCut, 0.17, 0.26, 0.06),
+		circle(emojiCut, 0.83, 0.26, 0.06),
+		poly(emojiYellow, 0.2, 0.05, 0.8, 0.05, 0.8, 0.3, 0.2, 0.3),
+		circle(emojiYellow, 0.5, 0.3, 0.3),
+		poly(emojiYellow, 0.44, 0.55, 0.56, 0.55, 0.6, 0.8, 0.4, 0.8),
+		poly(emojiBrown, 0.26, 0.8, 0.74, 0.8, 0.74, 0.96, 0.26, 0.96),
+	},
+	"🎮": {
+		circle(emojiGray, 0.27, 0.56, 0.23),
+		circle(emojiGray, 0.73, 0.56, 0.23),
+		poly(emojiGray, 0.27, 0.33, 0.73, 0.33, 0.73, 0.79, 0.27, 0.79),
+		poly(emojiDark, 0.18, 0.52, 0.36, 0.52, 0.36, 0.6, 0.18, 0.6),
+		poly(emojiDark, 0.23, 0.47, 0.31, 0.47, 0.31, 0.65, 0.23, 0.65),
+		circle(emojiRed, 0.72, 0.5, 0.055),
+		circle(emojiBlue, 0.81, 0.6, 0.055),
+	},
+	"🦁": {
+		circle(emojiBrown, 0.5, 0.5, 0.48),
+		circle(emojiOrange, 0.5, 0.5, 0.42),
+		circle(emojiSkin, 0.5, 0.55, 0.3),
+		circle(emojiDark, 0.4, 0.5, 0.045),
+		circle(emojiDark, 0.6, 0.5, 0.045),
+		poly(emojiBrown, 0.44, 0.62, 0.56, 0.62, 0.5, 0.69),
+	},
+	"🐯": {
+		circle(emojiOrange, 0.2, 0.2, 0.13),
+		circle(emojiOrange, 0.8, 0.2, 0.13),
+		circle(emojiOrange, 0.5, 0.55, 0.42),
+		circle(emojiWhite, 0.5, 0.72, 0.18),
+		poly(emojiDark, 0.44, 0.14, 0.56, 0.14, 0.5, 0.32),
+		poly(emojiDark, 0.08, 0.5, 0.24, 0.54, 0.08, 0.58),
+		poly(emojiDark, 0.92, 0.5, 0.76, 0.54, 0.92, 0.58),
+		circle(emojiDark, 0.38, 0.5, 0.045),
+		circle(emojiDark, 0.62, 0.5, 0.045),
+		poly(emojiDark, 0.44, 0.64, 0.56, 0.64, 0.5, 0.7),
+	},
+	"🦊": {
+		poly(emojiOrange, 0.08, 0.04, 0.42, 0.3, 0.14, 0.5),
+		poly(emojiOrange, 0.92, 0.04, 0.58, 0.3, 0.86, 0.5),
+		poly(emojiOrange, 0.06, 0.34, 0.94, 0.34, 0.5, 0.96),
+		poly(emojiWhite, 0.1, 0.48, 0.44, 0.66, 0.5, 0.96),
+		poly(emojiWhite, 0.9, 0.48, 0.56, 0.66, 0.5, 0.96),
+		circle(emojiDark, 0.36, 0.48, 0.045),
+		circle(emojiDark, 0.64, 0.48, 0.045),
+		circle(emojiDark, 0.5, 0.9, 0.05),
+	},
+	"🐺": {
+		poly(emojiGray, 0.08, 0.04, 0.42, 0.3, 0.14, 0.5),
+		poly(emojiGray, 0.92, 0.04, 0.58, 0.3, 0.86, 0.5),
+		poly(emojiGray, 0.06, 0.34, 0.94, 0.34, 0.5, 0.96),
+		poly(emojiLightGray, 0.1, 0.48, 0.44, 0.66, 0.5, 0.96),
+		poly(emojiLightGray, 0.9, 0.48, 0.56, 0.66, 0.5, 0.96),
+		circle(emojiDark, 0.36, 0.48, 0.045),
+		circle(emojiDark, 0.64, 0.48, 0.045),
+		circle(emojiDark, 0.5, 0.9, 0.05),
+	},
+	"🦅": {
+		poly(emojiBrown, 0.06, 0.7, 0.84, 0.7, 0.94, 1, 0, 1),
+		circle(emojiWhite, 0.44, 0.44, 0.34),
+		poly(emojiYellow, 0.66, 0.32, 1, 0.52, 0.74, 0.64),
+		circle(emojiDark, 0.56, 0.36, 0.05),
+	},
+	"🌈": {
+		circle(emojiRed, 0.5, 0.86, 0.48),
+		circle(emojiOrange, 0.5, 0.86, 0.4),
+		circle(emojiYellow, 0.5, 0.86, 0.32),
+		circle(emojiGreen, 0.5, 0.86, 0.24),
+		circle(emojiBlue, 0.5, 0.86, 0.16),
+		circle(emojiCut, 0.5, 0.86, 0.08),
+		poly(emojiCut, 0, 0.86, 1, 0.86, 1, 1, 0, 1),
+	},
+	"☀": {
+		star(emojiOrange, 0.5, 0.5, 0.5, 0.3, 12),
+		circle(emojiYellow, 0.5, 0.5, 0.28),
+	},
+	"🌙": {
+		circle(emojiYellow, 0.5, 0.5, 0.42),
+		circle(emojiCut, 0.68, 0.36, 0.36),
+	},
+	"❤": heart(emojiRed),
+	"💜": heart(emojiPurple),
+}
+
+func circle(c color.RGBA, cx, cy, r float64) emojiShape {
+	return emojiShape{color: c, circle: true, points: []float64{cx, cy, r}}
+}
+
+func poly(c color.RGBA, points ...float64) emojiShape {
+	return emojiShape{color: c, points: points}
+}
+
+// star returns an n-pointed star centered at (cx, cy) with its first point up
+func star(c color.RGBA, cx, cy, outer, inner float64, n int) emojiShape {
+	points := make([]float64, 0, 4*n)
+	for i := 0; i < 2*n; i++ {
+		r := outer
+		if i%2 == 1 {
+			r = inner
+		}
+		angle := float64(i) * math.Pi / float64(n)
+		points = append(points, cx+r*math.Sin(angle), cy-r*math.Cos(angle))
+	}
+	return poly(c, points...)
+}
+
+func heart(c color.RGBA) []emojiShape {
+	return []emojiShape{
+		circle(c, 0.3, 0.36, 0.23),
+		circle(c, 0.7, 0.36, 0.23),
+		poly(c, 0.08, 0.44, 0.92, 0.44, 0.5, 0.92),
+	}
+}
+
+// contains reports whether the point (x, y) is inside the shape
+func (s emojiShape) contains(x, y float64) bool {
+	if s.circle {
+		return math.Hypot(x-s.points[0], y-s.points[1]) <= s.points[2]
+	}
+	// Even-odd ray casting
+	inside := false
+	n := len(s.points) / 2
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		xi, yi := s.points[2*i], s.points[2*i+1]
+		xj, yj := s.points[2*j], s.points[2*j+1]
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// emojiIcon returns the icon of an emoji, or a badge colored after it when there is none
+func emojiIcon(emoji string) []emojiShape {
+	if shapes, ok := emojiIcons[strings.ReplaceAll(emoji, "\ufe0f", "")]; ok {
+		return shapes
+	}
+	h := fnv.New32a()
+	h.Write([]byte(emoji))
+	badge := emojiBadgeColors[h.Sum32()%uint32(len(emojiBadgeColors))]
+	return []emojiShape{circle(badge, 0.5, 0.5, 0.46)}
+}
+
+// drawEmoji draws an emoji icon into the size × size square with its top left corner at (x, y)
+func drawEmoji(dst *image.RGBA, x, y, size int, emoji string) {
+	const samples = 4 // per axis, for anti-aliasing
+	shapes := emojiIcon(emoji)
+
+	for py := 0; py < size; py++ {
+		for px := 0; px < size; px++ {
+			var r, g, b, covered float64
+			for sy := 0; sy < samples; sy++ {
+				for sx := 0; sx < samples; sx++ {
+					u := (float64(px) + (float64(sx)+0.5)/samples) / float64(size)
+					v := (float64(py) + (float64(sy)+0.5)/samples) / float64(size)
+					// Later shapes are painted over earlier ones
+					for i := len(shapes) - 1; i >= 0; i-- {
+						if !shapes[i].contains(u, v) {
+							continue
+						}
+						if c := shapes[i].color; c.A != 0 {
+							r += float64(c.R)
+							g += float64(c.G)
+							b += float64(c.B)
+							covered++
+						}
+						break
+					}
+				}
+			}
+			if covered == 0 {
+				continue
+			}
+
+			alpha := covered / (samples * samples)
+			bg := dst.RGBAAt(x+px, y+py)
+			dst.SetRGBA(x+px, y+py, color.RGBA{
+				R: uint8(float64(bg.R)*(1-alpha) + r/covered*alpha),
+				G: uint8(float64(bg.G)*(1-alpha) + g/covered*alpha),
+				B: uint8(float64(bg.B)*(1-alpha) + b/covered*alpha),
+				A: 0xff,
+			})
+		}
+	}
+}
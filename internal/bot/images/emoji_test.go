@@ -0,0 +1,28 @@
+package images
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/util"
+)
+
+func TestEmojiIcons(t *testing.T) {
+	for _, emoji := range util.SuggestedEmojis {
+		if _, ok := emojiIcons[strings.ReplaceAll(emoji, "\ufe0f", "")]; !ok {
+			t.Errorf("Suggested emoji %s has no icon", emoji)
+		}
+	}
+}
+
+func TestDrawEmoji(t *testing.T) {
+	for _, emoji := range []string{"❤️", "🐸"} {
+		img := image.NewRGBA(image.Rect(0, 0, 32, 32))
+		fillRect(img, img.Bounds(), colorPanel)
+		drawEmoji(img, 0, 0, 32, emoji)
+		if img.RGBAAt(16, 16) == colorPanel {
+			t.Errorf("drawEmoji(%s) left the middle of the icon empty", emoji)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package images
+
+import (
+	"image"
+	"image/color"
+	"time"
+)
+
+const (
+	heatmapCell     = 14
+	heatmapGap      = 3
+	heatmapMinWeeks = 12
+	heatmapMaxWeeks = 53
+	// heatmapMonthGap is the minimum number of weeks between month labels
+	heatmapMonthGap = 3
+)
+
+// heatmapLevels are cell colors from no activity to the busiest days
+var heatmapLevels = []color.RGBA{
+	colorTrack,
+	{0x1f, 0x5c, 0x3a, 0xff},
+	{0x2b, 0x85, 0x52, 0xff},
+	{0x3a, 0xaa, 0x68, 0xff},
+	colorAccent,
+}
+
+// HeatmapData holds data for the activity heatmap image
+type HeatmapData struct {
+	Title  string
+	Name   string
+	Today  time.Time         // user's local day (UTC midnight)
+	Counts map[time.Time]int // completions keyed by local day (UTC midnight)
+}
+
+// RenderHeatmap renders a GitHub-style calendar heatmap of daily completions as PNG
+// Columns are weeks (Monday first), ending with the current week
+func RenderHeatmap(data HeatmapData) ([]byte, error) {
+	if err := loadFonts(); err != nil {
+		return nil, err
+	}
+	titleFace, err := newFace(boldFont, 24)
+	if err != nil {
+		return nil, err
+	}
+	labelFace, err := newFace(regularFont, 14)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Date(data.Today.Year(), data.Today.Month(), data.Today.Day(), 0, 0, 0, 0, time.UTC)
+	lastWeekStart := today.AddDate(0, 0, -weekdayIndex(today))
+
+	// Cover the earliest activity, within limits
+	weeks := heatmapMinWeeks
+	maxCount := 0
+	for day, n := range data.Counts {
+		if n > maxCount {
+			maxCount = n
+		}
+		if w := int(lastWeekStart.Sub(day).Hours()/(24*7)) + 2; w > weeks {
+			weeks = w
+		}
+	}
+	if weeks > heatmapMaxWeeks {
+		weeks = heatmapMaxWeeks
+	}
+	firstWeekStart := lastWeekStart.AddDate(0, 0, -7*(weeks-1))
+
+	step := heatmapCell + heatmapGap
+	left, top := 56, 96
+	width := left + weeks*step + 24
+	if width < 480 {
+		width = 480
+	}
+	height := top + 7*step + 56
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fillRect(img, img.Bounds(), colorBackground)
+
+	drawText(img, titleFace, colorText, 24, 38, truncate(titleFace, printable(regularFont, data.Title), width-48))
+	drawText(img, labelFace, colorMuted, 24, 62, truncate(labelFace, printable(regularFont, data.Name)+" · daily completions", width-48))
+
+	for i, label := range []string{"Mon", "", "Wed", "", "Fri", "", "Sun"} {
+		if label != "" {
+			drawText(img, labelFace, colorMuted, 16, top+i*step+heatmapCell-2, label)
+		}
+	}
+
+	// Month labels go on the week a month starts; the leading partial month
+	// is labeled only if there is room before the next label
+	lastLabel := -heatmapMonthGap
+	for w := 0; w < weeks; w++ {
+		weekStart := firstWeekStart.AddDate(0, 0, 7*w)
+		startsMonth := w > 0 && weekStart.Month() != weekStart.AddDate(0, 0, -7).Month()
+		if w == 0 {
+			next := weekStart.AddDate(0, 1, 1-weekStart.Day())
+			startsMonth = int(next.Sub(weekStart).Hours()/(24*7)) >= heatmapMonthGap
+		}
+		if startsMonth && w-lastLabel >= heatmapMonthGap {
+			drawText(img, labelFace, colorMuted, left+w*step, top-8, weekStart.Format("Jan"))
+			lastLabel = w
+		}
+	}
+
+	for w := 0; w < weeks; w++ {
+		weekStart := firstWeekStart.AddDate(0, 0, 7*w)
+		x := left + w*step
+		for d := 0; d < 7; d++ {
+			day := weekStart.AddDate(0, 0, d)
+			if day.After(today) {
+				break
+			}
+			y := top + d*step
+			c := heatmapLevels[heatLevel(data.Counts[day], maxCount)]
+			fillRect(img, image.Rect(x, y, x+heatmapCell, y+heatmapCell), c)
+		}
+	}
+
+	// Legend
+	ly := height - 28
+	lx := width - 24 - len(heatmapLevels)*step - 80
+	drawText(img, labelFace, colorMuted, lx, ly+heatmapCell-2, "Less")
+	for i, c := range heatmapLevels {
+		x := lx + 40 + i*step
+		fillRect(img, image.Rect(x, ly, x+heatmapCell, ly+heatmapCell), c)
+	}
+	drawText(img, labelFace, colorMuted, lx+44+len(heatmapLevels)*step, ly+heatmapCell-2, "More")
+
+	return encodePNG(img)
+}
+
+// weekdayIndex returns 0 for Monday through 6 for Sunday
+func weekdayIndex(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
+// heatLevel maps a day's count to a color level relative to the busiest day
+func heatLevel(count, maxCount int) int {
+	if count <= 0 || maxCount <= 0 {
+		return 0
+	}
+	top := len(heatmapLevels) - 1
+	level := (count*top + maxCount - 1) / maxCount
+	if level < 1 {
+		level = 1
+	}
+	if level > top {
+		level = top
+	}
+	return level
+}
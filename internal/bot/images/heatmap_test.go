@@ -0,0 +1,67 @@
+package images
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestRenderHeatmap(t *testing.T) {
+	today := time.Date(2025, 6, 18, 0, 0, 0, 0, time.UTC)
+	counts := map[time.Time]int{
+		today:                    3,
+		today.AddDate(0, 0, -1):  1,
+		today.AddDate(0, 0, -30): 2,
+	}
+
+	b, err := RenderHeatmap(HeatmapData{Title: "Test Challenge", Name: "John", Today: today, Counts: counts})
+	if err != nil {
+		t.Fatalf("RenderHeatmap() error = %v", err)
+	}
+	if _, err := png.Decode(bytes.NewReader(b)); err != nil {
+		t.Fatalf("Result is not a valid PNG: %v", err)
+	}
+}
+
+func TestRenderHeatmap_OldActivityIsCapped(t *testing.T) {
+	today := time.Date(2025, 6, 18, 0, 0, 0, 0, time.UTC)
+	counts := map[time.Time]int{today.AddDate(-3, 0, 0): 1}
+
+	b, err := RenderHeatmap(HeatmapData{Title: "Old", Name: "John", Today: today, Counts: counts})
+	if err != nil {
+		t.Fatalf("RenderHeatmap() error = %v", err)
+	}
+	img, _ := png.Decode(bytes.NewReader(b))
+	maxWidth := 56 + heatmapMaxWeeks*(heatmapCell+heatmapGap) + 24
+	if img.Bounds().Dx() > maxWidth {
+		t.Errorf("Heatmap width = %d, want at most %d", img.Bounds().Dx(), maxWidth)
+	}
+}
+
+func TestHeatLevel(t *testing.T) {
+	tests := []struct {
+		count, max, want int
+	}{
+		{0, 5, 0},
+		{1, 5, 1},
+		{5, 5, 4},
+		{3, 0, 0},
+		{1, 1, 4},
+	}
+	for _, tt := range tests {
+		if got := heatLevel(tt.count, tt.max); got != tt.want {
+			t.Errorf("heatLevel(%d, %d) = %d, want %d", tt.count, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestWeekdayIndex(t *testing.T) {
+	monday := time.Date(2025, 6, 16, 0, 0, 0, 0, time.UTC)
+	if weekdayIndex(monday) != 0 {
+		t.Errorf("weekdayIndex(Monday) = %d, want 0", weekdayIndex(monday))
+	}
+	if weekdayIndex(monday.AddDate(0, 0, 6)) != 6 {
+		t.Errorf("weekdayIndex(Sunday) = %d, want 6", weekdayIndex(monday.AddDate(0, 0, 6)))
+	}
+}
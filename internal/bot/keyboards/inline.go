@@ -150,6 +150,9 @@ func MainChallengeView(
 	listAllBtn := menu.Data("📋 List all tasks", "list_all_tasks")
 	rows = append(rows, menu.Row(teamBtn, listAllBtn))
 
+	// Row: Shareable progress card
+	rows = append(rows, menu.Row(menu.Data("🖼 Progress card", "progress_card")))
+
 	// Row: Admin (optional), Settings, Exit
 	if hasAdmin {
 		adminBtn := menu.Data("🔧 Admin", "admin_panel")
@@ -196,15 +199,120 @@ func TaskDetail(taskID int64, isCompleted bool) *tele.ReplyMarkup {
 	}
 	backBtn := menu.Data("⬅️ Back", "back_to_main")
 
-	menu.Inline(menu.Row(actionBtn, backBtn))
+	addCommentBtn := menu.Data("💬 Comment", "add_comment", fmt.Sprintf("%d", taskID))
+	commentsBtn := menu.Data("🗨 All comments", "task_comments", fmt.Sprintf("%d", taskID), "0")
+
+	menu.Inline(
+		menu.Row(actionBtn, backBtn),
+		menu.Row(addCommentBtn, commentsBtn),
+	)
+	return menu
+}
+
+// TaskComments creates the paginated comments view keyboard
+// Admins get a delete button for each comment shown on the page
+func TaskComments(taskID int64, page, totalPages int, deletableIDs []int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	var rows []tele.Row
+
+	// Delete buttons, several per row
+	var row []tele.Btn
+	for i, id := range deletableIDs {
+		btn := menu.Data(
+			fmt.Sprintf("🗑 %d", i+1),
+			"delete_comment",
+			fmt.Sprintf("%d", id),
+			fmt.Sprintf("%d", page),
+		)
+		row = append(row, btn)
+		if len(row) == 5 {
+			rows = append(rows, menu.Row(row...))
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, menu.Row(row...))
+	}
+
+	// Pagination
+	if totalPages > 1 {
+		var nav []tele.Btn
+		if page > 0 {
+			nav = append(nav, menu.Data("◀️", "task_comments", fmt.Sprintf("%d", taskID), fmt.Sprintf("%d", page-1)))
+		}
+		nav = append(nav, menu.Data(fmt.Sprintf("%d/%d", page+1, totalPages), "noop"))
+		if page < totalPages-1 {
+			nav = append(nav, menu.Data("▶️", "task_comments", fmt.Sprintf("%d", taskID), fmt.Sprintf("%d", page+1)))
+		}
+		rows = append(rows, menu.Row(nav...))
+	}
+
+	addCommentBtn := menu.Data("💬 Comment", "add_comment", fmt.Sprintf("%d", taskID))
+	backBtn := menu.Data("⬅️ Back", "task_detail", fmt.Sprintf("%d", taskID))
+	rows = append(rows, menu.Row(addCommentBtn, backBtn))
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// Kudos creates the kudos reaction keyboard attached to task completion notifications
+func Kudos(taskID, toParticipantID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	var btns []tele.Btn
+	for _, emoji := range domain.KudosEmojis {
+		btns = append(btns, menu.Data(
+			emoji,
+			"kudos",
+			fmt.Sprintf("%d", taskID),
+			fmt.Sprintf("%d", toParticipantID),
+			emoji,
+		))
+	}
+
+	menu.Inline(menu.Row(btns...))
 	return menu
 }
 
 // TeamProgress creates the team progress keyboard
-func TeamProgress() *tele.ReplyMarkup {
+// nudgeTargets are teammates that can be nudged (may be empty)
+func TeamProgress(nudgeTargets []*domain.Participant) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
+	var rows []tele.Row
+
+	// Nudge buttons, two per row
+	var row []tele.Btn
+	for _, p := range nudgeTargets {
+		btn := menu.Data(
+			fmt.Sprintf("👋 Nudge %s %s", p.Emoji, p.DisplayName),
+			"nudge",
+			fmt.Sprintf("%d", p.ID),
+		)
+		row = append(row, btn)
+		if len(row) == 2 {
+			rows = append(rows, menu.Row(row...))
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, menu.Row(row...))
+	}
+
+	chartBtn := menu.Data("📈 Burn-up chart", "burnup_chart")
+	rows = append(rows, menu.Row(chartBtn))
+
 	backBtn := menu.Data("⬅️ Back", "back_to_main")
-	menu.Inline(menu.Row(backBtn))
+	rows = append(rows, menu.Row(backBtn))
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// OpenTask creates a keyboard with a button that opens a task in the given challenge
+func OpenTask(challengeID string, taskID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	openBtn := menu.Data("📌 Open my task", "open_task", challengeID, fmt.Sprintf("%d", taskID))
+	menu.Inline(menu.Row(openBtn))
 	return menu
 }
 
@@ -247,7 +355,7 @@ func NewCopyTextKeyboard(challengeID, link string) *CopyTextKeyboard {
 }
 
 // AdminPanel creates the admin panel keyboard
-func AdminPanel(dailyLimit int, hideFutureTasks bool, isObserverMode bool) *tele.ReplyMarkup {
+func AdminPanel(dailyLimit int, hideFutureTasks bool, shuffleTasks bool, isObserverMode bool, fromTemplate bool) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
 
 	addTaskBtn := menu.Data("➕ Add Task", "add_task")
@@ -273,6 +381,21 @@ func AdminPanel(dailyLimit int, hideFutureTasks bool, isObserverMode bool) *tele
 	}
 	hideBtn := menu.Data(hideText, "toggle_hide_future")
 
+	// Task order button
+	var orderText string
+	if shuffleTasks {
+		orderText = "🔀 Order: Per Person"
+	} else {
+		orderText = "🔀 Order: Shared"
+	}
+	orderBtn := menu.Data(orderText, "toggle_shuffle")
+
+	exportBtn := menu.Data("📤 Export", "export_challenge")
+	importBtn := menu.Data("📥 Import Tasks", "import_tasks")
+	bulkAddBtn := menu.Data("📑 Bulk Add", "bulk_add_tasks")
+	saveTplBtn := menu.Data("💾 Save as my template", "save_my_template")
+	historyBtn := menu.Data("📜 History", "audit_log", "0")
+	trashBtn := menu.Data("🗑 Trash", "task_trash")
 	deleteBtn := menu.Data("🗑 Delete Challenge", "delete_challenge")
 
 	// Back button depends on mode
@@ -283,15 +406,67 @@ func AdminPanel(dailyLimit int, hideFutureTasks bool, isObserverMode bool) *tele
 		mainBtn = menu.Data("🏠 Main Menu", "back_to_main")
 	}
 
-	menu.Inline(
-		menu.Row(addTaskBtn, editTasksBtn),
+	rows := []tele.Row{
+		menu.Row(addTaskBtn, bulkAddBtn),
+		menu.Row(editTasksBtn, orderBtn),
 		menu.Row(editNameBtn, editDescBtn),
 		menu.Row(limitBtn, hideBtn),
-		menu.Row(deleteBtn, mainBtn),
+		menu.Row(exportBtn, importBtn),
+		menu.Row(saveTplBtn),
+		menu.Row(historyBtn, trashBtn),
+	}
+	if fromTemplate {
+		rows = append(rows, menu.Row(menu.Data("🔄 Template Updates", "tpl_upd_preview")))
+	}
+	rows = append(rows, menu.Row(deleteBtn, mainBtn))
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// TemplateUpdateOffer - Apply a pending template update to a challenge
+func TemplateUpdateOffer(challengeID string, withBack bool) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	applyBtn := menu.Data("✅ Apply Update", "tpl_upd_apply", challengeID)
+	if withBack {
+		backBtn := menu.Data("⬅️ Back", "back_to_admin")
+		menu.Inline(menu.Row(applyBtn, backBtn))
+	} else {
+		menu.Inline(menu.Row(applyBtn))
+	}
+	return menu
+}
+
+// ExportFormats creates the export format choice keyboard for a challenge
+func ExportFormats(challengeID, backUnique string) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	xlsxBtn := menu.Data("📊 Excel (.xlsx)", "export", "xlsx", challengeID)
+	csvBtn := menu.Data("📄 CSV", "export", "csv", challengeID)
+	jsonBtn := menu.Data("🧩 JSON", "export", "json", challengeID)
+	yamlBtn := menu.Data("🧩 YAML", "export", "yaml", challengeID)
+	backBtn := menu.Data("⬅️ Back", backUnique)
+	menu.Inline(
+		menu.Row(xlsxBtn, csvBtn),
+		menu.Row(jsonBtn, yamlBtn),
+		menu.Row(backBtn),
 	)
 	return menu
 }
 
+// BulkTasksPreview creates the bulk-add preview keyboard
+func BulkTasksPreview(count int, valid bool, confirmUnique string) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	var rows []tele.Row
+	if valid {
+		confirmBtn := menu.Data(fmt.Sprintf("✅ Create %d tasks", count), confirmUnique)
+		rows = append(rows, menu.Row(confirmBtn))
+	}
+	cancelBtn := menu.Data("❌ Cancel", "cancel")
+	rows = append(rows, menu.Row(cancelBtn))
+	menu.Inline(rows...)
+	return menu
+}
+
 // AddTaskDone creates the keyboard after adding a task
 func AddTaskDone() *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
@@ -323,6 +498,21 @@ func EditTasksList(tasks []*domain.Task) *tele.ReplyMarkup {
 	return menu
 }
 
+// TaskTrash creates the trashed tasks list with a restore button for each task
+func TaskTrash(tasks []*domain.Task) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0)
+
+	for _, task := range tasks {
+		btn := menu.Data("♻️ "+task.Title, "restore_task", fmt.Sprintf("%d", task.ID))
+		rows = append(rows, menu.Row(btn))
+	}
+
+	rows = append(rows, menu.Row(menu.Data("⬅️ Back", "back_to_admin")))
+	menu.Inline(rows...)
+	return menu
+}
+
 // EditTask creates the edit task keyboard
 func EditTask(taskID int64) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
@@ -434,13 +624,16 @@ func Settings(notifyEnabled bool, isAdmin bool) *tele.ReplyMarkup {
 	changeEmojiBtn := menu.Data("😀 Change Emoji", "change_emoji")
 	// syncTimeBtn := menu.Data("🕐 Sync Time", "sync_time")
 	shareBtn := menu.Data("🔗 Share the Challenge", "share_id")
+	badgesBtn := menu.Data("🏅 My Badges", "my_badges")
+	activityBtn := menu.Data("🗓 My Activity", "activity_heatmap")
 	backBtn := menu.Data("⬅️ Back", "back_to_main")
 
 	rows := []tele.Row{
 		menu.Row(notifyBtn),
 		menu.Row(changeNameBtn, changeEmojiBtn),
 		// menu.Row(syncTimeBtn, shareBtn),
-		menu.Row(shareBtn),
+		menu.Row(shareBtn, badgesBtn),
+		menu.Row(activityBtn),
 	}
 
 	if !isAdmin {
@@ -454,6 +647,14 @@ func Settings(notifyEnabled bool, isAdmin bool) *tele.ReplyMarkup {
 	return menu
 }
 
+// BackToSettings creates a back to settings keyboard
+func BackToSettings() *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	backBtn := menu.Data("⬅️ Back", "settings")
+	menu.Inline(menu.Row(backBtn))
+	return menu
+}
+
 // LeaveConfirm creates leave challenge confirmation keyboard
 func LeaveConfirm() *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
@@ -555,12 +756,17 @@ func SuperAdminMenu() *tele.ReplyMarkup {
 	manageBtn := menu.Data("👑 Manage Admins", "sa_manage")
 	templatesAddBtn := menu.Data("📋 Templates Add", "sa_templates_add")
 	templatesEditBtn := menu.Data("✏️ Templates Edit", "sa_templates_edit")
+	templatesImportBtn := menu.Data("📥 Import Template", "sa_tpl_import")
+	auditLogBtn := menu.Data("📜 Audit Log", "sa_audit_log", "0")
+	trashBtn := menu.Data("🗑 Trash", "sa_trash")
 	backBtn := menu.Data("⬅️ Back", "exit_challenge")
 
 	menu.Inline(
 		menu.Row(allChallengesBtn),
 		menu.Row(grantBtn, manageBtn),
 		menu.Row(templatesAddBtn, templatesEditBtn),
+		menu.Row(templatesImportBtn),
+		menu.Row(auditLogBtn, trashBtn),
 		menu.Row(backBtn),
 	)
 	return menu
@@ -579,7 +785,8 @@ func AllChallengesObserver(
 		participants := participantCounts[ch.ID]
 		text := fmt.Sprintf("👁 %s (%d tasks, %d members)", ch.Name, tasks, participants)
 		btn := menu.Data(text, "sa_observe", ch.ID)
-		rows = append(rows, menu.Row(btn))
+		exportBtn := menu.Data("📤", "sa_export", ch.ID)
+		rows = append(rows, menu.Row(btn, exportBtn))
 	}
 
 	backBtn := menu.Data("⬅️ Back", "back_to_super_admin")
@@ -641,6 +848,43 @@ func ManageSuperAdmins(admins []*domain.SuperAdmin, currentUserID int64) *tele.R
 	return menu
 }
 
+// AuditLog - Page through an audit log. action opens another page, backAction leaves the log
+func AuditLog(action string, page, totalPages int, backAction string) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0)
+
+	if totalPages > 1 {
+		var nav []tele.Btn
+		if page > 0 {
+			nav = append(nav, menu.Data("◀️", action, fmt.Sprintf("%d", page-1)))
+		}
+		nav = append(nav, menu.Data(fmt.Sprintf("%d/%d", page+1, totalPages), "noop"))
+		if page < totalPages-1 {
+			nav = append(nav, menu.Data("▶️", action, fmt.Sprintf("%d", page+1)))
+		}
+		rows = append(rows, menu.Row(nav...))
+	}
+
+	rows = append(rows, menu.Row(menu.Data("⬅️ Back", backAction)))
+	menu.Inline(rows...)
+	return menu
+}
+
+// ChallengeTrash creates the trashed challenges list with a restore button for each challenge
+func ChallengeTrash(challenges []*domain.Challenge) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0)
+
+	for _, ch := range challenges {
+		btn := menu.Data("♻️ "+ch.Name, "sa_restore_challenge", ch.ID)
+		rows = append(rows, menu.Row(btn))
+	}
+
+	rows = append(rows, menu.Row(menu.Data("⬅️ Back", "back_to_super_admin")))
+	menu.Inline(rows...)
+	return menu
+}
+
 // BackToSuperAdmin creates a back to super admin menu button
 func BackToSuperAdmin() *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
@@ -753,42 +997,154 @@ func TemplateOrScratchChoice() *tele.ReplyMarkup {
 	return menu
 }
 
-// TemplatesList - List available templates for user selection
-func TemplatesList(templates []*domain.Template, taskCounts map[int64]int) *tele.ReplyMarkup {
+// TemplateListNav describes the template catalog view a TemplatesList keyboard belongs to
+type TemplateListNav struct {
+	Sort       string // "new" or "pop"
+	CategoryID int64  // 0 = all categories
+	Search     bool   // showing search results
+	Page       int
+	TotalPages int
+}
+
+// Args returns the "tpl_list" callback arguments for the given page and sort of this view
+func (n TemplateListNav) Args(page int, sort string) []string {
+	search := "0"
+	if n.Search {
+		search = "1"
+	}
+	return []string{sort, fmt.Sprintf("%d", n.CategoryID), fmt.Sprintf("%d", page), search}
+}
+
+// TemplatesList - List available templates for user selection, one page at a time.
+// Personal templates are listed in a separate "My templates" section below the global ones.
+func TemplatesList(templates []*domain.Template, myTemplates []*domain.Template, taskCounts map[int64]int, nav TemplateListNav) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
 	rows := make([]tele.Row, 0)
 
-	for _, tpl := range templates {
-		tasks := taskCounts[tpl.ID]
-		text := fmt.Sprintf("%s (%d tasks)", tpl.Name, tasks)
-		btn := menu.Data(text, "tpl_select", fmt.Sprintf("%d", tpl.ID))
-		rows = append(rows, menu.Row(btn))
+	// Sort toggle shows the order you switch to
+	var sortBtn tele.Btn
+	if nav.Sort == "pop" {
+		sortBtn = menu.Data("🆕 Newest", "tpl_list", nav.Args(0, "new")...)
+	} else {
+		sortBtn = menu.Data("🔥 Popular", "tpl_list", nav.Args(0, "pop")...)
+	}
+	categoriesBtn := menu.Data("🗂 Categories", "tpl_categories", nav.Sort)
+	searchBtn := menu.Data("🔍 Search", "tpl_search", nav.Sort)
+	rows = append(rows, menu.Row(sortBtn, categoriesBtn, searchBtn))
+
+	addTemplates := func(list []*domain.Template) {
+		for _, tpl := range list {
+			tasks := taskCounts[tpl.ID]
+			text := fmt.Sprintf("%s (%d tasks)", tpl.Name, tasks)
+			btn := menu.Data(text, "tpl_select", fmt.Sprintf("%d", tpl.ID))
+			rows = append(rows, menu.Row(btn))
+		}
+	}
+
+	addTemplates(templates)
+	if len(myTemplates) > 0 {
+		rows = append(rows, menu.Row(menu.Data("👤 My templates", "noop")))
+		addTemplates(myTemplates)
+	}
+
+	// Pagination
+	if nav.TotalPages > 1 {
+		var pageNav []tele.Btn
+		if nav.Page > 0 {
+			pageNav = append(pageNav, menu.Data("◀️", "tpl_list", nav.Args(nav.Page-1, nav.Sort)...))
+		}
+		pageNav = append(pageNav, menu.Data(fmt.Sprintf("%d/%d", nav.Page+1, nav.TotalPages), "noop"))
+		if nav.Page < nav.TotalPages-1 {
+			pageNav = append(pageNav, menu.Data("▶️", "tpl_list", nav.Args(nav.Page+1, nav.Sort)...))
+		}
+		rows = append(rows, menu.Row(pageNav...))
 	}
 
 	backBtn := menu.Data("⬅️ Back", "back_to_tpl_choice")
+	if nav.CategoryID > 0 || nav.Search {
+		showAllBtn := menu.Data("✖️ Show all", "tpl_list", nav.Sort, "0", "0", "0")
+		rows = append(rows, menu.Row(showAllBtn, backBtn))
+	} else {
+		rows = append(rows, menu.Row(backBtn))
+	}
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// TemplateCategories - Category browser for the template catalog; counts are global templates per category
+func TemplateCategories(categories []*domain.TemplateCategory, counts map[int64]int, sort string) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0)
+
+	for _, category := range categories {
+		if counts[category.ID] == 0 {
+			continue
+		}
+		text := fmt.Sprintf("%s (%d)", category.Name, counts[category.ID])
+		btn := menu.Data(text, "tpl_list", sort, fmt.Sprintf("%d", category.ID), "0", "0")
+		rows = append(rows, menu.Row(btn))
+	}
+
+	backBtn := menu.Data("⬅️ Back", "tpl_list", sort, "0", "0", "0")
 	rows = append(rows, menu.Row(backBtn))
 
 	menu.Inline(rows...)
 	return menu
 }
 
-// TemplateDetails - Show template details for user
-func TemplateDetails(templateID int64) *tele.ReplyMarkup {
+// TemplateSearchPrompt - Back button while waiting for a template search query
+func TemplateSearchPrompt(sort string) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	backBtn := menu.Data("⬅️ Back", "tpl_list", sort, "0", "0", "0")
+	menu.Inline(menu.Row(backBtn))
+	return menu
+}
+
+// TemplateDetails - Show template details for user; shareCode is set when it was opened via share link
+func TemplateDetails(templateID int64, shareCode string, isOwner bool) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
 
-	seeTasksBtn := menu.Data("📋 See Tasks", "tpl_tasks", fmt.Sprintf("%d", templateID))
-	createBtn := menu.Data("✅ Create from Template", "tpl_create", fmt.Sprintf("%d", templateID))
+	seeTasksBtn := menu.Data("📋 See Tasks", "tpl_tasks", fmt.Sprintf("%d", templateID), shareCode)
+	createBtn := menu.Data("✅ Create challenge from this", "tpl_create", fmt.Sprintf("%d", templateID), shareCode)
 	backBtn := menu.Data("⬅️ Back", "back_to_tpl_list")
 
-	menu.Inline(
-		menu.Row(seeTasksBtn),
-		menu.Row(createBtn, backBtn),
-	)
+	rows := []tele.Row{menu.Row(seeTasksBtn)}
+	if isOwner {
+		shareBtn := menu.Data("🔗 Share", "tpl_share", fmt.Sprintf("%d", templateID))
+		deleteBtn := menu.Data("🗑 Delete Template", "tpl_delete", fmt.Sprintf("%d", templateID))
+		rows = append(rows, menu.Row(shareBtn, deleteBtn))
+	}
+	rows = append(rows, menu.Row(createBtn, backBtn))
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// TemplateShareLink - Shown with a template's share link; revokeUnique and backUnique take the template ID
+func TemplateShareLink(templateID int64, revokeUnique, backUnique string) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	revokeBtn := menu.Data("🚫 Revoke Link", revokeUnique, fmt.Sprintf("%d", templateID))
+	backBtn := menu.Data("⬅️ Back", backUnique, fmt.Sprintf("%d", templateID))
+
+	menu.Inline(menu.Row(revokeBtn, backBtn))
+	return menu
+}
+
+// DeletePersonalTemplateConfirm - Confirm deletion of the user's own template
+func DeletePersonalTemplateConfirm(templateID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	confirmBtn := menu.Data("🗑 Yes, delete", "tpl_del_confirm", fmt.Sprintf("%d", templateID))
+	cancelBtn := menu.Data("❌ Cancel", "tpl_select", fmt.Sprintf("%d", templateID))
+
+	menu.Inline(menu.Row(confirmBtn, cancelBtn))
 	return menu
 }
 
 // TemplateTasksList - View template tasks (read-only)
-func TemplateTasksList(tasks []*domain.TemplateTask, templateID int64) *tele.ReplyMarkup {
+func TemplateTasksList(tasks []*domain.TemplateTask, templateID int64, shareCode string) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
 	rows := make([]tele.Row, 0)
 
@@ -798,11 +1154,12 @@ func TemplateTasksList(tasks []*domain.TemplateTask, templateID int64) *tele.Rep
 			"tpl_task_detail",
 			fmt.Sprintf("%d", templateID),
 			fmt.Sprintf("%d", task.ID),
+			shareCode,
 		)
 		rows = append(rows, menu.Row(btn))
 	}
 
-	backBtn := menu.Data("⬅️ Back", "tpl_select", fmt.Sprintf("%d", templateID))
+	backBtn := menu.Data("⬅️ Back", "tpl_select", fmt.Sprintf("%d", templateID), shareCode)
 	rows = append(rows, menu.Row(backBtn))
 
 	menu.Inline(rows...)
@@ -827,9 +1184,9 @@ func BackToSATplTasks(challengeID string) *tele.ReplyMarkup {
 }
 
 // BackToTplTasks - Back button from user template task detail view
-func BackToTplTasks(templateID int64) *tele.ReplyMarkup {
+func BackToTplTasks(templateID int64, shareCode string) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
-	backBtn := menu.Data("⬅️ Back", "tpl_tasks", fmt.Sprintf("%d", templateID))
+	backBtn := menu.Data("⬅️ Back", "tpl_tasks", fmt.Sprintf("%d", templateID), shareCode)
 	menu.Inline(menu.Row(backBtn))
 	return menu
 }
@@ -880,18 +1237,142 @@ func TemplateAdminPanel(templateID int64, dailyLimit int, hideFutureTasks bool)
 	}
 	hideBtn := menu.Data(hideText, "sa_tpl_toggle_hide", fmt.Sprintf("%d", templateID))
 
+	bulkAddBtn := menu.Data("📑 Bulk Add", "sa_tpl_bulk_add", fmt.Sprintf("%d", templateID))
+	exportJSONBtn := menu.Data("📤 Export JSON", "sa_tpl_export", fmt.Sprintf("%d", templateID), "json")
+	exportYAMLBtn := menu.Data("📤 Export YAML", "sa_tpl_export", fmt.Sprintf("%d", templateID), "yaml")
+	shareBtn := menu.Data("🔗 Share Link", "sa_tpl_share", fmt.Sprintf("%d", templateID))
+	pushBtn := menu.Data("🔄 Push to Challenges", "sa_tpl_push", fmt.Sprintf("%d", templateID))
+	categoryBtn := menu.Data("🗂 Category", "sa_tpl_category", fmt.Sprintf("%d", templateID))
+	tagsBtn := menu.Data("🏷 Tags", "sa_tpl_edit_tags", fmt.Sprintf("%d", templateID))
+	historyBtn := menu.Data("🕘 History", "sa_tpl_versions", fmt.Sprintf("%d", templateID), "0")
+
 	deleteBtn := menu.Data("🗑 Delete Template", "sa_tpl_del_select", fmt.Sprintf("%d", templateID))
 	backBtn := menu.Data("⬅️ Back", "back_to_sa_tpl_edit")
 
 	menu.Inline(
-		menu.Row(addTaskBtn, editTasksBtn),
+		menu.Row(addTaskBtn, bulkAddBtn),
+		menu.Row(editTasksBtn, historyBtn),
 		menu.Row(editNameBtn, editDescBtn),
 		menu.Row(limitBtn, hideBtn),
+		menu.Row(categoryBtn, tagsBtn),
+		menu.Row(exportJSONBtn, exportYAMLBtn),
+		menu.Row(shareBtn, pushBtn),
 		menu.Row(deleteBtn, backBtn),
 	)
 	return menu
 }
 
+// TemplatePushPreview - Confirm offering a template update to the admins of pending challenges
+func TemplatePushPreview(templateID int64, pending int) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	backBtn := menu.Data("⬅️ Back", "sa_tpl_edit_select", fmt.Sprintf("%d", templateID))
+	if pending == 0 {
+		menu.Inline(menu.Row(backBtn))
+		return menu
+	}
+	sendBtn := menu.Data(fmt.Sprintf("📨 Offer to %d admins", pending), "sa_tpl_push_confirm", fmt.Sprintf("%d", templateID))
+	menu.Inline(menu.Row(sendBtn), menu.Row(backBtn))
+	return menu
+}
+
+// TemplateVersions - Paginated version history of a template, newest first
+func TemplateVersions(templateID int64, versions []*domain.TemplateVersion, current, page, totalPages int) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0)
+	tid := fmt.Sprintf("%d", templateID)
+
+	for _, v := range versions {
+		text := fmt.Sprintf("v%d · %s", v.Version, v.CreatedAt.UTC().Format("Jan 2 15:04"))
+		if v.Version == current {
+			text = "✅ " + text
+		}
+		rows = append(rows, menu.Row(menu.Data(text, "sa_tpl_version", fmt.Sprintf("%d", v.ID), fmt.Sprintf("%d", page))))
+	}
+
+	if totalPages > 1 {
+		var nav []tele.Btn
+		if page > 0 {
+			nav = append(nav, menu.Data("◀️", "sa_tpl_versions", tid, fmt.Sprintf("%d", page-1)))
+		}
+		nav = append(nav, menu.Data(fmt.Sprintf("%d/%d", page+1, totalPages), "noop"))
+		if page < totalPages-1 {
+			nav = append(nav, menu.Data("▶️", "sa_tpl_versions", tid, fmt.Sprintf("%d", page+1)))
+		}
+		rows = append(rows, menu.Row(nav...))
+	}
+
+	rows = append(rows, menu.Row(menu.Data("⬅️ Back", "sa_tpl_edit_select", tid)))
+	menu.Inline(rows...)
+	return menu
+}
+
+// TemplateVersionView - Restore a template version or go back to the history
+func TemplateVersionView(version *domain.TemplateVersion, page int, isCurrent bool) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	backBtn := menu.Data("⬅️ Back to History", "sa_tpl_versions", fmt.Sprintf("%d", version.TemplateID), fmt.Sprintf("%d", page))
+	if isCurrent {
+		menu.Inline(menu.Row(backBtn))
+		return menu
+	}
+	restoreBtn := menu.Data("♻️ Restore This Version", "sa_tpl_ver_restore", fmt.Sprintf("%d", version.ID), fmt.Sprintf("%d", page))
+	menu.Inline(menu.Row(restoreBtn), menu.Row(backBtn))
+	return menu
+}
+
+// RestoreTemplateVersionConfirm - Confirm restoring a template version
+func RestoreTemplateVersionConfirm(versionID int64, page int) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	confirmBtn := menu.Data("♻️ Yes, restore", "sa_tpl_ver_restore_ok", fmt.Sprintf("%d", versionID))
+	cancelBtn := menu.Data("❌ Cancel", "sa_tpl_version", fmt.Sprintf("%d", versionID), fmt.Sprintf("%d", page))
+
+	menu.Inline(menu.Row(confirmBtn, cancelBtn))
+	return menu
+}
+
+// TemplateCategoryPicker - Choose a template's category, create or delete categories
+func TemplateCategoryPicker(templateID int64, categories []*domain.TemplateCategory, currentID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+	rows := make([]tele.Row, 0)
+	tid := fmt.Sprintf("%d", templateID)
+
+	noneText := "Uncategorized"
+	if currentID == 0 {
+		noneText = "✅ " + noneText
+	}
+	rows = append(rows, menu.Row(menu.Data(noneText, "sa_tpl_set_cat", tid, "0")))
+
+	for _, category := range categories {
+		text := category.Name
+		if category.ID == currentID {
+			text = "✅ " + text
+		}
+		cid := fmt.Sprintf("%d", category.ID)
+		rows = append(rows, menu.Row(
+			menu.Data(text, "sa_tpl_set_cat", tid, cid),
+			menu.Data("🗑", "sa_cat_delete", tid, cid),
+		))
+	}
+
+	newBtn := menu.Data("➕ New Category", "sa_cat_new", tid)
+	backBtn := menu.Data("⬅️ Back", "sa_tpl_edit_select", tid)
+	rows = append(rows, menu.Row(newBtn, backBtn))
+
+	menu.Inline(rows...)
+	return menu
+}
+
+// DeleteCategoryConfirm - Confirm deletion of a template category
+func DeleteCategoryConfirm(templateID, categoryID int64) *tele.ReplyMarkup {
+	menu := &tele.ReplyMarkup{}
+
+	confirmBtn := menu.Data("🗑 Yes, delete", "sa_cat_del_confirm", fmt.Sprintf("%d", templateID), fmt.Sprintf("%d", categoryID))
+	cancelBtn := menu.Data("❌ Cancel", "sa_tpl_category", fmt.Sprintf("%d", templateID))
+
+	menu.Inline(menu.Row(confirmBtn, cancelBtn))
+	return menu
+}
+
 // EditTemplateTasksList creates the edit tasks list keyboard for templates
 func EditTemplateTasksList(tasks []*domain.TemplateTask, templateID int64) *tele.ReplyMarkup {
 	menu := &tele.ReplyMarkup{}
@@ -0,0 +1,233 @@
+package bot
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/metrics"
+	tele "gopkg.in/telebot.v3"
+)
+
+// RateLimits are how many updates of each kind one user may send per minute; 0 disables the limit
+type RateLimits struct {
+	Text      int // messages, commands, photos and documents
+	Callback  int // button taps
+	Expensive int // taps on expensiveActions, counted instead of Callback
+}
+
+// expensiveActions are callback actions that do many database reads, render images or
+// notify every participant
+var expensiveActions = map[string]bool{
+	"complete_current":    true,
+	"complete_task":       true,
+	"uncomplete_task":     true,
+	"randomize_tasks":     true,
+	"sa_tpl_randomize":    true,
+	"team_progress":       true,
+	"progress_card":       true,
+	"burnup_chart":        true,
+	"activity_heatmap":    true,
+	"export":              true,
+	"export_challenge":    true,
+	"sa_export":           true,
+	"nudge":               true,
+	"kudos":               true,
+	"tpl_upd_apply":       true,
+	"sa_tpl_push_confirm": true,
+}
+
+const (
+	// offenderWindow and offenderStrikes define a repeat offender: a user with offenderStrikes
+	// throttled updates within offenderWindow. They are reported once per window
+	offenderWindow  = 10 * time.Minute
+	offenderStrikes = 30
+)
+
+// bucket is one user's token bucket
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// limiter holds a token bucket per user for one kind of update. A user can send perMinute
+// updates at once, after that tokens refill evenly over the minute
+type limiter struct {
+	rate    float64 // tokens per second
+	burst   float64
+	buckets map[int64]*bucket
+}
+
+// newLimiter returns nil when perMinute disables the limit
+func newLimiter(perMinute int) *limiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &limiter{
+		rate:    float64(perMinute) / 60,
+		burst:   float64(perMinute),
+		buckets: make(map[int64]*bucket),
+	}
+}
+
+// take spends a token of userID's bucket. It returns 0 when a token was available and how
+// long until the next one otherwise
+func (l *limiter) take(userID int64, now time.Time) time.Duration {
+	b, ok := l.buckets[userID]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[userID] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// prune drops buckets that have refilled completely, they are the same as new ones
+func (l *limiter) prune(now time.Time) {
+	full := time.Duration(l.burst / l.rate * float64(time.Second))
+	for userID, b := range l.buckets {
+		if now.Sub(b.last) >= full {
+			delete(l.buckets, userID)
+		}
+	}
+}
+
+// offender tracks a user's throttled updates within offenderWindow
+type offender struct {
+	throttled int
+	since     time.Time
+	warned    bool // told about the cooldown since the last update that went through
+}
+
+// verdict is the rate limiter's decision about one update
+type verdict struct {
+	wait      time.Duration // 0 = let the update through
+	warn      bool          // first throttled update since the last one that went through
+	report    bool          // the user just became a repeat offender
+	throttled int           // the user's throttled updates within offenderWindow
+}
+
+// rateLimiter drops updates from users going over their RateLimits
+type rateLimiter struct {
+	mu        sync.Mutex
+	text      *limiter
+	callback  *limiter
+	expensive *limiter
+	offenders map[int64]*offender
+	lastPrune time.Time
+
+	// onOffender is called outside the lock when a user becomes a repeat offender
+	onOffender func(userID int64, throttled int)
+}
+
+func newRateLimiter(limits RateLimits, onOffender func(userID int64, throttled int)) *rateLimiter {
+	return &rateLimiter{
+		text:       newLimiter(limits.Text),
+		callback:   newLimiter(limits.Callback),
+		expensive:  newLimiter(limits.Expensive),
+		offenders:  make(map[int64]*offender),
+		lastPrune:  time.Now(),
+		onOffender: onOffender,
+	}
+}
+
+// limiterFor returns the limiter an update counts against and its kind for metrics, or nil
+// for updates that are not limited
+func (r *rateLimiter) limiterFor(c tele.Context) (*limiter, string) {
+	if cb := c.Callback(); cb != nil {
+		// Same parsing as HandleCallback: "\f" prefix, then action|param1|param2
+		action, _, _ := strings.Cut(strings.TrimPrefix(cb.Data, "\f"), "|")
+		if expensiveActions[action] {
+			return r.expensive, "expensive"
+		}
+		return r.callback, "callback"
+	}
+	if c.Message() != nil {
+		return r.text, "text"
+	}
+	return nil, ""
+}
+
+// check spends a token of userID's bucket in l and tracks the user as an offender when none is left
+func (r *rateLimiter) check(l *limiter, userID int64, now time.Time) verdict {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Sub(r.lastPrune) >= offenderWindow {
+		r.prune(now)
+	}
+
+	o := r.offenders[userID]
+	wait := l.take(userID, now)
+	if wait == 0 {
+		if o != nil {
+			o.warned = false
+		}
+		return verdict{}
+	}
+
+	if o == nil || now.Sub(o.since) >= offenderWindow {
+		o = &offender{since: now}
+		r.offenders[userID] = o
+	}
+	o.throttled++
+	v := verdict{wait: wait, warn: !o.warned, report: o.throttled == offenderStrikes, throttled: o.throttled}
+	o.warned = true
+	return v
+}
+
+// prune forgets users who are back to a full bucket and offenders whose window is over
+func (r *rateLimiter) prune(now time.Time) {
+	for _, l := range []*limiter{r.text, r.callback, r.expensive} {
+		if l != nil {
+			l.prune(now)
+		}
+	}
+	for userID, o := range r.offenders {
+		if now.Sub(o.since) >= offenderWindow {
+			delete(r.offenders, userID)
+		}
+	}
+	r.lastPrune = now
+}
+
+// middleware drops updates over the limit. Throttled button taps always get a short cooldown
+// notice, since Telegram keeps the button spinning otherwise; throttled messages get one per cooldown
+func (r *rateLimiter) middleware() tele.MiddlewareFunc {
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			l, kind := r.limiterFor(c)
+			if l == nil || c.Sender() == nil {
+				return next(c)
+			}
+
+			userID := c.Sender().ID
+			v := r.check(l, userID, time.Now())
+			if v.wait == 0 {
+				return next(c)
+			}
+
+			metrics.RateLimited(kind)
+			if v.report && r.onOffender != nil {
+				r.onOffender(userID, v.throttled)
+			}
+
+			msg := fmt.Sprintf("🐢 Easy there! Give it %d s and try again.", int(math.Ceil(v.wait.Seconds())))
+			if c.Callback() != nil {
+				return c.Respond(&tele.CallbackResponse{Text: msg})
+			}
+			if v.warn {
+				return c.Send(msg)
+			}
+			return nil
+		}
+	}
+}
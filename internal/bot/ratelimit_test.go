@@ -0,0 +1,126 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/testutil"
+	tele "gopkg.in/telebot.v3"
+)
+
+func TestLimiter_Take(t *testing.T) {
+	l := newLimiter(6) // one token every 10 s
+	now := time.Unix(0, 0)
+
+	for i := 0; i < 6; i++ {
+		if wait := l.take(1, now); wait != 0 {
+			t.Fatalf("take() #%d wait = %v, want a token from the burst", i+1, wait)
+		}
+	}
+	if wait := l.take(1, now); wait != 10*time.Second {
+		t.Errorf("take() over burst wait = %v, want 10s", wait)
+	}
+	if wait := l.take(2, now); wait != 0 {
+		t.Errorf("take() for another user wait = %v, want 0", wait)
+	}
+
+	if wait := l.take(1, now.Add(10*time.Second)); wait != 0 {
+		t.Errorf("take() after refill wait = %v, want 0", wait)
+	}
+
+	if newLimiter(0) != nil {
+		t.Error("newLimiter(0) should disable the limit")
+	}
+}
+
+func TestRateLimiter_RepeatOffender(t *testing.T) {
+	r := newRateLimiter(RateLimits{Callback: 1}, nil)
+	now := time.Unix(0, 0)
+
+	if v := r.check(r.callback, 1, now); v.wait != 0 {
+		t.Fatalf("first check() wait = %v, want 0", v.wait)
+	}
+
+	reports := 0
+	for i := 1; i <= offenderStrikes+5; i++ {
+		v := r.check(r.callback, 1, now)
+		if v.wait == 0 {
+			t.Fatalf("check() #%d let a throttled update through", i)
+		}
+		if v.warn != (i == 1) {
+			t.Errorf("check() #%d warn = %v, want a warning only for the first throttled update", i, v.warn)
+		}
+		if v.report {
+			reports++
+			if v.throttled != offenderStrikes {
+				t.Errorf("reported throttled = %d, want %d", v.throttled, offenderStrikes)
+			}
+		}
+	}
+	if reports != 1 {
+		t.Errorf("reports = %d, want 1 per window", reports)
+	}
+
+	// A new window starts counting again
+	later := now.Add(offenderWindow)
+	r.check(r.callback, 1, later)
+	if v := r.check(r.callback, 1, later); v.throttled != 1 || !v.warn {
+		t.Errorf("check() in a new window = %+v, want the first throttled update", v)
+	}
+}
+
+func TestRateLimiter_Middleware(t *testing.T) {
+	var offenders []int64
+	r := newRateLimiter(RateLimits{Text: 1, Callback: 1, Expensive: 0}, func(userID int64, throttled int) {
+		offenders = append(offenders, userID)
+	})
+
+	handled := 0
+	handler := r.middleware()(func(c tele.Context) error {
+		handled++
+		return nil
+	})
+
+	// Text: the first throttled message gets a cooldown notice, later ones are dropped silently
+	handler(testutil.NewMockContext(1).WithMessage("hi"))
+	ctx := testutil.NewMockContext(1).WithMessage("hi")
+	handler(ctx)
+	if !strings.Contains(ctx.LastMessage(), "Easy there") {
+		t.Errorf("Throttled message reply = %q, want a cooldown notice", ctx.LastMessage())
+	}
+	handler(testutil.NewMockContext(1).WithMessage("hi"))
+	ctx = testutil.NewMockContext(1).WithMessage("hi")
+	handler(ctx)
+	if handled != 1 {
+		t.Errorf("handled = %d text updates, want 1", handled)
+	}
+	if ctx.MessageCount() != 0 {
+		t.Errorf("Repeated throttled message got %d replies, want 0", ctx.MessageCount())
+	}
+
+	// Callbacks have their own bucket and are always answered
+	handler(testutil.NewMockContext(1).WithCallback("settings"))
+	ctx = testutil.NewMockContext(1).WithCallback("settings")
+	handler(ctx)
+	if handled != 2 {
+		t.Errorf("handled = %d updates, want 2", handled)
+	}
+	if !ctx.RespondCalled {
+		t.Error("Throttled callback should get a cooldown response")
+	}
+
+	// Expensive limit disabled
+	handler(testutil.NewMockContext(1).WithCallback("complete_current"))
+	handler(testutil.NewMockContext(1).WithCallback("complete_current"))
+	if handled != 4 {
+		t.Errorf("handled = %d updates, want 4 with the expensive limit disabled", handled)
+	}
+
+	for i := 0; i < offenderStrikes; i++ {
+		handler(testutil.NewMockContext(1).WithCallback("settings"))
+	}
+	if len(offenders) != 1 || offenders[0] != 1 {
+		t.Errorf("offenders = %v, want user 1 reported once", offenders)
+	}
+}
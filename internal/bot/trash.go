@@ -0,0 +1,32 @@
+package bot
+
+import (
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+)
+
+// trashPurgeInterval is how often tasks and challenges past the trash retention window are purged
+const trashPurgeInterval = time.Hour
+
+// purgeTrash purges expired trash right away and then every trashPurgeInterval until stop is closed
+func purgeTrash(trash *service.TrashService, stop <-chan struct{}) {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		tasks, challenges, err := trash.Purge(time.Now())
+		if err != nil {
+			logger.Error("Failed to purge trash", "error", err)
+		} else if tasks > 0 || challenges > 0 {
+			logger.Info("Trash purged", "tasks", tasks, "challenges", challenges)
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// AchievementsData holds data for the badges (profile) view
+type AchievementsData struct {
+	ChallengeName string
+	Earned        []domain.AchievementInfo // badges earned in this challenge
+	AllTimeCounts map[string]int           // badge code -> times earned across all challenges
+}
+
+// RenderAchievements renders the user's badges in the current challenge and all-time totals
+func RenderAchievements(data AchievementsData) string {
+	var sb strings.Builder
+
+	sb.WriteString("🏅 <i>Your Badges</i>\n\n")
+	sb.WriteString(fmt.Sprintf("<b>In %s:</b>\n", data.ChallengeName))
+
+	earned := make(map[string]bool, len(data.Earned))
+	for _, a := range data.Earned {
+		earned[a.Code] = true
+	}
+
+	for _, a := range domain.Achievements {
+		if earned[a.Code] {
+			sb.WriteString(fmt.Sprintf("%s <b>%s</b> — %s\n", a.Emoji, a.Title, a.Description))
+		} else {
+			sb.WriteString(fmt.Sprintf("🔒 %s — %s\n", a.Title, a.Description))
+		}
+	}
+
+	var allTime []string
+	for _, a := range domain.Achievements {
+		if n := data.AllTimeCounts[a.Code]; n > 0 {
+			allTime = append(allTime, fmt.Sprintf("%s ×%d", a.Emoji, n))
+		}
+	}
+	if len(allTime) > 0 {
+		sb.WriteString("\n<b>All challenges:</b>\n")
+		sb.WriteString(strings.Join(allTime, "  ") + "\n")
+	}
+
+	return sb.String()
+}
+
+// renderBadges renders a compact inline list of badge emojis
+func renderBadges(badges []domain.AchievementInfo) string {
+	emojis := make([]string, len(badges))
+	for i, b := range badges {
+		emojis[i] = b.Emoji
+	}
+	return strings.Join(emojis, " ")
+}
@@ -0,0 +1,151 @@
+package views
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// maxAuditValue keeps a page of audit entries within one message
+const maxAuditValue = 40
+
+var auditLabels = map[string]string{
+	domain.AuditChallengeRename:      "Renamed challenge",
+	domain.AuditChallengeDescription: "Changed description of",
+	domain.AuditChallengeDailyLimit:  "Changed daily limit of",
+	domain.AuditChallengeSequential:  "Changed mode of",
+	domain.AuditChallengeShuffle:     "Changed task order of",
+	domain.AuditChallengeDelete:      "Deleted challenge",
+	domain.AuditChallengeRestore:     "Restored challenge",
+	domain.AuditChallengeSync:        "Applied template update to",
+
+	domain.AuditTaskCreate:      "Added task",
+	domain.AuditTaskBulkCreate:  "Bulk added tasks to",
+	domain.AuditTaskImport:      "Imported tasks into",
+	domain.AuditTaskTitle:       "Renamed task",
+	domain.AuditTaskDescription: "Changed description of task",
+	domain.AuditTaskImage:       "Changed image of task",
+	domain.AuditTaskDelete:      "Deleted task",
+	domain.AuditTaskRestore:     "Restored task",
+	domain.AuditTaskMove:        "Moved task",
+	domain.AuditTaskShuffle:     "Shuffled tasks of",
+
+	domain.AuditSuperAdminGrant:  "Granted super admin to",
+	domain.AuditSuperAdminRevoke: "Revoked super admin from",
+	domain.AuditUserRateLimited:  "Rate limited",
+
+	domain.AuditTemplateRename:          "Renamed template",
+	domain.AuditTemplateDescription:     "Changed description of template",
+	domain.AuditTemplateDailyLimit:      "Changed daily limit of template",
+	domain.AuditTemplateSequential:      "Changed mode of template",
+	domain.AuditTemplateDelete:          "Deleted template",
+	domain.AuditTemplateRestore:         "Restored template",
+	domain.AuditTemplateTaskCreate:      "Added template task",
+	domain.AuditTemplateTaskBulkCreate:  "Bulk added tasks to template",
+	domain.AuditTemplateTaskTitle:       "Renamed template task",
+	domain.AuditTemplateTaskDescription: "Changed description of template task",
+	domain.AuditTemplateTaskImage:       "Changed image of template task",
+	domain.AuditTemplateTaskDelete:      "Deleted template task",
+	domain.AuditTemplateTaskMove:        "Moved template task",
+	domain.AuditTemplateTaskShuffle:     "Shuffled tasks of template",
+}
+
+// RenderAuditLog renders a page of audit entries. withScope adds the challenge or
+// template each entry belongs to, for the global log
+func RenderAuditLog(title string, entries []*domain.AuditEntry, withScope bool) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("📜 <b>%s</b>\n\n", html.EscapeString(title)))
+	if len(entries) == 0 {
+		sb.WriteString("No changes recorded yet.")
+		return sb.String()
+	}
+
+	for _, e := range entries {
+		sb.WriteString(fmt.Sprintf("<i>%s UTC</i> · %s", e.CreatedAt.UTC().Format("2006-01-02 15:04"), FormatEditor(e.ActorID)))
+		if e.Observer {
+			sb.WriteString(" 👁")
+		}
+		sb.WriteString("\n")
+		sb.WriteString(renderAuditEntry(e))
+		if withScope {
+			switch {
+			case e.ChallengeID != "":
+				sb.WriteString(fmt.Sprintf("\n<i>Challenge</i> <code>%s</code>", html.EscapeString(e.ChallengeID)))
+			case e.TemplateID != 0:
+				sb.WriteString(fmt.Sprintf("\n<i>Template #%d</i>", e.TemplateID))
+			}
+		}
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("<i>👁 = super admin in observer mode</i>")
+
+	return sb.String()
+}
+
+// renderAuditEntry renders what an audit entry changed
+func renderAuditEntry(e *domain.AuditEntry) string {
+	label, ok := auditLabels[e.Action]
+	if !ok {
+		label = e.Action
+	}
+
+	text := label
+	if e.TargetUserID != 0 {
+		text += " " + FormatEditor(e.TargetUserID)
+	} else if e.TargetName != "" {
+		text += " <b>" + html.EscapeString(truncateAuditValue(e.TargetName)) + "</b>"
+	}
+
+	switch e.Action {
+	case domain.AuditTaskBulkCreate, domain.AuditTaskImport, domain.AuditTemplateTaskBulkCreate:
+		return text + fmt.Sprintf(" (%s tasks)", html.EscapeString(e.After))
+	case domain.AuditUserRateLimited:
+		return text + fmt.Sprintf(" (%s updates dropped)", html.EscapeString(e.After))
+	}
+	if e.Before == "" && e.After == "" {
+		return text
+	}
+	return text + fmt.Sprintf("\n%s → %s", formatAuditValue(e.Action, e.Before), formatAuditValue(e.Action, e.After))
+}
+
+// formatAuditValue renders a before or after value of an audit entry
+func formatAuditValue(action, value string) string {
+	switch action {
+	case domain.AuditChallengeDailyLimit, domain.AuditTemplateDailyLimit:
+		if value == "0" {
+			return "no limit"
+		}
+		return html.EscapeString(value) + "/day"
+	case domain.AuditChallengeSequential, domain.AuditTemplateSequential:
+		if value == "true" {
+			return "Sequential"
+		}
+		return "All Visible"
+	case domain.AuditChallengeShuffle:
+		if value == "true" {
+			return "Per Person"
+		}
+		return "Shared"
+	case domain.AuditChallengeSync, domain.AuditTemplateRestore:
+		if value == "" || value == "0" {
+			return "none"
+		}
+		return "v" + html.EscapeString(value)
+	case domain.AuditTaskMove, domain.AuditTemplateTaskMove:
+		return "#" + html.EscapeString(value)
+	}
+	if value == "" {
+		return "<i>empty</i>"
+	}
+	return "<i>" + html.EscapeString(truncateAuditValue(value)) + "</i>"
+}
+
+func truncateAuditValue(value string) string {
+	if runes := []rune(value); len(runes) > maxAuditValue {
+		return strings.TrimSpace(string(runes[:maxAuditValue])) + "…"
+	}
+	return value
+}
@@ -0,0 +1,56 @@
+package views
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// BulkTaskItem holds display info for one task in a bulk-add preview
+type BulkTaskItem struct {
+	Title          string
+	HasDescription bool
+}
+
+// BulkPreviewData holds data for rendering a bulk-add preview
+type BulkPreviewData struct {
+	Tasks         []BulkTaskItem
+	Errors        []string
+	ExistingCount int
+	MaxTasks      int
+}
+
+// maxBulkPreviewErrors caps how many validation errors are listed
+const maxBulkPreviewErrors = 10
+
+// RenderBulkPreview renders the parsed tasks and validation errors of a pasted list
+func RenderBulkPreview(data BulkPreviewData) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("📑 <b>Preview: %d tasks</b>\n", len(data.Tasks)))
+	sb.WriteString(fmt.Sprintf("<i>%d existing + %d new = %d of %d</i>\n\n",
+		data.ExistingCount, len(data.Tasks), data.ExistingCount+len(data.Tasks), data.MaxTasks))
+
+	for i, t := range data.Tasks {
+		line := fmt.Sprintf("%d. %s", data.ExistingCount+i+1, html.EscapeString(t.Title))
+		if t.HasDescription {
+			line += " 📝"
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	if len(data.Errors) > 0 {
+		sb.WriteString("\n⚠️ <b>Fix these and send the list again:</b>\n")
+		for i, e := range data.Errors {
+			if i == maxBulkPreviewErrors {
+				sb.WriteString(fmt.Sprintf("…and %d more\n", len(data.Errors)-i))
+				break
+			}
+			sb.WriteString("• " + html.EscapeString(e) + "\n")
+		}
+	} else {
+		sb.WriteString("\nLooks good! Send another message to replace the list.")
+	}
+
+	return sb.String()
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 )
 
 // CelebrationData holds data for the celebration view
@@ -12,6 +14,8 @@ type CelebrationData struct {
 	TotalTasks     int
 	CompletedTasks int
 	TimeTaken      time.Duration
+	KudosReceived  int
+	Badges         []domain.AchievementInfo
 	TeamStatus     []*TeamMemberStatus
 }
 
@@ -22,6 +26,7 @@ type TeamMemberStatus struct {
 	IsCompleted    bool
 	CompletedTasks int
 	TotalTasks     int
+	Kudos          int
 }
 
 // RenderCelebration renders the celebration view
@@ -33,17 +38,28 @@ func RenderCelebration(data CelebrationData) string {
 
 	// Stats
 	sb.WriteString(fmt.Sprintf("🕓 Finished in %s\n", formatDuration(data.TimeTaken)))
-	sb.WriteString(fmt.Sprintf("📊 %d/%d tasks done\n\n", data.CompletedTasks, data.TotalTasks))
+	sb.WriteString(fmt.Sprintf("📊 %d/%d tasks done\n", data.CompletedTasks, data.TotalTasks))
+	if data.KudosReceived > 0 {
+		sb.WriteString(fmt.Sprintf("👏 %d kudos from the squad\n", data.KudosReceived))
+	}
+	if len(data.Badges) > 0 {
+		sb.WriteString(fmt.Sprintf("🏅 Badges: %s\n", renderBadges(data.Badges)))
+	}
+	sb.WriteString("\n")
 
 	// Squad status
 	sb.WriteString("👥 How's the squad doing:\n")
 	for _, member := range data.TeamStatus {
 		if member.IsCompleted {
-			sb.WriteString(fmt.Sprintf("%s %s — ✅ Crushed it!\n", member.Emoji, member.Name))
+			sb.WriteString(fmt.Sprintf("%s %s — ✅ Crushed it!", member.Emoji, member.Name))
 		} else {
-			sb.WriteString(fmt.Sprintf("%s %s — 🔄 %d/%d\n",
+			sb.WriteString(fmt.Sprintf("%s %s — 🔄 %d/%d",
 				member.Emoji, member.Name, member.CompletedTasks, member.TotalTasks))
 		}
+		if member.Kudos > 0 {
+			sb.WriteString(fmt.Sprintf("  👏%d", member.Kudos))
+		}
+		sb.WriteString("\n")
 	}
 
 	return sb.String()
@@ -0,0 +1,58 @@
+package views
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// CommentItem holds display info for a single task comment
+type CommentItem struct {
+	Number    int // position on the current page, used for admin delete buttons
+	Emoji     string
+	Name      string
+	Text      string
+	CreatedAt time.Time
+}
+
+// TaskCommentsData holds data for rendering the full comments view of a task
+type TaskCommentsData struct {
+	TaskOrderNum int
+	TaskTitle    string
+	Comments     []*CommentItem
+	Page         int // 0-based
+	TotalPages   int
+	ShowNumbers  bool
+}
+
+// RenderTaskComments renders a page of task comments
+func RenderTaskComments(data TaskCommentsData) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("💬 <i>Comments</i> — Task #%d: <b>%s</b>\n\n", data.TaskOrderNum, data.TaskTitle))
+
+	if len(data.Comments) == 0 {
+		sb.WriteString("No comments yet. Be the first to say something!\n")
+		return sb.String()
+	}
+
+	for _, c := range data.Comments {
+		if data.ShowNumbers {
+			sb.WriteString(fmt.Sprintf("%d. ", c.Number))
+		}
+		sb.WriteString(renderComment(c))
+	}
+
+	if data.TotalPages > 1 {
+		sb.WriteString(fmt.Sprintf("\n<i>Page %d/%d</i>\n", data.Page+1, data.TotalPages))
+	}
+
+	return sb.String()
+}
+
+// renderComment renders a single comment line; comment text is user input and must be escaped
+func renderComment(c *CommentItem) string {
+	return fmt.Sprintf("%s <b>%s</b> <i>%s</i>\n%s\n\n",
+		c.Emoji, html.EscapeString(c.Name), c.CreatedAt.Format("Jan 2 15:04"), html.EscapeString(c.Text))
+}
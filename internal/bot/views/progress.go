@@ -19,6 +19,7 @@ type ParticipantProgress struct {
 	IsAdmin        bool
 	CompletedTasks int
 	TotalTasks     int
+	Kudos          int
 }
 
 // RenderTeamProgress renders the team progress view
@@ -56,8 +57,12 @@ func RenderTeamProgress(data TeamProgressData) string {
 		}
 		bar := renderProgressBar(pct)
 
-		sb.WriteString(fmt.Sprintf("%s %d%% (%d/%d)  %s %s\n",
+		sb.WriteString(fmt.Sprintf("%s %d%% (%d/%d)  %s %s",
 			bar, pct, p.CompletedTasks, p.TotalTasks, p.Emoji, name))
+		if p.Kudos > 0 {
+			sb.WriteString(fmt.Sprintf("  👏%d", p.Kudos))
+		}
+		sb.WriteString("\n")
 	}
 
 	return sb.String()
@@ -90,3 +90,22 @@ func TestTeamProgressSorting(t *testing.T) {
 		t.Error("Participants should be sorted by completion percentage descending")
 	}
 }
+
+func TestRenderTeamProgress_Kudos(t *testing.T) {
+	data := TeamProgressData{
+		ChallengeName: "Test Challenge",
+		Participants: []*ParticipantProgress{
+			{Emoji: "💪", Name: "John", CompletedTasks: 2, TotalTasks: 10, Kudos: 3},
+			{Emoji: "🔥", Name: "Sarah", CompletedTasks: 1, TotalTasks: 10},
+		},
+	}
+
+	result := RenderTeamProgress(data)
+
+	if !strings.Contains(result, "👏3") {
+		t.Error("Should show kudos count for John")
+	}
+	if strings.Count(result, "👏") != 1 {
+		t.Error("Should not show kudos for participants without any")
+	}
+}
@@ -13,6 +13,10 @@ type TaskDetailData struct {
 	IsCompleted bool
 	CompletedBy []*ParticipantStatus
 	NotYet      []*ParticipantStatus
+
+	// Latest comments (newest first) and the total comment count
+	Comments     []*CommentItem
+	CommentCount int
 }
 
 // ParticipantStatus holds participant display info
@@ -59,6 +63,14 @@ func RenderTaskDetail(data TaskDetailData) string {
 		sb.WriteString(strings.Join(names, " • ") + "\n")
 	}
 
+	// Latest comments
+	if data.CommentCount > 0 {
+		sb.WriteString(fmt.Sprintf("\n<b>💬 Comments (%d):</b>\n", data.CommentCount))
+		for _, c := range data.Comments {
+			sb.WriteString(renderComment(c))
+		}
+	}
+
 	return sb.String()
 }
 
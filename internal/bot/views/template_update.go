@@ -0,0 +1,110 @@
+package views
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// TemplateUpdateChange holds display info for one edited task in a template update
+type TemplateUpdateChange struct {
+	OrderNum           int
+	OldTitle           string
+	NewTitle           string
+	DescriptionChanged bool
+}
+
+// TemplateUpdateData holds data for rendering the pending template update of a challenge
+type TemplateUpdateData struct {
+	ChallengeName string
+	TemplateName  string
+	FromVersion   int // template version the challenge is on, 0 = unknown
+	ToVersion     int // latest template version, 0 = unknown
+	Added         []string
+	Changed       []TemplateUpdateChange
+}
+
+// TemplatePushItem holds the pending change counts of one challenge created from a template
+type TemplatePushItem struct {
+	ChallengeName string
+	ChallengeID   string
+	Added         int
+	Changed       int
+}
+
+// maxTemplateUpdateItems caps how many tasks are listed per section
+const maxTemplateUpdateItems = 10
+
+// RenderTemplateUpdate renders the changes a template update would apply to a challenge
+func RenderTemplateUpdate(data TemplateUpdateData) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🔄 <b>Template update for \"%s\"</b>\n", html.EscapeString(data.ChallengeName)))
+	sb.WriteString(fmt.Sprintf("<i>From template: %s", html.EscapeString(data.TemplateName)))
+	if data.FromVersion > 0 && data.ToVersion > 0 {
+		sb.WriteString(fmt.Sprintf(" (version %d → %d)", data.FromVersion, data.ToVersion))
+	}
+	sb.WriteString("</i>\n")
+
+	if len(data.Added) > 0 {
+		sb.WriteString(fmt.Sprintf("\n➕ <b>New tasks (%d)</b>\n", len(data.Added)))
+		for i, title := range data.Added {
+			if i == maxTemplateUpdateItems {
+				sb.WriteString(fmt.Sprintf("…and %d more\n", len(data.Added)-i))
+				break
+			}
+			sb.WriteString("• " + html.EscapeString(title) + "\n")
+		}
+	}
+
+	if len(data.Changed) > 0 {
+		sb.WriteString(fmt.Sprintf("\n✏️ <b>Edited tasks (%d)</b>\n", len(data.Changed)))
+		for i, change := range data.Changed {
+			if i == maxTemplateUpdateItems {
+				sb.WriteString(fmt.Sprintf("…and %d more\n", len(data.Changed)-i))
+				break
+			}
+			line := fmt.Sprintf("• %d. %s", change.OrderNum, html.EscapeString(change.OldTitle))
+			if change.NewTitle != change.OldTitle {
+				line += " → " + html.EscapeString(change.NewTitle)
+			}
+			if change.DescriptionChanged {
+				line += " <i>(description updated)</i>"
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	sb.WriteString("\n<i>New tasks are added at the end. Existing completions are kept.</i>")
+	return sb.String()
+}
+
+// RenderTemplatePush renders the per-challenge summary shown before pushing a template update
+func RenderTemplatePush(templateName string, items []TemplatePushItem) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🔄 <b>Push \"%s\" to challenges</b>\n\n", html.EscapeString(templateName)))
+	if len(items) == 0 {
+		sb.WriteString("No challenges were created from this template yet.")
+		return sb.String()
+	}
+
+	pending := 0
+	for _, item := range items {
+		line := fmt.Sprintf("• %s <code>%s</code>: ", html.EscapeString(item.ChallengeName), item.ChallengeID)
+		if item.Added == 0 && item.Changed == 0 {
+			line += "up to date"
+		} else {
+			pending++
+			line += fmt.Sprintf("+%d new, %d edited", item.Added, item.Changed)
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	if pending > 0 {
+		sb.WriteString(fmt.Sprintf("\nEach challenge admin gets the preview and decides whether to apply it (%d challenges).", pending))
+	} else {
+		sb.WriteString("\nAll challenges are up to date.")
+	}
+	return sb.String()
+}
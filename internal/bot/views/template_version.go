@@ -0,0 +1,61 @@
+package views
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// maxVersionTaskTitle keeps the full task list of a version within one message
+const maxVersionTaskTitle = 60
+
+// RenderTemplateVersion renders a template version with its settings and task list
+func RenderTemplateVersion(version *domain.TemplateVersion, tasks []*domain.TemplateVersionTask, isCurrent bool) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🕘 <b>%s</b> — version %d", html.EscapeString(version.Name), version.Version))
+	if isCurrent {
+		sb.WriteString(" <i>(current)</i>")
+	}
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("<i>Saved %s UTC by %s</i>\n\n",
+		version.CreatedAt.UTC().Format("2006-01-02 15:04"), FormatEditor(version.EditorID)))
+
+	if version.Description != "" {
+		sb.WriteString(fmt.Sprintf("<b>Description:</b> %s\n", html.EscapeString(version.Description)))
+	}
+	if version.DailyTaskLimit > 0 {
+		sb.WriteString(fmt.Sprintf("<b>Daily Limit:</b> %d/day\n", version.DailyTaskLimit))
+	} else {
+		sb.WriteString("<b>Daily Limit:</b> No daily limit\n")
+	}
+	if version.HideFutureTasks {
+		sb.WriteString("<b>Mode:</b> Sequential\n")
+	} else {
+		sb.WriteString("<b>Mode:</b> All Visible\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("\n<b>Tasks (%d)</b>\n", len(tasks)))
+	if len(tasks) == 0 {
+		sb.WriteString("<i>No tasks</i>\n")
+	}
+	for _, t := range tasks {
+		title := t.Title
+		if runes := []rune(title); len(runes) > maxVersionTaskTitle {
+			title = strings.TrimSpace(string(runes[:maxVersionTaskTitle])) + "…"
+		}
+		sb.WriteString(fmt.Sprintf("%d. %s\n", t.OrderNum, html.EscapeString(title)))
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// FormatEditor renders the editor of a template version
+func FormatEditor(editorID int64) string {
+	if editorID == 0 {
+		return "unknown"
+	}
+	return fmt.Sprintf("<code>%d</code>", editorID)
+}
@@ -0,0 +1,148 @@
+package bot
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	tele "gopkg.in/telebot.v3"
+)
+
+const (
+	secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+	maxUpdateSize     = 1 << 20
+	// updateBuffer holds updates that arrive before the bot starts polling
+	updateBuffer = 100
+	// webhookCheckInterval is how often Poll checks in with Telegram while no updates arrive
+	webhookCheckInterval = time.Minute
+)
+
+// Telegram allows 1-256 characters A-Z, a-z, 0-9, _ and - in a secret token
+var secretTokenPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+// Webhook receives updates from Telegram over HTTP instead of long polling.
+// It is an http.Handler to be mounted on the application's HTTP server at Path()
+type Webhook struct {
+	webhook  *tele.Webhook
+	path     string
+	updates  chan tele.Update
+	activity *activity // set by New
+}
+
+// NewWebhook creates a webhook for publicURL, the HTTPS address Telegram posts updates to.
+// A random secret token is generated when secretToken is empty. certFile is an optional
+// self-signed certificate uploaded to Telegram
+func NewWebhook(publicURL, secretToken, certFile string) (*Webhook, error) {
+	u, err := url.Parse(publicURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return nil, errors.New("webhook URL must be an absolute https:// URL")
+	}
+
+	if secretToken == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		secretToken = hex.EncodeToString(buf)
+	}
+	if !secretTokenPattern.MatchString(secretToken) {
+		return nil, errors.New("webhook secret must be 1-256 characters of A-Z, a-z, 0-9, _ and -")
+	}
+
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return &Webhook{
+		webhook: &tele.Webhook{
+			SecretToken: secretToken,
+			Endpoint:    &tele.WebhookEndpoint{PublicURL: publicURL, Cert: certFile},
+		},
+		path:    path,
+		updates: make(chan tele.Update, updateBuffer),
+	}, nil
+}
+
+// Path returns the URL path updates are posted to
+func (w *Webhook) Path() string {
+	return w.path
+}
+
+// Poll registers the webhook with Telegram and hands received updates to the bot until stopped.
+// The webhook stays registered on stop, so Telegram keeps delivering (and waking us up) later.
+// Delivered updates count as activity for the liveness check, and so does a periodic
+// getWebhookInfo call, so a quiet bot isn't reported dead. Like tele.LongPoller, it only
+// receives from stop: Bot.Start closes it and waits for Poll to return
+func (w *Webhook) Poll(b *tele.Bot, dest chan tele.Update, stop chan struct{}) {
+	if err := b.SetWebhook(w.webhook); err != nil {
+		logger.Error("Failed to register webhook", "error", err)
+		return
+	}
+	logger.Info("Webhook registered", "path", w.path)
+	w.activity.touch()
+
+	ticker := time.NewTicker(webhookCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case update := <-w.updates:
+			select {
+			case dest <- update:
+			case <-stop:
+				return
+			}
+			w.activity.touch()
+		case <-ticker.C:
+			if _, err := b.Raw("getWebhookInfo", nil); err != nil {
+				logger.Warn("Webhook check failed", "error", err)
+				continue
+			}
+			w.activity.touch()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// ServeHTTP accepts an update from Telegram after checking its secret token header
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.Header().Set("Allow", http.MethodPost)
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := r.Header.Get(secretTokenHeader)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(w.webhook.SecretToken)) != 1 {
+		logger.Warn("Webhook request with invalid secret token", "remote_addr", r.RemoteAddr)
+		http.Error(rw, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var update tele.Update
+	if err := json.NewDecoder(http.MaxBytesReader(rw, r.Body, maxUpdateSize)).Decode(&update); err != nil {
+		logger.Warn("Webhook request with invalid update", "error", err)
+		http.Error(rw, "invalid update", http.StatusBadRequest)
+		return
+	}
+
+	// Only acknowledge once the update is queued, so Telegram retries anything we couldn't take
+	select {
+	case w.updates <- update:
+		rw.WriteHeader(http.StatusOK)
+	case <-r.Context().Done():
+	}
+}
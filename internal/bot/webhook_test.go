@@ -0,0 +1,179 @@
+package bot
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
+	tele "gopkg.in/telebot.v3"
+)
+
+func init() {
+	// Initialize logger for tests
+	logger.Init("error")
+}
+
+func TestNewWebhook(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		secret   string
+		wantErr  bool
+		wantPath string
+	}{
+		{"with path", "https://bot.example.com/telegram/hook", "s3cret_token-1", false, "/telegram/hook"},
+		{"root", "https://bot.example.com", "s3cret", false, "/"},
+		{"plain http", "http://bot.example.com/hook", "s3cret", true, ""},
+		{"relative", "/hook", "s3cret", true, ""},
+		{"bad secret", "https://bot.example.com/hook", "not allowed!", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewWebhook(tt.url, tt.secret, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && w.Path() != tt.wantPath {
+				t.Errorf("Path() = %q, want %q", w.Path(), tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestNewWebhook_GeneratesSecret(t *testing.T) {
+	w1, err := NewWebhook("https://bot.example.com/hook", "", "")
+	if err != nil {
+		t.Fatalf("NewWebhook() error = %v", err)
+	}
+	w2, _ := NewWebhook("https://bot.example.com/hook", "", "")
+
+	secret := w1.webhook.SecretToken
+	if !secretTokenPattern.MatchString(secret) {
+		t.Errorf("generated secret %q is not a valid Telegram secret token", secret)
+	}
+	if secret == w2.webhook.SecretToken {
+		t.Error("generated secrets should differ between webhooks")
+	}
+}
+
+func TestWebhook_ServeHTTP(t *testing.T) {
+	const update = `{"update_id": 42, "message": {"message_id": 1, "text": "hi"}}`
+
+	tests := []struct {
+		name       string
+		method     string
+		secret     string
+		body       string
+		wantStatus int
+		wantQueued bool
+	}{
+		{"valid", http.MethodPost, "s3cret", update, http.StatusOK, true},
+		{"missing secret", http.MethodPost, "", update, http.StatusUnauthorized, false},
+		{"wrong secret", http.MethodPost, "guess", update, http.StatusUnauthorized, false},
+		{"wrong method", http.MethodGet, "s3cret", "", http.StatusMethodNotAllowed, false},
+		{"invalid body", http.MethodPost, "s3cret", "{not json", http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewWebhook("https://bot.example.com/hook", "s3cret", "")
+			if err != nil {
+				t.Fatalf("NewWebhook() error = %v", err)
+			}
+
+			req := httptest.NewRequest(tt.method, "/hook", strings.NewReader(tt.body))
+			if tt.secret != "" {
+				req.Header.Set(secretTokenHeader, tt.secret)
+			}
+			rec := httptest.NewRecorder()
+			w.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if queued := len(w.updates) == 1; queued != tt.wantQueued {
+				t.Fatalf("update queued = %v, want %v", queued, tt.wantQueued)
+			}
+			if tt.wantQueued {
+				if got := <-w.updates; got.ID != 42 || got.Message == nil || got.Message.Text != "hi" {
+					t.Errorf("queued update = %+v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhook_StartStop(t *testing.T) {
+	tests := []struct {
+		name        string
+		setWebhook  string
+		wantHandled bool
+	}{
+		{"registered", `{"ok": true, "result": true}`, true},
+		{"registration failed", `{"ok": false, "error_code": 400, "description": "Bad Request: bad webhook"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/setWebhook") {
+					io.WriteString(w, tt.setWebhook)
+					return
+				}
+				io.WriteString(w, `{"ok": true, "result": {}}`)
+			}))
+			defer api.Close()
+
+			w, err := NewWebhook("https://bot.example.com/hook", "s3cret", "")
+			if err != nil {
+				t.Fatalf("NewWebhook() error = %v", err)
+			}
+			w.activity = newActivity()
+
+			b, err := tele.NewBot(tele.Settings{Token: "TOKEN", URL: api.URL, Poller: w, Offline: true})
+			if err != nil {
+				t.Fatalf("NewBot() error = %v", err)
+			}
+			handled := make(chan struct{}, 1)
+			b.Handle(tele.OnText, func(c tele.Context) error {
+				handled <- struct{}{}
+				return nil
+			})
+
+			started := make(chan struct{})
+			go func() {
+				b.Start()
+				close(started)
+			}()
+
+			w.updates <- tele.Update{ID: 1, Message: &tele.Message{Text: "hi", Chat: &tele.Chat{ID: 1}}}
+			select {
+			case <-handled:
+				if !tt.wantHandled {
+					t.Error("update handled without a registered webhook")
+				}
+			case <-time.After(200 * time.Millisecond):
+				if tt.wantHandled {
+					t.Error("update not handled")
+				}
+			}
+
+			stopped := make(chan struct{})
+			go func() {
+				b.Stop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-time.After(5 * time.Second):
+				t.Fatal("Stop() did not return")
+			}
+			<-started
+		})
+	}
+}
@@ -3,15 +3,37 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // Config holds application configuration
 type Config struct {
 	TelegramBotToken string
 	DatabasePath     string
+	DatabaseURL      string // PostgreSQL connection URL; SQLite at DatabasePath is used when empty
 	LogLevel         string
 	HealthPort       string
+	MetricsListen    string // separate listen address for /metrics; served on the health server when empty
 	SuperAdminID     int64
+
+	// LivenessThreshold is how long the bot may go without hearing from Telegram before /health fails
+	LivenessThreshold time.Duration
+
+	// TrashRetention is how long deleted tasks and challenges can be restored before they are purged
+	TrashRetention time.Duration
+
+	// Updates one user may send per minute; 0 disables the limit
+	RateLimitText      int // messages, commands, photos and documents
+	RateLimitCallback  int // button taps
+	RateLimitExpensive int // taps that complete tasks, render images, export or notify the squad
+
+	// Webhook mode; the bot long-polls when WebhookURL is empty
+	WebhookURL        string // public HTTPS URL Telegram posts updates to
+	WebhookListen     string // address of the HTTP server shared by the webhook and health endpoints
+	WebhookSecret     string // secret token Telegram sends with every update; random when empty
+	WebhookSelfSigned bool   // upload TLSCertFile to Telegram as a self-signed certificate
+	TLSCertFile       string // serve HTTPS directly when both TLS files are set
+	TLSKeyFile        string
 }
 
 // Load reads configuration from environment variables
@@ -23,12 +45,38 @@ func Load() *Config {
 		}
 	}
 
+	livenessThreshold := 3 * time.Minute
+	if d, err := time.ParseDuration(os.Getenv("LIVENESS_THRESHOLD")); err == nil && d > 0 {
+		livenessThreshold = d
+	}
+
+	trashRetentionDays := 30
+	if days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); err == nil && days > 0 {
+		trashRetentionDays = days
+	}
+
 	return &Config{
 		TelegramBotToken: getEnv("TELEGRAM_BOT_TOKEN", ""),
 		DatabasePath:     getEnv("DATABASE_PATH", "./data/bot.db"),
+		DatabaseURL:      getEnv("DATABASE_URL", ""),
 		LogLevel:         getEnv("LOG_LEVEL", "info"),
 		HealthPort:       getEnv("HEALTH_PORT", ""),
+		MetricsListen:    getEnv("METRICS_LISTEN", ""),
 		SuperAdminID:     superAdminID,
+
+		LivenessThreshold: livenessThreshold,
+		TrashRetention:    time.Duration(trashRetentionDays) * 24 * time.Hour,
+
+		RateLimitText:      getEnvInt("RATE_LIMIT_TEXT", 20),
+		RateLimitCallback:  getEnvInt("RATE_LIMIT_CALLBACK", 60),
+		RateLimitExpensive: getEnvInt("RATE_LIMIT_EXPENSIVE", 10),
+
+		WebhookURL:        getEnv("WEBHOOK_URL", ""),
+		WebhookListen:     getEnv("WEBHOOK_LISTEN", ":8080"),
+		WebhookSecret:     getEnv("WEBHOOK_SECRET", ""),
+		WebhookSelfSigned: getEnv("WEBHOOK_SELF_SIGNED", "") == "true",
+		TLSCertFile:       getEnv("TLS_CERT_FILE", ""),
+		TLSKeyFile:        getEnv("TLS_KEY_FILE", ""),
 	}
 }
 
@@ -38,3 +86,11 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvInt returns a non-negative integer from the environment, or defaultValue when it is unset or invalid
+func getEnvInt(key string, defaultValue int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n >= 0 {
+		return n
+	}
+	return defaultValue
+}
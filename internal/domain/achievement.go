@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// Achievement codes
+const (
+	AchievementFirstTask       = "first_task"
+	AchievementStreak7         = "streak_7"
+	AchievementFirstFinisher   = "first_finisher"
+	AchievementDailyDiscipline = "daily_discipline"
+	AchievementNightOwl        = "night_owl"
+)
+
+// Achievement represents a badge awarded to a user in a challenge
+// Badges are keyed by Telegram user so they survive leaving or deleting a challenge
+type Achievement struct {
+	ID          int64     `db:"id"`
+	TelegramID  int64     `db:"telegram_id"`
+	ChallengeID string    `db:"challenge_id"`
+	Code        string    `db:"code"`
+	AwardedAt   time.Time `db:"awarded_at"`
+}
+
+// AchievementInfo describes how a badge is displayed
+type AchievementInfo struct {
+	Code        string
+	Emoji       string
+	Title       string
+	Description string
+}
+
+// Achievements lists all badges in display order
+var Achievements = []AchievementInfo{
+	{AchievementFirstTask, "🥇", "First Step", "Completed your first task"},
+	{AchievementStreak7, "🔥", "On Fire", "Completed tasks 7 days in a row"},
+	{AchievementFirstFinisher, "🏁", "Trailblazer", "First in the squad to finish the challenge"},
+	{AchievementDailyDiscipline, "⏱", "Clockwork", "Finished the challenge hitting the daily limit every day"},
+	{AchievementNightOwl, "🦉", "Night Owl", "Completed a task between midnight and 5 AM"},
+}
+
+// GetAchievementInfo returns display info for a badge code
+func GetAchievementInfo(code string) (AchievementInfo, bool) {
+	for _, a := range Achievements {
+		if a.Code == code {
+			return a, true
+		}
+	}
+	return AchievementInfo{}, false
+}
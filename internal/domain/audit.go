@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// Audit actions recorded by the services
+const (
+	AuditChallengeRename      = "challenge.rename"
+	AuditChallengeDescription = "challenge.description"
+	AuditChallengeDailyLimit  = "challenge.daily_limit"
+	AuditChallengeSequential  = "challenge.sequential"
+	AuditChallengeShuffle     = "challenge.shuffle"
+	AuditChallengeDelete      = "challenge.delete"
+	AuditChallengeRestore     = "challenge.restore"
+	AuditChallengeSync        = "challenge.template_sync"
+
+	AuditTaskCreate      = "task.create"
+	AuditTaskBulkCreate  = "task.bulk_create"
+	AuditTaskImport      = "task.import"
+	AuditTaskTitle       = "task.title"
+	AuditTaskDescription = "task.description"
+	AuditTaskImage       = "task.image"
+	AuditTaskDelete      = "task.delete"
+	AuditTaskRestore     = "task.restore"
+	AuditTaskMove        = "task.move"
+	AuditTaskShuffle     = "task.shuffle"
+
+	AuditSuperAdminGrant  = "super_admin.grant"
+	AuditSuperAdminRevoke = "super_admin.revoke"
+
+	// A user flooding the bot with updates, recorded by the rate limiter
+	AuditUserRateLimited = "user.rate_limited"
+
+	AuditTemplateRename          = "template.rename"
+	AuditTemplateDescription     = "template.description"
+	AuditTemplateDailyLimit      = "template.daily_limit"
+	AuditTemplateSequential      = "template.sequential"
+	AuditTemplateDelete          = "template.delete"
+	AuditTemplateRestore         = "template.restore"
+	AuditTemplateTaskCreate      = "template.task_create"
+	AuditTemplateTaskBulkCreate  = "template.task_bulk_create"
+	AuditTemplateTaskTitle       = "template.task_title"
+	AuditTemplateTaskDescription = "template.task_description"
+	AuditTemplateTaskImage       = "template.task_image"
+	AuditTemplateTaskDelete      = "template.task_delete"
+	AuditTemplateTaskMove        = "template.task_move"
+	AuditTemplateTaskShuffle     = "template.task_shuffle"
+)
+
+// AuditEntry is one admin or super admin action, or a repeat rate limit offender, in the append-only audit log.
+// Targets are kept as plain values without foreign keys, so entries outlive what they point to
+type AuditEntry struct {
+	ID           int64     `db:"id"`
+	ActorID      int64     `db:"actor_id"`
+	Action       string    `db:"action"`
+	ChallengeID  string    `db:"challenge_id"`   // empty = not about a challenge
+	TaskID       int64     `db:"task_id"`        // 0 = not about a task (or a template task, with TemplateID set)
+	TemplateID   int64     `db:"template_id"`    // 0 = not about a template
+	TargetUserID int64     `db:"target_user_id"` // user granted or revoked super admin, or rate limited
+	TargetName   string    `db:"target_name"`    // name or title of the target at the time
+	Before       string    `db:"before_value"`
+	After        string    `db:"after_value"`
+	Observer     bool      `db:"observer"` // super admin editing a challenge they don't own
+	CreatedAt    time.Time `db:"created_at"`
+}
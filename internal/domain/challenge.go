@@ -4,12 +4,16 @@ import "time"
 
 // Challenge represents a team challenge with tasks
 type Challenge struct {
-	ID              string    `db:"id"`
-	Name            string    `db:"name"`
-	Description     string    `db:"description"`
-	CreatorID       int64     `db:"creator_id"`
-	DailyTaskLimit  int       `db:"daily_task_limit"`  // 0 = unlimited
-	HideFutureTasks bool      `db:"hide_future_tasks"` // hide task names after current task
-	CreatedAt       time.Time `db:"created_at"`
-	UpdatedAt       time.Time `db:"updated_at"`
+	ID              string     `db:"id"`
+	Name            string     `db:"name"`
+	Description     string     `db:"description"`
+	CreatorID       int64      `db:"creator_id"`
+	DailyTaskLimit  int        `db:"daily_task_limit"`  // 0 = unlimited
+	HideFutureTasks bool       `db:"hide_future_tasks"` // hide task names after current task
+	ShuffleTasks    bool       `db:"shuffle_tasks"`     // each participant gets their own task order
+	TemplateID      int64      `db:"template_id"`       // source template, 0 = created from scratch
+	TemplateVersion int        `db:"template_version"`  // source template version, 0 = unknown
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+	DeletedAt       *time.Time `db:"deleted_at"` // in the trash since, nil = not deleted
 }
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// TaskComment represents a participant's comment on a task
+type TaskComment struct {
+	ID            int64     `db:"id"`
+	TaskID        int64     `db:"task_id"`
+	ParticipantID int64     `db:"participant_id"`
+	Text          string    `db:"text"`
+	CreatedAt     time.Time `db:"created_at"`
+}
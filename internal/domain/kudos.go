@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// KudosEmojis are the preset reactions offered on task completion notifications
+var KudosEmojis = []string{"👏", "🔥", "💪", "🎉"}
+
+// Kudos represents a reaction sent by one participant for another's task completion
+type Kudos struct {
+	ID                int64     `db:"id"`
+	TaskID            int64     `db:"task_id"`
+	FromParticipantID int64     `db:"from_participant_id"`
+	ToParticipantID   int64     `db:"to_participant_id"`
+	Emoji             string    `db:"emoji"`
+	CreatedAt         time.Time `db:"created_at"`
+}
+
+// IsKudosEmoji checks if the emoji is one of the preset kudos reactions
+func IsKudosEmoji(emoji string) bool {
+	for _, e := range KudosEmojis {
+		if e == emoji {
+			return true
+		}
+	}
+	return false
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // Business logic limits - adjust these values to configure system constraints
 const (
 	// MaxChallengesPerUser is the maximum number of challenges a user can join
@@ -11,9 +13,48 @@ const (
 	// MaxTasksPerChallenge is the maximum number of tasks allowed per challenge
 	MaxTasksPerChallenge = 50
 
+	// MaxChallengeNameLength is the maximum byte length for challenge and template names
+	MaxChallengeNameLength = 50
+
+	// MaxChallengeDescriptionLength is the maximum byte length for challenge and template descriptions
+	MaxChallengeDescriptionLength = 500
+
 	// MaxTaskTitleLength is the maximum character length for task titles
 	MaxTaskTitleLength = 150
 
 	// MaxTaskDescriptionLength is the maximum character length for task descriptions
 	MaxTaskDescriptionLength = 1200
+
+	// MaxCommentLength is the maximum character length for task comments
+	MaxCommentLength = 300
+
+	// CommentsPreviewCount is the number of latest comments shown on the task detail view
+	CommentsPreviewCount = 3
+
+	// CommentsPageSize is the number of comments shown per page in the comments view
+	CommentsPageSize = 10
+
+	// MaxTemplatesPerUser is the maximum number of personal templates a user can own
+	MaxTemplatesPerUser = 10
+
+	// TemplatesPageSize is the number of templates shown per page in the template catalog
+	TemplatesPageSize = 8
+
+	// MaxTemplateTags is the maximum number of tags per template
+	MaxTemplateTags = 10
+
+	// MaxTemplateTagLength is the maximum character length for a template tag
+	MaxTemplateTagLength = 20
+
+	// MaxCategoryNameLength is the maximum character length for a template category name
+	MaxCategoryNameLength = 30
+
+	// TemplateVersionsPageSize is the number of versions shown per page in the template history
+	TemplateVersionsPageSize = 8
+
+	// AuditLogPageSize is the number of entries shown per page in the audit log viewers
+	AuditLogPageSize = 10
+
+	// NudgeCooldown is the minimum time between nudges from the same sender to the same recipient
+	NudgeCooldown = 6 * time.Hour
 )
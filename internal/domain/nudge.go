@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// Nudge represents a reminder sent by one participant to a lagging teammate
+type Nudge struct {
+	ID                int64     `db:"id"`
+	FromParticipantID int64     `db:"from_participant_id"`
+	ToParticipantID   int64     `db:"to_participant_id"`
+	CreatedAt         time.Time `db:"created_at"`
+}
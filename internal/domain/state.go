@@ -34,6 +34,9 @@ const (
 	StateReorderSelectTask       = "reorder_select_task"
 	StateReorderSelectPosition   = "reorder_select_position"
 
+	// Task comments
+	StateAwaitingTaskComment = "awaiting_task_comment"
+
 	// Joining challenge
 	StateAwaitingChallengeID      = "awaiting_challenge_id"
 	StateAwaitingParticipantName  = "awaiting_participant_name"
@@ -44,18 +47,22 @@ const (
 	StateAwaitingNewChallengeName        = "awaiting_new_challenge_name"
 	StateAwaitingNewChallengeDescription = "awaiting_new_challenge_description"
 	StateAwaitingNewDailyLimit           = "awaiting_new_daily_limit"
+	StateAwaitingImportTasks             = "awaiting_import_tasks"
+	StateAwaitingBulkTasks               = "awaiting_bulk_tasks"
 
 	// User settings
 	StateAwaitingNewName  = "awaiting_new_name"
 	StateAwaitingNewEmoji = "awaiting_new_emoji"
 
 	// Super Admin
-	StateAwaitingSuperAdminID = "awaiting_super_admin_id"
+	StateAwaitingSuperAdminID   = "awaiting_super_admin_id"
+	StateAwaitingImportTemplate = "awaiting_import_template"
 
 	// Template-based challenge creation states (User)
 	StateSelectTemplateOrScratch         = "select_template_or_scratch"
 	StateSelectTemplate                  = "select_template"
 	StateViewingTemplate                 = "viewing_template"
+	StateAwaitingTemplateSearch          = "awaiting_template_search"
 	StateAwaitingTemplateChallengeName   = "awaiting_template_challenge_name"
 	StateAwaitingTemplateCreatorName     = "awaiting_template_creator_name"
 	StateAwaitingTemplateCreatorEmoji    = "awaiting_template_creator_emoji"
@@ -68,6 +75,9 @@ const (
 	StateAwaitingTplTaskTitle           = "awaiting_tpl_task_title"
 	StateAwaitingTplTaskDescription     = "awaiting_tpl_task_description"
 	StateAwaitingTplTaskImage           = "awaiting_tpl_task_image"
+	StateAwaitingTplBulkTasks           = "awaiting_tpl_bulk_tasks"
+	StateAwaitingTemplateCategoryName   = "awaiting_template_category_name"
+	StateAwaitingTemplateTags           = "awaiting_template_tags"
 	StateAwaitingTplEditTitle       = "awaiting_tpl_edit_title"
 	StateAwaitingTplEditDescription = "awaiting_tpl_edit_description"
 	StateAwaitingTplEditImage       = "awaiting_tpl_edit_image"
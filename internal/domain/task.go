@@ -4,11 +4,13 @@ import "time"
 
 // Task represents a single task within a challenge
 type Task struct {
-	ID          int64     `db:"id"`
-	ChallengeID string    `db:"challenge_id"`
-	OrderNum    int       `db:"order_num"`
-	Title       string    `db:"title"`
-	Description string    `db:"description"`
-	ImageFileID string    `db:"image_file_id"`
-	CreatedAt   time.Time `db:"created_at"`
+	ID             int64      `db:"id"`
+	ChallengeID    string     `db:"challenge_id"`
+	OrderNum       int        `db:"order_num"`
+	Title          string     `db:"title"`
+	Description    string     `db:"description"`
+	ImageFileID    string     `db:"image_file_id"`
+	TemplateTaskID int64      `db:"template_task_id"` // source template task, 0 = added manually
+	CreatedAt      time.Time  `db:"created_at"`
+	DeletedAt      *time.Time `db:"deleted_at"` // in the trash since, nil = not deleted
 }
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Template represents a challenge template
 type Template struct {
@@ -9,5 +12,64 @@ type Template struct {
 	Description     string    `db:"description"`
 	DailyTaskLimit  int       `db:"daily_task_limit"`  // 0 = unlimited
 	HideFutureTasks bool      `db:"hide_future_tasks"` // hide task names after current task
+	OwnerID         int64     `db:"owner_id"`          // 0 = global template curated by super admins
+	ShareCode       string    `db:"share_code"`        // empty = not shared via link
+	CategoryID      int64     `db:"category_id"`       // 0 = uncategorized
+	Tags            string    `db:"tags"`              // comma-separated, lowercase
+	UseCount        int       `db:"use_count"`         // number of challenges created from this template
 	CreatedAt       time.Time `db:"created_at"`
 }
+
+// TagList returns the template tags as a slice
+func (t *Template) TagList() []string {
+	if t.Tags == "" {
+		return nil
+	}
+	return strings.Split(t.Tags, ",")
+}
+
+// TemplateVersion is a snapshot of a template's settings taken after an edit
+type TemplateVersion struct {
+	ID              int64     `db:"id"`
+	TemplateID      int64     `db:"template_id"`
+	Version         int       `db:"version"`   // 1-based, increases with every edit
+	EditorID        int64     `db:"editor_id"` // 0 = unknown (e.g. recorded on upgrade)
+	Name            string    `db:"name"`
+	Description     string    `db:"description"`
+	DailyTaskLimit  int       `db:"daily_task_limit"`
+	HideFutureTasks bool      `db:"hide_future_tasks"`
+	CreatedAt       time.Time `db:"created_at"`
+}
+
+// TemplateVersionTask is a template task as it was in a version
+type TemplateVersionTask struct {
+	ID             int64  `db:"id"`
+	VersionID      int64  `db:"version_id"`
+	TemplateTaskID int64  `db:"template_task_id"` // the template task this snapshot was taken from
+	OrderNum       int    `db:"order_num"`
+	Title          string `db:"title"`
+	Description    string `db:"description"`
+	ImageFileID    string `db:"image_file_id"`
+}
+
+// TemplateCategory groups templates in the catalog
+type TemplateCategory struct {
+	ID        int64     `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// TemplateSort is the ordering of the template catalog
+type TemplateSort string
+
+const (
+	TemplateSortNewest  TemplateSort = "new"
+	TemplateSortPopular TemplateSort = "pop"
+)
+
+// TemplateFilter selects global templates in the catalog
+type TemplateFilter struct {
+	CategoryID int64        // 0 = all categories
+	Query      string       // matched against name, description and tags; empty = no search
+	Sort       TemplateSort // defaults to newest first
+}
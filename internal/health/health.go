@@ -0,0 +1,107 @@
+// Package health implements the liveness and readiness endpoints
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+// Result is the outcome of a single check
+type Result struct {
+	Status  string         `json:"status"`
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Check runs one health check
+type Check func() Result
+
+// response is the JSON body returned by Handler
+type response struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Handler runs every check on each request and responds 200 when all pass and 503 otherwise,
+// with the result of each check in the body
+func Handler(checks map[string]Check) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := response{Status: statusOK, Checks: make(map[string]Result, len(checks))}
+		for name, check := range checks {
+			result := check()
+			if result.Status != statusOK {
+				resp.Status = statusFail
+			}
+			resp.Checks[name] = result
+		}
+
+		code := http.StatusOK
+		if resp.Status != statusOK {
+			code = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// Database checks that ping succeeds
+func Database(ping func() error) Check {
+	return func() Result {
+		start := time.Now()
+		err := ping()
+		details := map[string]any{"latency_ms": time.Since(start).Milliseconds()}
+		if err != nil {
+			return Result{Status: statusFail, Error: err.Error(), Details: details}
+		}
+		return Result{Status: statusOK, Details: details}
+	}
+}
+
+// Migrations checks that the database schema is at the version this build expects
+func Migrations(current func() (int, error), expected int) Check {
+	return func() Result {
+		version, err := current()
+		if err != nil {
+			return Result{Status: statusFail, Error: err.Error()}
+		}
+
+		details := map[string]any{"version": version, "expected": expected}
+		switch {
+		case version < expected:
+			return Result{Status: statusFail, Error: "database has pending migrations", Details: details}
+		case version > expected:
+			return Result{Status: statusFail, Error: "database schema is newer than this build", Details: details}
+		}
+		return Result{Status: statusOK, Details: details}
+	}
+}
+
+// Activity checks that lastActivity is no older than threshold
+func Activity(lastActivity func() time.Time, threshold time.Duration) Check {
+	return func() Result {
+		last := lastActivity()
+		age := time.Since(last)
+		details := map[string]any{
+			"last_activity": last.UTC().Format(time.RFC3339),
+			"age_seconds":   int(age.Seconds()),
+			"threshold":     threshold.String(),
+		}
+		if age > threshold {
+			return Result{
+				Status:  statusFail,
+				Error:   fmt.Sprintf("no updates from Telegram for %s", age.Round(time.Second)),
+				Details: details,
+			}
+		}
+		return Result{Status: statusOK, Details: details}
+	}
+}
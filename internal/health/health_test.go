@@ -0,0 +1,88 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func serve(t *testing.T, checks map[string]Check) (int, response) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Handler(checks).ServeHTTP(rec, httptest.NewRequest("GET", "/ready", nil))
+
+	var resp response
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("invalid JSON body: %v", err)
+	}
+	return rec.Code, resp
+}
+
+func TestHandler(t *testing.T) {
+	ok := func() Result { return Result{Status: statusOK} }
+	failing := func() Result { return Result{Status: statusFail, Error: "broken"} }
+
+	code, resp := serve(t, map[string]Check{"a": ok, "b": ok})
+	if code != http.StatusOK || resp.Status != statusOK {
+		t.Errorf("all passing: got %d %q, want 200 %q", code, resp.Status, statusOK)
+	}
+
+	code, resp = serve(t, map[string]Check{"a": ok, "b": failing})
+	if code != http.StatusServiceUnavailable || resp.Status != statusFail {
+		t.Errorf("one failing: got %d %q, want 503 %q", code, resp.Status, statusFail)
+	}
+	if resp.Checks["b"].Error != "broken" {
+		t.Errorf("failing check error = %q, want %q", resp.Checks["b"].Error, "broken")
+	}
+	if resp.Checks["a"].Status != statusOK {
+		t.Errorf("passing check status = %q, want %q", resp.Checks["a"].Status, statusOK)
+	}
+}
+
+func TestDatabase(t *testing.T) {
+	if got := Database(func() error { return nil })(); got.Status != statusOK {
+		t.Errorf("successful ping status = %q, want %q", got.Status, statusOK)
+	}
+	got := Database(func() error { return errors.New("database is locked") })()
+	if got.Status != statusFail || got.Error != "database is locked" {
+		t.Errorf("failed ping = %+v, want fail with the ping error", got)
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int
+		err     error
+		want    string
+	}{
+		{"up to date", 15, nil, statusOK},
+		{"pending", 14, nil, statusFail},
+		{"newer", 16, nil, statusFail},
+		{"error", 0, errors.New("no such table"), statusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Migrations(func() (int, error) { return tt.version, tt.err }, 15)()
+			if got.Status != tt.want {
+				t.Errorf("status = %q, want %q (%+v)", got.Status, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestActivity(t *testing.T) {
+	recent := func() time.Time { return time.Now().Add(-10 * time.Second) }
+	stale := func() time.Time { return time.Now().Add(-10 * time.Minute) }
+
+	if got := Activity(recent, time.Minute)(); got.Status != statusOK {
+		t.Errorf("recent activity status = %q, want %q", got.Status, statusOK)
+	}
+	if got := Activity(stale, time.Minute)(); got.Status != statusFail {
+		t.Errorf("stale activity status = %q, want %q", got.Status, statusFail)
+	}
+}
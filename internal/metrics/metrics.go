@@ -0,0 +1,78 @@
+// Package metrics exposes Prometheus metrics for the bot
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "squadbot"
+
+// Registry holds all bot metrics plus the Go runtime and process collectors
+var Registry = prometheus.NewRegistry()
+
+var (
+	updatesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "updates_total",
+		Help:      "Telegram updates processed, by update type and callback action.",
+	}, []string{"type", "action"})
+
+	handlerDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "handler_duration_seconds",
+		Help:      "Time spent handling an update, by update type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type"})
+
+	handlerErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "handler_errors_total",
+		Help:      "Updates whose handler returned an error, by handler.",
+	}, []string{"handler"})
+
+	notificationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_total",
+		Help:      "Notifications sent to participants, by result (sent or failed).",
+	}, []string{"result"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Repository call latency, by repository and method.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"repo", "method"})
+
+	rateLimitedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "Updates dropped by the per-user rate limiter, by kind (text, callback or expensive).",
+	}, []string{"kind"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		updatesTotal,
+		handlerDuration,
+		handlerErrors,
+		notificationsTotal,
+		dbQueryDuration,
+		rateLimitedTotal,
+	)
+}
+
+// RateLimited counts an update of kind dropped by the rate limiter
+func RateLimited(kind string) {
+	rateLimitedTotal.WithLabelValues(kind).Inc()
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,200 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository/sqlite"
+	"github.com/rgeraskin/squad-challenge-bot/internal/testutil"
+	tele "gopkg.in/telebot.v3"
+)
+
+func setupTestRepo(t *testing.T) *sqlite.SQLiteRepository {
+	t.Helper()
+	tmpDir, err := os.MkdirTemp("", "squadbot-metrics-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	repo, err := sqlite.New(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	t.Cleanup(func() {
+		repo.Close()
+		os.RemoveAll(tmpDir)
+	})
+	return repo
+}
+
+// mockContext wraps an update's message and callback in a testutil.MockContext
+func mockContext(u tele.Update) tele.Context {
+	return &testutil.MockContext{MessageObj: u.Message, CallbackObj: u.Callback}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name       string
+		update     tele.Update
+		wantType   string
+		wantAction string
+	}{
+		{"callback", tele.Update{Callback: &tele.Callback{Data: "\fcomplete_task|42"}}, "callback", "complete_task"},
+		{"callback without params", tele.Update{Callback: &tele.Callback{Data: "settings"}}, "callback", "settings"},
+		{"command", tele.Update{Message: &tele.Message{Text: "/start abc"}}, "command", ""},
+		{"text", tele.Update{Message: &tele.Message{Text: "hello"}}, "text", ""},
+		{"photo", tele.Update{Message: &tele.Message{Photo: &tele.Photo{}}}, "photo", ""},
+		{"document", tele.Update{Message: &tele.Message{Document: &tele.Document{}}}, "document", ""},
+		{"other", tele.Update{}, "other", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotType, gotAction := classify(mockContext(tt.update))
+			if gotType != tt.wantType || gotAction != tt.wantAction {
+				t.Errorf("classify() = %q, %q, want %q, %q", gotType, gotAction, tt.wantType, tt.wantAction)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	c := mockContext(tele.Update{Callback: &tele.Callback{Data: "\fmetrics_test|1"}})
+	updates := updatesTotal.WithLabelValues("callback", "metrics_test")
+	failures := handlerErrors.WithLabelValues("callback:metrics_test")
+	beforeUpdates, beforeErrors := promtest.ToFloat64(updates), promtest.ToFloat64(failures)
+
+	ok := Middleware([]string{"metrics_test"})(func(tele.Context) error { return nil })
+	failing := Middleware([]string{"metrics_test"})(func(tele.Context) error { return errors.New("boom") })
+	if err := ok(c); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+	if err := failing(c); err == nil {
+		t.Fatal("middleware should pass the handler error through")
+	}
+
+	if got := promtest.ToFloat64(updates) - beforeUpdates; got != 2 {
+		t.Errorf("updates_total increased by %v, want 2", got)
+	}
+	if got := promtest.ToFloat64(failures) - beforeErrors; got != 1 {
+		t.Errorf("handler_errors_total increased by %v, want 1", got)
+	}
+
+	// Actions the bot doesn't handle share one series
+	unknown := updatesTotal.WithLabelValues("callback", unknownAction)
+	beforeUnknown, beforeSeries := promtest.ToFloat64(unknown), promtest.CollectAndCount(updatesTotal)
+	for i := 0; i < 3; i++ {
+		ok(mockContext(tele.Update{Callback: &tele.Callback{Data: fmt.Sprintf("\fforged_%d|1", i)}}))
+	}
+	if got := promtest.ToFloat64(unknown) - beforeUnknown; got != 3 {
+		t.Errorf("unknown actions increased by %v, want 3", got)
+	}
+	if got := promtest.CollectAndCount(updatesTotal) - beforeSeries; got > 1 {
+		t.Errorf("forged actions created %d series, want at most 1", got)
+	}
+}
+
+type stubNotifier struct {
+	err error
+}
+
+func (n *stubNotifier) Send(to tele.Recipient, what interface{}, opts ...interface{}) (*tele.Message, error) {
+	return &tele.Message{}, n.err
+}
+
+func TestInstrumentNotifier(t *testing.T) {
+	sent, failed := notificationsTotal.WithLabelValues("sent"), notificationsTotal.WithLabelValues("failed")
+	beforeSent, beforeFailed := promtest.ToFloat64(sent), promtest.ToFloat64(failed)
+
+	InstrumentNotifier(&stubNotifier{}).Send(&tele.User{ID: 1}, "hi")
+	InstrumentNotifier(&stubNotifier{err: errors.New("blocked")}).Send(&tele.User{ID: 1}, "hi")
+
+	if got := promtest.ToFloat64(sent) - beforeSent; got != 1 {
+		t.Errorf("sent increased by %v, want 1", got)
+	}
+	if got := promtest.ToFloat64(failed) - beforeFailed; got != 1 {
+		t.Errorf("failed increased by %v, want 1", got)
+	}
+}
+
+// queryCount returns how many calls of repo.method were observed
+func queryCount(t *testing.T, repo, method string) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := dbQueryDuration.WithLabelValues(repo, method).(prometheus.Metric).Write(&m); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestInstrumentRepository(t *testing.T) {
+	repo := InstrumentRepository(setupTestRepo(t))
+	beforeCreate, beforeGet := queryCount(t, "challenge", "Create"), queryCount(t, "challenge", "GetByID")
+
+	if err := repo.Challenge().Create(&domain.Challenge{ID: "metric12", Name: "Metrics", CreatorID: 1}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	// Calls inside a transaction are observed too
+	err := repo.WithTx(func(tx repository.Repository) error {
+		_, err := tx.Challenge().GetByID("metric12")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error = %v", err)
+	}
+
+	if got := queryCount(t, "challenge", "Create") - beforeCreate; got != 1 {
+		t.Errorf("Create observations increased by %d, want 1", got)
+	}
+	if got := queryCount(t, "challenge", "GetByID") - beforeGet; got != 1 {
+		t.Errorf("GetByID observations increased by %d, want 1", got)
+	}
+}
+
+func TestStatsCollector(t *testing.T) {
+	repo := setupTestRepo(t)
+	repo.Challenge().Create(&domain.Challenge{ID: "stats123", Name: "Stats", CreatorID: 1})
+	repo.Challenge().Create(&domain.Challenge{ID: "idle1234", Name: "Idle", CreatorID: 1})
+	task := &domain.Task{ChallengeID: "stats123", OrderNum: 1, Title: "Task"}
+	repo.Task().Create(task)
+	p := &domain.Participant{ChallengeID: "stats123", TelegramID: 1, DisplayName: "User", Emoji: "🔥"}
+	repo.Participant().Create(p)
+	repo.Completion().Create(&domain.TaskCompletion{TaskID: task.ID, ParticipantID: p.ID})
+
+	expected := `
+# HELP squadbot_active_challenges Challenges with at least one completion in the last 7 days.
+# TYPE squadbot_active_challenges gauge
+squadbot_active_challenges 1
+# HELP squadbot_completions_last_24h Task completions in the last 24 hours.
+# TYPE squadbot_completions_last_24h gauge
+squadbot_completions_last_24h 1
+# HELP squadbot_participants Participants across all challenges.
+# TYPE squadbot_participants gauge
+squadbot_participants 1
+`
+	if err := promtest.CollectAndCompare(NewStatsCollector(repo), strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if rec.Code != 200 {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "go_goroutines") {
+		t.Error("metrics output should include the Go runtime collector")
+	}
+}
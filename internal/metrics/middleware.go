@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	tele "gopkg.in/telebot.v3"
+)
+
+// unknownAction labels callbacks whose action the bot doesn't handle. Clients control callback
+// data, so labelling them as sent would let anyone create unlimited series
+const unknownAction = "unknown"
+
+// Middleware counts and times every update passing through the bot's handlers. Callbacks are
+// labelled with their action when it is one of actions and with unknownAction otherwise
+func Middleware(actions []string) tele.MiddlewareFunc {
+	known := make(map[string]bool, len(actions))
+	for _, action := range actions {
+		known[action] = true
+	}
+
+	return func(next tele.HandlerFunc) tele.HandlerFunc {
+		return func(c tele.Context) error {
+			updateType, action := classify(c)
+			if updateType == "callback" && !known[action] {
+				action = unknownAction
+			}
+			updatesTotal.WithLabelValues(updateType, action).Inc()
+
+			start := time.Now()
+			err := next(c)
+			handlerDuration.WithLabelValues(updateType).Observe(time.Since(start).Seconds())
+
+			if err != nil {
+				handler := updateType
+				if action != "" {
+					handler = updateType + ":" + action
+				}
+				handlerErrors.WithLabelValues(handler).Inc()
+			}
+			return err
+		}
+	}
+}
+
+// classify returns the kind of update and, for callbacks, the action it triggers
+func classify(c tele.Context) (updateType, action string) {
+	if cb := c.Callback(); cb != nil {
+		// Same parsing as HandleCallback: "\f" prefix, then action|param1|param2
+		data := strings.TrimPrefix(cb.Data, "\f")
+		action, _, _ = strings.Cut(data, "|")
+		return "callback", action
+	}
+
+	msg := c.Message()
+	switch {
+	case msg == nil:
+		return "other", ""
+	case msg.Photo != nil:
+		return "photo", ""
+	case msg.Document != nil:
+		return "document", ""
+	case strings.HasPrefix(msg.Text, "/"):
+		return "command", ""
+	case msg.Text != "":
+		return "text", ""
+	default:
+		return "other", ""
+	}
+}
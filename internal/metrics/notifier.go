@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"github.com/rgeraskin/squad-challenge-bot/internal/service"
+	tele "gopkg.in/telebot.v3"
+)
+
+// notifier counts notifications sent through the wrapped Notifier
+type notifier struct {
+	next service.Notifier
+}
+
+// InstrumentNotifier wraps n so every notification is counted as sent or failed
+func InstrumentNotifier(n service.Notifier) service.Notifier {
+	return &notifier{next: n}
+}
+
+func (n *notifier) Send(to tele.Recipient, what interface{}, opts ...interface{}) (*tele.Message, error) {
+	msg, err := n.next.Send(to, what, opts...)
+	if err != nil {
+		notificationsTotal.WithLabelValues("failed").Inc()
+	} else {
+		notificationsTotal.WithLabelValues("sent").Inc()
+	}
+	return msg, err
+}
@@ -0,0 +1,820 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+// instrumentedRepo records the latency of every repository call
+type instrumentedRepo struct {
+	next             repository.Repository
+	challenge        challengeRepo
+	task             taskRepo
+	participant      participantRepo
+	completion       completionRepo
+	state            stateRepo
+	superAdmin       superAdminRepo
+	template         templateRepo
+	templateTask     templateTaskRepo
+	templateCategory templateCategoryRepo
+	templateVersion  templateVersionRepo
+	comment          commentRepo
+	kudos            kudosRepo
+	nudge            nudgeRepo
+	achievement      achievementRepo
+	audit            auditRepo
+}
+
+// InstrumentRepository wraps repo so the latency of every call is recorded, including
+// calls made inside WithTx
+func InstrumentRepository(repo repository.Repository) repository.Repository {
+	return &instrumentedRepo{
+		next:             repo,
+		challenge:        challengeRepo{next: repo.Challenge()},
+		task:             taskRepo{next: repo.Task()},
+		participant:      participantRepo{next: repo.Participant()},
+		completion:       completionRepo{next: repo.Completion()},
+		state:            stateRepo{next: repo.State()},
+		superAdmin:       superAdminRepo{next: repo.SuperAdmin()},
+		template:         templateRepo{next: repo.Template()},
+		templateTask:     templateTaskRepo{next: repo.TemplateTask()},
+		templateCategory: templateCategoryRepo{next: repo.TemplateCategory()},
+		templateVersion:  templateVersionRepo{next: repo.TemplateVersion()},
+		comment:          commentRepo{next: repo.Comment()},
+		kudos:            kudosRepo{next: repo.Kudos()},
+		nudge:            nudgeRepo{next: repo.Nudge()},
+		achievement:      achievementRepo{next: repo.Achievement()},
+		audit:            auditRepo{next: repo.Audit()},
+	}
+}
+
+// observe records the time since start for a repository call; use it with defer
+func observe(repo, method string, start time.Time) {
+	dbQueryDuration.WithLabelValues(repo, method).Observe(time.Since(start).Seconds())
+}
+
+func (r *instrumentedRepo) Challenge() repository.ChallengeRepository {
+	return &r.challenge
+}
+
+func (r *instrumentedRepo) Task() repository.TaskRepository {
+	return &r.task
+}
+
+func (r *instrumentedRepo) Participant() repository.ParticipantRepository {
+	return &r.participant
+}
+
+func (r *instrumentedRepo) Completion() repository.CompletionRepository {
+	return &r.completion
+}
+
+func (r *instrumentedRepo) State() repository.StateRepository {
+	return &r.state
+}
+
+func (r *instrumentedRepo) SuperAdmin() repository.SuperAdminRepository {
+	return &r.superAdmin
+}
+
+func (r *instrumentedRepo) Template() repository.TemplateRepository {
+	return &r.template
+}
+
+func (r *instrumentedRepo) TemplateTask() repository.TemplateTaskRepository {
+	return &r.templateTask
+}
+
+func (r *instrumentedRepo) TemplateCategory() repository.TemplateCategoryRepository {
+	return &r.templateCategory
+}
+
+func (r *instrumentedRepo) TemplateVersion() repository.TemplateVersionRepository {
+	return &r.templateVersion
+}
+
+func (r *instrumentedRepo) Comment() repository.CommentRepository {
+	return &r.comment
+}
+
+func (r *instrumentedRepo) Kudos() repository.KudosRepository {
+	return &r.kudos
+}
+
+func (r *instrumentedRepo) Nudge() repository.NudgeRepository {
+	return &r.nudge
+}
+
+func (r *instrumentedRepo) Achievement() repository.AchievementRepository {
+	return &r.achievement
+}
+
+func (r *instrumentedRepo) Audit() repository.AuditRepository {
+	return &r.audit
+}
+
+func (r *instrumentedRepo) WithTx(fn func(repo repository.Repository) error) error {
+	return r.next.WithTx(func(tx repository.Repository) error {
+		return fn(InstrumentRepository(tx))
+	})
+}
+
+func (r *instrumentedRepo) Ping() error {
+	return r.next.Ping()
+}
+
+func (r *instrumentedRepo) Close() error {
+	return r.next.Close()
+}
+
+type challengeRepo struct {
+	next repository.ChallengeRepository
+}
+
+func (r *challengeRepo) Create(challenge *domain.Challenge) error {
+	defer observe("challenge", "Create", time.Now())
+	return r.next.Create(challenge)
+}
+
+func (r *challengeRepo) GetByID(id string) (*domain.Challenge, error) {
+	defer observe("challenge", "GetByID", time.Now())
+	return r.next.GetByID(id)
+}
+
+func (r *challengeRepo) GetByUserID(telegramID int64) ([]*domain.Challenge, error) {
+	defer observe("challenge", "GetByUserID", time.Now())
+	return r.next.GetByUserID(telegramID)
+}
+
+func (r *challengeRepo) GetAll() ([]*domain.Challenge, error) {
+	defer observe("challenge", "GetAll", time.Now())
+	return r.next.GetAll()
+}
+
+func (r *challengeRepo) Update(challenge *domain.Challenge) error {
+	defer observe("challenge", "Update", time.Now())
+	return r.next.Update(challenge)
+}
+
+func (r *challengeRepo) UpdateDailyLimit(id string, limit int) error {
+	defer observe("challenge", "UpdateDailyLimit", time.Now())
+	return r.next.UpdateDailyLimit(id, limit)
+}
+
+func (r *challengeRepo) UpdateHideFutureTasks(id string, hide bool) error {
+	defer observe("challenge", "UpdateHideFutureTasks", time.Now())
+	return r.next.UpdateHideFutureTasks(id, hide)
+}
+
+func (r *challengeRepo) UpdateShuffleTasks(id string, shuffle bool) error {
+	defer observe("challenge", "UpdateShuffleTasks", time.Now())
+	return r.next.UpdateShuffleTasks(id, shuffle)
+}
+
+func (r *challengeRepo) Delete(id string) error {
+	defer observe("challenge", "Delete", time.Now())
+	return r.next.Delete(id)
+}
+
+func (r *challengeRepo) Exists(id string) (bool, error) {
+	defer observe("challenge", "Exists", time.Now())
+	return r.next.Exists(id)
+}
+
+func (r *challengeRepo) GetByTemplateID(templateID int64) ([]*domain.Challenge, error) {
+	defer observe("challenge", "GetByTemplateID", time.Now())
+	return r.next.GetByTemplateID(templateID)
+}
+
+func (r *challengeRepo) UpdateTemplateVersion(id string, version int) error {
+	defer observe("challenge", "UpdateTemplateVersion", time.Now())
+	return r.next.UpdateTemplateVersion(id, version)
+}
+
+func (r *challengeRepo) CountActive(since time.Time) (int, error) {
+	defer observe("challenge", "CountActive", time.Now())
+	return r.next.CountActive(since)
+}
+
+func (r *challengeRepo) GetDeleted() ([]*domain.Challenge, error) {
+	defer observe("challenge", "GetDeleted", time.Now())
+	return r.next.GetDeleted()
+}
+
+func (r *challengeRepo) GetDeletedByID(id string) (*domain.Challenge, error) {
+	defer observe("challenge", "GetDeletedByID", time.Now())
+	return r.next.GetDeletedByID(id)
+}
+
+func (r *challengeRepo) Restore(id string) error {
+	defer observe("challenge", "Restore", time.Now())
+	return r.next.Restore(id)
+}
+
+func (r *challengeRepo) PurgeDeleted(before time.Time) (int, error) {
+	defer observe("challenge", "PurgeDeleted", time.Now())
+	return r.next.PurgeDeleted(before)
+}
+
+type taskRepo struct {
+	next repository.TaskRepository
+}
+
+func (r *taskRepo) Create(task *domain.Task) error {
+	defer observe("task", "Create", time.Now())
+	return r.next.Create(task)
+}
+
+func (r *taskRepo) CreateBatch(tasks []*domain.Task) error {
+	defer observe("task", "CreateBatch", time.Now())
+	return r.next.CreateBatch(tasks)
+}
+
+func (r *taskRepo) ApplyBatch(updated []*domain.Task, created []*domain.Task) error {
+	defer observe("task", "ApplyBatch", time.Now())
+	return r.next.ApplyBatch(updated, created)
+}
+
+func (r *taskRepo) GetByID(id int64) (*domain.Task, error) {
+	defer observe("task", "GetByID", time.Now())
+	return r.next.GetByID(id)
+}
+
+func (r *taskRepo) GetByChallengeID(challengeID string) ([]*domain.Task, error) {
+	defer observe("task", "GetByChallengeID", time.Now())
+	return r.next.GetByChallengeID(challengeID)
+}
+
+func (r *taskRepo) Update(task *domain.Task) error {
+	defer observe("task", "Update", time.Now())
+	return r.next.Update(task)
+}
+
+func (r *taskRepo) Delete(id int64) error {
+	defer observe("task", "Delete", time.Now())
+	return r.next.Delete(id)
+}
+
+func (r *taskRepo) GetMaxOrderNum(challengeID string) (int, error) {
+	defer observe("task", "GetMaxOrderNum", time.Now())
+	return r.next.GetMaxOrderNum(challengeID)
+}
+
+func (r *taskRepo) UpdateOrderNums(challengeID string, updates map[int64]int) error {
+	defer observe("task", "UpdateOrderNums", time.Now())
+	return r.next.UpdateOrderNums(challengeID, updates)
+}
+
+func (r *taskRepo) CountByChallengeID(challengeID string) (int, error) {
+	defer observe("task", "CountByChallengeID", time.Now())
+	return r.next.CountByChallengeID(challengeID)
+}
+
+func (r *taskRepo) GetDeletedByChallengeID(challengeID string) ([]*domain.Task, error) {
+	defer observe("task", "GetDeletedByChallengeID", time.Now())
+	return r.next.GetDeletedByChallengeID(challengeID)
+}
+
+func (r *taskRepo) GetDeletedByID(id int64) (*domain.Task, error) {
+	defer observe("task", "GetDeletedByID", time.Now())
+	return r.next.GetDeletedByID(id)
+}
+
+func (r *taskRepo) Restore(id int64, orderNum int) error {
+	defer observe("task", "Restore", time.Now())
+	return r.next.Restore(id, orderNum)
+}
+
+func (r *taskRepo) PurgeDeleted(before time.Time) (int, error) {
+	defer observe("task", "PurgeDeleted", time.Now())
+	return r.next.PurgeDeleted(before)
+}
+
+type participantRepo struct {
+	next repository.ParticipantRepository
+}
+
+func (r *participantRepo) Create(participant *domain.Participant) error {
+	defer observe("participant", "Create", time.Now())
+	return r.next.Create(participant)
+}
+
+func (r *participantRepo) GetByID(id int64) (*domain.Participant, error) {
+	defer observe("participant", "GetByID", time.Now())
+	return r.next.GetByID(id)
+}
+
+func (r *participantRepo) GetByIDForUpdate(id int64) (*domain.Participant, error) {
+	defer observe("participant", "GetByIDForUpdate", time.Now())
+	return r.next.GetByIDForUpdate(id)
+}
+
+func (r *participantRepo) GetByChallengeAndUser(challengeID string, telegramID int64) (*domain.Participant, error) {
+	defer observe("participant", "GetByChallengeAndUser", time.Now())
+	return r.next.GetByChallengeAndUser(challengeID, telegramID)
+}
+
+func (r *participantRepo) GetByChallengeID(challengeID string) ([]*domain.Participant, error) {
+	defer observe("participant", "GetByChallengeID", time.Now())
+	return r.next.GetByChallengeID(challengeID)
+}
+
+func (r *participantRepo) Update(participant *domain.Participant) error {
+	defer observe("participant", "Update", time.Now())
+	return r.next.Update(participant)
+}
+
+func (r *participantRepo) UpdateTimeOffset(id int64, offsetMinutes int) error {
+	defer observe("participant", "UpdateTimeOffset", time.Now())
+	return r.next.UpdateTimeOffset(id, offsetMinutes)
+}
+
+func (r *participantRepo) Delete(id int64) error {
+	defer observe("participant", "Delete", time.Now())
+	return r.next.Delete(id)
+}
+
+func (r *participantRepo) CountByChallengeID(challengeID string) (int, error) {
+	defer observe("participant", "CountByChallengeID", time.Now())
+	return r.next.CountByChallengeID(challengeID)
+}
+
+func (r *participantRepo) GetUsedEmojis(challengeID string) ([]string, error) {
+	defer observe("participant", "GetUsedEmojis", time.Now())
+	return r.next.GetUsedEmojis(challengeID)
+}
+
+func (r *participantRepo) Count() (int, error) {
+	defer observe("participant", "Count", time.Now())
+	return r.next.Count()
+}
+
+type completionRepo struct {
+	next repository.CompletionRepository
+}
+
+func (r *completionRepo) Create(completion *domain.TaskCompletion) error {
+	defer observe("completion", "Create", time.Now())
+	return r.next.Create(completion)
+}
+
+func (r *completionRepo) Delete(taskID, participantID int64) error {
+	defer observe("completion", "Delete", time.Now())
+	return r.next.Delete(taskID, participantID)
+}
+
+func (r *completionRepo) GetByTaskID(taskID int64) ([]*domain.TaskCompletion, error) {
+	defer observe("completion", "GetByTaskID", time.Now())
+	return r.next.GetByTaskID(taskID)
+}
+
+func (r *completionRepo) GetByParticipantID(participantID int64) ([]*domain.TaskCompletion, error) {
+	defer observe("completion", "GetByParticipantID", time.Now())
+	return r.next.GetByParticipantID(participantID)
+}
+
+func (r *completionRepo) GetByTaskAndParticipant(taskID, participantID int64) (*domain.TaskCompletion, error) {
+	defer observe("completion", "GetByTaskAndParticipant", time.Now())
+	return r.next.GetByTaskAndParticipant(taskID, participantID)
+}
+
+func (r *completionRepo) CountByParticipantID(participantID int64) (int, error) {
+	defer observe("completion", "CountByParticipantID", time.Now())
+	return r.next.CountByParticipantID(participantID)
+}
+
+func (r *completionRepo) CountCompletionsInRange(participantID int64, from, to time.Time) (int, error) {
+	defer observe("completion", "CountCompletionsInRange", time.Now())
+	return r.next.CountCompletionsInRange(participantID, from, to)
+}
+
+func (r *completionRepo) GetCompletedTaskIDs(participantID int64) ([]int64, error) {
+	defer observe("completion", "GetCompletedTaskIDs", time.Now())
+	return r.next.GetCompletedTaskIDs(participantID)
+}
+
+func (r *completionRepo) CountSince(since time.Time) (int, error) {
+	defer observe("completion", "CountSince", time.Now())
+	return r.next.CountSince(since)
+}
+
+type stateRepo struct {
+	next repository.StateRepository
+}
+
+func (r *stateRepo) Get(telegramID int64) (*domain.UserState, error) {
+	defer observe("state", "Get", time.Now())
+	return r.next.Get(telegramID)
+}
+
+func (r *stateRepo) Set(state *domain.UserState) error {
+	defer observe("state", "Set", time.Now())
+	return r.next.Set(state)
+}
+
+func (r *stateRepo) Reset(telegramID int64) error {
+	defer observe("state", "Reset", time.Now())
+	return r.next.Reset(telegramID)
+}
+
+func (r *stateRepo) ResetByChallenge(challengeID string) error {
+	defer observe("state", "ResetByChallenge", time.Now())
+	return r.next.ResetByChallenge(challengeID)
+}
+
+type superAdminRepo struct {
+	next repository.SuperAdminRepository
+}
+
+func (r *superAdminRepo) Create(telegramID int64) error {
+	defer observe("super_admin", "Create", time.Now())
+	return r.next.Create(telegramID)
+}
+
+func (r *superAdminRepo) Delete(telegramID int64) error {
+	defer observe("super_admin", "Delete", time.Now())
+	return r.next.Delete(telegramID)
+}
+
+func (r *superAdminRepo) IsSuperAdmin(telegramID int64) (bool, error) {
+	defer observe("super_admin", "IsSuperAdmin", time.Now())
+	return r.next.IsSuperAdmin(telegramID)
+}
+
+func (r *superAdminRepo) GetAll() ([]*domain.SuperAdmin, error) {
+	defer observe("super_admin", "GetAll", time.Now())
+	return r.next.GetAll()
+}
+
+func (r *superAdminRepo) Exists(telegramID int64) (bool, error) {
+	defer observe("super_admin", "Exists", time.Now())
+	return r.next.Exists(telegramID)
+}
+
+type templateRepo struct {
+	next repository.TemplateRepository
+}
+
+func (r *templateRepo) Create(template *domain.Template) error {
+	defer observe("template", "Create", time.Now())
+	return r.next.Create(template)
+}
+
+func (r *templateRepo) GetByID(id int64) (*domain.Template, error) {
+	defer observe("template", "GetByID", time.Now())
+	return r.next.GetByID(id)
+}
+
+func (r *templateRepo) GetGlobal() ([]*domain.Template, error) {
+	defer observe("template", "GetGlobal", time.Now())
+	return r.next.GetGlobal()
+}
+
+func (r *templateRepo) GetByOwner(ownerID int64) ([]*domain.Template, error) {
+	defer observe("template", "GetByOwner", time.Now())
+	return r.next.GetByOwner(ownerID)
+}
+
+func (r *templateRepo) Delete(id int64) error {
+	defer observe("template", "Delete", time.Now())
+	return r.next.Delete(id)
+}
+
+func (r *templateRepo) CountByOwner(ownerID int64) (int, error) {
+	defer observe("template", "CountByOwner", time.Now())
+	return r.next.CountByOwner(ownerID)
+}
+
+func (r *templateRepo) ExistsByName(name string, ownerID int64) (bool, error) {
+	defer observe("template", "ExistsByName", time.Now())
+	return r.next.ExistsByName(name, ownerID)
+}
+
+func (r *templateRepo) GetByShareCode(code string) (*domain.Template, error) {
+	defer observe("template", "GetByShareCode", time.Now())
+	return r.next.GetByShareCode(code)
+}
+
+func (r *templateRepo) ExistsByShareCode(code string) (bool, error) {
+	defer observe("template", "ExistsByShareCode", time.Now())
+	return r.next.ExistsByShareCode(code)
+}
+
+func (r *templateRepo) UpdateShareCode(id int64, code string) error {
+	defer observe("template", "UpdateShareCode", time.Now())
+	return r.next.UpdateShareCode(id, code)
+}
+
+func (r *templateRepo) Find(filter domain.TemplateFilter, limit, offset int) ([]*domain.Template, error) {
+	defer observe("template", "Find", time.Now())
+	return r.next.Find(filter, limit, offset)
+}
+
+func (r *templateRepo) CountFiltered(filter domain.TemplateFilter) (int, error) {
+	defer observe("template", "CountFiltered", time.Now())
+	return r.next.CountFiltered(filter)
+}
+
+func (r *templateRepo) CountByCategory() (map[int64]int, error) {
+	defer observe("template", "CountByCategory", time.Now())
+	return r.next.CountByCategory()
+}
+
+func (r *templateRepo) UpdateCategory(id int64, categoryID int64) error {
+	defer observe("template", "UpdateCategory", time.Now())
+	return r.next.UpdateCategory(id, categoryID)
+}
+
+func (r *templateRepo) UpdateTags(id int64, tags string) error {
+	defer observe("template", "UpdateTags", time.Now())
+	return r.next.UpdateTags(id, tags)
+}
+
+func (r *templateRepo) IncrementUseCount(id int64) error {
+	defer observe("template", "IncrementUseCount", time.Now())
+	return r.next.IncrementUseCount(id)
+}
+
+func (r *templateRepo) UpdateName(id int64, name string) error {
+	defer observe("template", "UpdateName", time.Now())
+	return r.next.UpdateName(id, name)
+}
+
+func (r *templateRepo) UpdateDescription(id int64, description string) error {
+	defer observe("template", "UpdateDescription", time.Now())
+	return r.next.UpdateDescription(id, description)
+}
+
+func (r *templateRepo) UpdateDailyLimit(id int64, limit int) error {
+	defer observe("template", "UpdateDailyLimit", time.Now())
+	return r.next.UpdateDailyLimit(id, limit)
+}
+
+func (r *templateRepo) UpdateHideFutureTasks(id int64, hide bool) error {
+	defer observe("template", "UpdateHideFutureTasks", time.Now())
+	return r.next.UpdateHideFutureTasks(id, hide)
+}
+
+func (r *templateRepo) Restore(template *domain.Template, tasks []*domain.TemplateTask) error {
+	defer observe("template", "Restore", time.Now())
+	return r.next.Restore(template, tasks)
+}
+
+type templateTaskRepo struct {
+	next repository.TemplateTaskRepository
+}
+
+func (r *templateTaskRepo) Create(task *domain.TemplateTask) error {
+	defer observe("template_task", "Create", time.Now())
+	return r.next.Create(task)
+}
+
+func (r *templateTaskRepo) CreateBatch(tasks []*domain.TemplateTask) error {
+	defer observe("template_task", "CreateBatch", time.Now())
+	return r.next.CreateBatch(tasks)
+}
+
+func (r *templateTaskRepo) GetByID(id int64) (*domain.TemplateTask, error) {
+	defer observe("template_task", "GetByID", time.Now())
+	return r.next.GetByID(id)
+}
+
+func (r *templateTaskRepo) GetByTemplateID(templateID int64) ([]*domain.TemplateTask, error) {
+	defer observe("template_task", "GetByTemplateID", time.Now())
+	return r.next.GetByTemplateID(templateID)
+}
+
+func (r *templateTaskRepo) DeleteByTemplateID(templateID int64) error {
+	defer observe("template_task", "DeleteByTemplateID", time.Now())
+	return r.next.DeleteByTemplateID(templateID)
+}
+
+func (r *templateTaskRepo) CountByTemplateID(templateID int64) (int, error) {
+	defer observe("template_task", "CountByTemplateID", time.Now())
+	return r.next.CountByTemplateID(templateID)
+}
+
+func (r *templateTaskRepo) Delete(id int64) error {
+	defer observe("template_task", "Delete", time.Now())
+	return r.next.Delete(id)
+}
+
+func (r *templateTaskRepo) UpdateTitle(id int64, title string) error {
+	defer observe("template_task", "UpdateTitle", time.Now())
+	return r.next.UpdateTitle(id, title)
+}
+
+func (r *templateTaskRepo) UpdateDescription(id int64, description string) error {
+	defer observe("template_task", "UpdateDescription", time.Now())
+	return r.next.UpdateDescription(id, description)
+}
+
+func (r *templateTaskRepo) UpdateImage(id int64, imageFileID string) error {
+	defer observe("template_task", "UpdateImage", time.Now())
+	return r.next.UpdateImage(id, imageFileID)
+}
+
+func (r *templateTaskRepo) GetMaxOrderNum(templateID int64) (int, error) {
+	defer observe("template_task", "GetMaxOrderNum", time.Now())
+	return r.next.GetMaxOrderNum(templateID)
+}
+
+func (r *templateTaskRepo) UpdateOrderNum(id int64, orderNum int) error {
+	defer observe("template_task", "UpdateOrderNum", time.Now())
+	return r.next.UpdateOrderNum(id, orderNum)
+}
+
+func (r *templateTaskRepo) UpdateOrderNums(templateID int64, updates map[int64]int) error {
+	defer observe("template_task", "UpdateOrderNums", time.Now())
+	return r.next.UpdateOrderNums(templateID, updates)
+}
+
+type templateCategoryRepo struct {
+	next repository.TemplateCategoryRepository
+}
+
+func (r *templateCategoryRepo) Create(category *domain.TemplateCategory) error {
+	defer observe("template_category", "Create", time.Now())
+	return r.next.Create(category)
+}
+
+func (r *templateCategoryRepo) GetByID(id int64) (*domain.TemplateCategory, error) {
+	defer observe("template_category", "GetByID", time.Now())
+	return r.next.GetByID(id)
+}
+
+func (r *templateCategoryRepo) GetAll() ([]*domain.TemplateCategory, error) {
+	defer observe("template_category", "GetAll", time.Now())
+	return r.next.GetAll()
+}
+
+func (r *templateCategoryRepo) ExistsByName(name string) (bool, error) {
+	defer observe("template_category", "ExistsByName", time.Now())
+	return r.next.ExistsByName(name)
+}
+
+func (r *templateCategoryRepo) Delete(id int64) error {
+	defer observe("template_category", "Delete", time.Now())
+	return r.next.Delete(id)
+}
+
+type templateVersionRepo struct {
+	next repository.TemplateVersionRepository
+}
+
+func (r *templateVersionRepo) Create(version *domain.TemplateVersion, tasks []*domain.TemplateVersionTask) error {
+	defer observe("template_version", "Create", time.Now())
+	return r.next.Create(version, tasks)
+}
+
+func (r *templateVersionRepo) GetByID(id int64) (*domain.TemplateVersion, error) {
+	defer observe("template_version", "GetByID", time.Now())
+	return r.next.GetByID(id)
+}
+
+func (r *templateVersionRepo) GetLatest(templateID int64) (*domain.TemplateVersion, error) {
+	defer observe("template_version", "GetLatest", time.Now())
+	return r.next.GetLatest(templateID)
+}
+
+func (r *templateVersionRepo) GetByVersion(templateID int64, version int) (*domain.TemplateVersion, error) {
+	defer observe("template_version", "GetByVersion", time.Now())
+	return r.next.GetByVersion(templateID, version)
+}
+
+func (r *templateVersionRepo) GetByTemplateID(templateID int64, limit, offset int) ([]*domain.TemplateVersion, error) {
+	defer observe("template_version", "GetByTemplateID", time.Now())
+	return r.next.GetByTemplateID(templateID, limit, offset)
+}
+
+func (r *templateVersionRepo) CountByTemplateID(templateID int64) (int, error) {
+	defer observe("template_version", "CountByTemplateID", time.Now())
+	return r.next.CountByTemplateID(templateID)
+}
+
+func (r *templateVersionRepo) GetTasks(versionID int64) ([]*domain.TemplateVersionTask, error) {
+	defer observe("template_version", "GetTasks", time.Now())
+	return r.next.GetTasks(versionID)
+}
+
+type commentRepo struct {
+	next repository.CommentRepository
+}
+
+func (r *commentRepo) Create(comment *domain.TaskComment) error {
+	defer observe("comment", "Create", time.Now())
+	return r.next.Create(comment)
+}
+
+func (r *commentRepo) GetByID(id int64) (*domain.TaskComment, error) {
+	defer observe("comment", "GetByID", time.Now())
+	return r.next.GetByID(id)
+}
+
+func (r *commentRepo) GetByTaskID(taskID int64, limit, offset int) ([]*domain.TaskComment, error) {
+	defer observe("comment", "GetByTaskID", time.Now())
+	return r.next.GetByTaskID(taskID, limit, offset)
+}
+
+func (r *commentRepo) CountByTaskID(taskID int64) (int, error) {
+	defer observe("comment", "CountByTaskID", time.Now())
+	return r.next.CountByTaskID(taskID)
+}
+
+func (r *commentRepo) Delete(id int64) error {
+	defer observe("comment", "Delete", time.Now())
+	return r.next.Delete(id)
+}
+
+type kudosRepo struct {
+	next repository.KudosRepository
+}
+
+func (r *kudosRepo) Create(kudos *domain.Kudos) error {
+	defer observe("kudos", "Create", time.Now())
+	return r.next.Create(kudos)
+}
+
+func (r *kudosRepo) Exists(taskID, fromParticipantID, toParticipantID int64) (bool, error) {
+	defer observe("kudos", "Exists", time.Now())
+	return r.next.Exists(taskID, fromParticipantID, toParticipantID)
+}
+
+func (r *kudosRepo) CountByReceiver(participantID int64) (int, error) {
+	defer observe("kudos", "CountByReceiver", time.Now())
+	return r.next.CountByReceiver(participantID)
+}
+
+func (r *kudosRepo) CountByChallengeID(challengeID string) (map[int64]int, error) {
+	defer observe("kudos", "CountByChallengeID", time.Now())
+	return r.next.CountByChallengeID(challengeID)
+}
+
+type nudgeRepo struct {
+	next repository.NudgeRepository
+}
+
+func (r *nudgeRepo) Create(nudge *domain.Nudge) error {
+	defer observe("nudge", "Create", time.Now())
+	return r.next.Create(nudge)
+}
+
+func (r *nudgeRepo) GetLast(fromParticipantID, toParticipantID int64) (*domain.Nudge, error) {
+	defer observe("nudge", "GetLast", time.Now())
+	return r.next.GetLast(fromParticipantID, toParticipantID)
+}
+
+type achievementRepo struct {
+	next repository.AchievementRepository
+}
+
+func (r *achievementRepo) Create(achievement *domain.Achievement) (bool, error) {
+	defer observe("achievement", "Create", time.Now())
+	return r.next.Create(achievement)
+}
+
+func (r *achievementRepo) GetByChallengeAndUser(challengeID string, telegramID int64) ([]*domain.Achievement, error) {
+	defer observe("achievement", "GetByChallengeAndUser", time.Now())
+	return r.next.GetByChallengeAndUser(challengeID, telegramID)
+}
+
+func (r *achievementRepo) GetByUser(telegramID int64) ([]*domain.Achievement, error) {
+	defer observe("achievement", "GetByUser", time.Now())
+	return r.next.GetByUser(telegramID)
+}
+
+func (r *achievementRepo) GetCodesByChallengeAndUser(challengeID string, telegramID int64) ([]string, error) {
+	defer observe("achievement", "GetCodesByChallengeAndUser", time.Now())
+	return r.next.GetCodesByChallengeAndUser(challengeID, telegramID)
+}
+
+type auditRepo struct {
+	next repository.AuditRepository
+}
+
+func (r *auditRepo) Create(entry *domain.AuditEntry) error {
+	defer observe("audit", "Create", time.Now())
+	return r.next.Create(entry)
+}
+
+func (r *auditRepo) GetByChallengeID(challengeID string, limit, offset int) ([]*domain.AuditEntry, error) {
+	defer observe("audit", "GetByChallengeID", time.Now())
+	return r.next.GetByChallengeID(challengeID, limit, offset)
+}
+
+func (r *auditRepo) CountByChallengeID(challengeID string) (int, error) {
+	defer observe("audit", "CountByChallengeID", time.Now())
+	return r.next.CountByChallengeID(challengeID)
+}
+
+func (r *auditRepo) GetAll(limit, offset int) ([]*domain.AuditEntry, error) {
+	defer observe("audit", "GetAll", time.Now())
+	return r.next.GetAll(limit, offset)
+}
+
+func (r *auditRepo) Count() (int, error) {
+	defer observe("audit", "Count", time.Now())
+	return r.next.Count()
+}
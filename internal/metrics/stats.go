@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+// activeChallengeWindow is how recently a challenge needs a completion to count as active
+const activeChallengeWindow = 7 * 24 * time.Hour
+
+var (
+	activeChallengesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "active_challenges"),
+		"Challenges with at least one completion in the last 7 days.",
+		nil, nil,
+	)
+	participantsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "participants"),
+		"Participants across all challenges.",
+		nil, nil,
+	)
+	completionsDayDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "completions_last_24h"),
+		"Task completions in the last 24 hours.",
+		nil, nil,
+	)
+)
+
+// statsCollector reads the domain gauges from the database on every scrape
+type statsCollector struct {
+	repo repository.Repository
+}
+
+// NewStatsCollector returns a collector for active challenges, participants and daily completions
+func NewStatsCollector(repo repository.Repository) prometheus.Collector {
+	return &statsCollector{repo: repo}
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeChallengesDesc
+	ch <- participantsDesc
+	ch <- completionsDayDesc
+}
+
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	now := time.Now()
+	collectGauge(ch, activeChallengesDesc, func() (int, error) {
+		return c.repo.Challenge().CountActive(now.Add(-activeChallengeWindow))
+	})
+	collectGauge(ch, participantsDesc, c.repo.Participant().Count)
+	collectGauge(ch, completionsDayDesc, func() (int, error) {
+		return c.repo.Completion().CountSince(now.Add(-24 * time.Hour))
+	})
+}
+
+// collectGauge emits count as a gauge, or an invalid metric so the scrape reports the error
+func collectGauge(ch chan<- prometheus.Metric, desc *prometheus.Desc, count func() (int, error)) {
+	n, err := count()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(desc, err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(n))
+}
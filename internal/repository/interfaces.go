@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository/migration"
 )
 
 // ChallengeRepository defines methods for challenge data access
@@ -15,26 +16,52 @@ type ChallengeRepository interface {
 	Update(challenge *domain.Challenge) error
 	UpdateDailyLimit(id string, limit int) error
 	UpdateHideFutureTasks(id string, hide bool) error
+	UpdateShuffleTasks(id string, shuffle bool) error
+	// Delete moves a challenge to the trash; the Get methods skip trashed challenges
 	Delete(id string) error
+	// Exists includes trashed challenges, so their IDs are not reused
 	Exists(id string) (bool, error)
+	GetByTemplateID(templateID int64) ([]*domain.Challenge, error)
+	UpdateTemplateVersion(id string, version int) error
+	// CountActive counts challenges with at least one completion since the given time
+	CountActive(since time.Time) (int, error)
+	// GetDeleted returns trashed challenges, most recently deleted first
+	GetDeleted() ([]*domain.Challenge, error)
+	GetDeletedByID(id string) (*domain.Challenge, error)
+	Restore(id string) error
+	// PurgeDeleted removes challenges trashed before the given time for good, returning how many were removed
+	PurgeDeleted(before time.Time) (int, error)
 }
 
 // TaskRepository defines methods for task data access
 type TaskRepository interface {
 	Create(task *domain.Task) error
+	CreateBatch(tasks []*domain.Task) error
+	// ApplyBatch updates and creates tasks in a single transaction
+	ApplyBatch(updated []*domain.Task, created []*domain.Task) error
 	GetByID(id int64) (*domain.Task, error)
 	GetByChallengeID(challengeID string) ([]*domain.Task, error)
 	Update(task *domain.Task) error
+	// Delete moves a task to the trash, keeping its completions; the Get and Count methods skip trashed tasks
 	Delete(id int64) error
 	GetMaxOrderNum(challengeID string) (int, error)
 	UpdateOrderNums(challengeID string, updates map[int64]int) error
 	CountByChallengeID(challengeID string) (int, error)
+	// GetDeletedByChallengeID returns a challenge's trashed tasks, most recently deleted first
+	GetDeletedByChallengeID(challengeID string) ([]*domain.Task, error)
+	GetDeletedByID(id int64) (*domain.Task, error)
+	// Restore takes a task out of the trash at the given position
+	Restore(id int64, orderNum int) error
+	// PurgeDeleted removes tasks trashed before the given time for good, returning how many were removed
+	PurgeDeleted(before time.Time) (int, error)
 }
 
 // ParticipantRepository defines methods for participant data access
 type ParticipantRepository interface {
 	Create(participant *domain.Participant) error
 	GetByID(id int64) (*domain.Participant, error)
+	// GetByIDForUpdate is GetByID that also locks the participant until the surrounding transaction ends
+	GetByIDForUpdate(id int64) (*domain.Participant, error)
 	GetByChallengeAndUser(challengeID string, telegramID int64) (*domain.Participant, error)
 	GetByChallengeID(challengeID string) ([]*domain.Participant, error)
 	Update(participant *domain.Participant) error
@@ -42,6 +69,7 @@ type ParticipantRepository interface {
 	Delete(id int64) error
 	CountByChallengeID(challengeID string) (int, error)
 	GetUsedEmojis(challengeID string) ([]string, error)
+	Count() (int, error)
 }
 
 // CompletionRepository defines methods for task completion data access
@@ -49,11 +77,14 @@ type CompletionRepository interface {
 	Create(completion *domain.TaskCompletion) error
 	Delete(taskID, participantID int64) error
 	GetByTaskID(taskID int64) ([]*domain.TaskCompletion, error)
+	// GetByParticipantID, CountByParticipantID and GetCompletedTaskIDs skip completions of trashed tasks
 	GetByParticipantID(participantID int64) ([]*domain.TaskCompletion, error)
 	GetByTaskAndParticipant(taskID, participantID int64) (*domain.TaskCompletion, error)
 	CountByParticipantID(participantID int64) (int, error)
+	// CountCompletionsInRange counts trashed tasks too: a completion used up the day's limit either way
 	CountCompletionsInRange(participantID int64, from, to time.Time) (int, error)
 	GetCompletedTaskIDs(participantID int64) ([]int64, error)
+	CountSince(since time.Time) (int, error)
 }
 
 // StateRepository defines methods for user state data access
@@ -77,19 +108,51 @@ type SuperAdminRepository interface {
 type TemplateRepository interface {
 	Create(template *domain.Template) error
 	GetByID(id int64) (*domain.Template, error)
-	GetAll() ([]*domain.Template, error)
+	GetGlobal() ([]*domain.Template, error)
+	GetByOwner(ownerID int64) ([]*domain.Template, error)
 	Delete(id int64) error
-	Count() (int, error)
-	ExistsByName(name string) (bool, error)
+	CountByOwner(ownerID int64) (int, error)
+	ExistsByName(name string, ownerID int64) (bool, error)
+	GetByShareCode(code string) (*domain.Template, error)
+	ExistsByShareCode(code string) (bool, error)
+	UpdateShareCode(id int64, code string) error
+	Find(filter domain.TemplateFilter, limit, offset int) ([]*domain.Template, error)
+	CountFiltered(filter domain.TemplateFilter) (int, error)
+	CountByCategory() (map[int64]int, error)
+	UpdateCategory(id int64, categoryID int64) error
+	UpdateTags(id int64, tags string) error
+	IncrementUseCount(id int64) error
 	UpdateName(id int64, name string) error
 	UpdateDescription(id int64, description string) error
 	UpdateDailyLimit(id int64, limit int) error
 	UpdateHideFutureTasks(id int64, hide bool) error
+	Restore(template *domain.Template, tasks []*domain.TemplateTask) error
+}
+
+// TemplateVersionRepository defines methods for template version history data access
+type TemplateVersionRepository interface {
+	Create(version *domain.TemplateVersion, tasks []*domain.TemplateVersionTask) error
+	GetByID(id int64) (*domain.TemplateVersion, error)
+	GetLatest(templateID int64) (*domain.TemplateVersion, error)
+	GetByVersion(templateID int64, version int) (*domain.TemplateVersion, error)
+	GetByTemplateID(templateID int64, limit, offset int) ([]*domain.TemplateVersion, error)
+	CountByTemplateID(templateID int64) (int, error)
+	GetTasks(versionID int64) ([]*domain.TemplateVersionTask, error)
+}
+
+// TemplateCategoryRepository defines methods for template category data access
+type TemplateCategoryRepository interface {
+	Create(category *domain.TemplateCategory) error
+	GetByID(id int64) (*domain.TemplateCategory, error)
+	GetAll() ([]*domain.TemplateCategory, error)
+	ExistsByName(name string) (bool, error)
+	Delete(id int64) error
 }
 
 // TemplateTaskRepository defines methods for template task data access
 type TemplateTaskRepository interface {
 	Create(task *domain.TemplateTask) error
+	CreateBatch(tasks []*domain.TemplateTask) error
 	GetByID(id int64) (*domain.TemplateTask, error)
 	GetByTemplateID(templateID int64) ([]*domain.TemplateTask, error)
 	DeleteByTemplateID(templateID int64) error
@@ -103,6 +166,48 @@ type TemplateTaskRepository interface {
 	UpdateOrderNums(templateID int64, updates map[int64]int) error
 }
 
+// CommentRepository defines methods for task comment data access
+type CommentRepository interface {
+	Create(comment *domain.TaskComment) error
+	GetByID(id int64) (*domain.TaskComment, error)
+	GetByTaskID(taskID int64, limit, offset int) ([]*domain.TaskComment, error)
+	CountByTaskID(taskID int64) (int, error)
+	Delete(id int64) error
+}
+
+// KudosRepository defines methods for kudos data access
+type KudosRepository interface {
+	Create(kudos *domain.Kudos) error
+	Exists(taskID, fromParticipantID, toParticipantID int64) (bool, error)
+	CountByReceiver(participantID int64) (int, error)
+	CountByChallengeID(challengeID string) (map[int64]int, error)
+}
+
+// NudgeRepository defines methods for nudge data access
+type NudgeRepository interface {
+	Create(nudge *domain.Nudge) error
+	GetLast(fromParticipantID, toParticipantID int64) (*domain.Nudge, error)
+}
+
+// AchievementRepository defines methods for achievement data access
+type AchievementRepository interface {
+	// Create stores a badge; returns false if it was already awarded
+	Create(achievement *domain.Achievement) (bool, error)
+	GetByChallengeAndUser(challengeID string, telegramID int64) ([]*domain.Achievement, error)
+	GetByUser(telegramID int64) ([]*domain.Achievement, error)
+	GetCodesByChallengeAndUser(challengeID string, telegramID int64) ([]string, error)
+}
+
+// AuditRepository defines methods for the append-only audit log; entries are never changed or removed
+type AuditRepository interface {
+	Create(entry *domain.AuditEntry) error
+	// GetByChallengeID and GetAll return entries newest first
+	GetByChallengeID(challengeID string, limit, offset int) ([]*domain.AuditEntry, error)
+	CountByChallengeID(challengeID string) (int, error)
+	GetAll(limit, offset int) ([]*domain.AuditEntry, error)
+	Count() (int, error)
+}
+
 // Repository combines all repositories
 type Repository interface {
 	Challenge() ChallengeRepository
@@ -113,5 +218,25 @@ type Repository interface {
 	SuperAdmin() SuperAdminRepository
 	Template() TemplateRepository
 	TemplateTask() TemplateTaskRepository
+	TemplateCategory() TemplateCategoryRepository
+	TemplateVersion() TemplateVersionRepository
+	Comment() CommentRepository
+	Kudos() KudosRepository
+	Nudge() NudgeRepository
+	Achievement() AchievementRepository
+	Audit() AuditRepository
+
+	// WithTx runs fn in a single transaction, committing if fn returns nil and
+	// rolling back otherwise. Use the repo passed to fn for all work inside it
+	WithTx(fn func(repo Repository) error) error
+	// Ping verifies the database connection is alive
+	Ping() error
 	Close() error
 }
+
+// Migrator is implemented by repositories with versioned schema migrations
+type Migrator interface {
+	Migrate(target int) error
+	MigrationStatus() ([]migration.Status, error)
+	SchemaVersion() (int, error)
+}
@@ -0,0 +1,155 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var fileRe = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+var (
+	ErrModified  = errors.New("applied migration was modified")
+	ErrUnknown   = errors.New("database has a migration this binary doesn't know")
+	ErrDowngrade = errors.New("migrating down is not supported")
+)
+
+// Migration is a schema migration embedded in the binary
+type Migration struct {
+	Version  int
+	Name     string
+	Checksum string
+	SQL      string
+}
+
+// Applied is a migration recorded in the schema_migrations table
+type Applied struct {
+	Version   int       `db:"version"`
+	Name      string    `db:"name"`
+	Checksum  string    `db:"checksum"`
+	AppliedAt time.Time `db:"applied_at"`
+}
+
+// Status describes a migration and whether it has been applied
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	Modified  bool // applied with a different checksum than the embedded file
+}
+
+// Load reads the NNN_name.sql migrations in dir ordered by version
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		m := fileRe.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", e.Name())
+		}
+		version, _ := strconv.Atoi(m[1])
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %q and %q share version %d", other, e.Name(), version)
+		}
+		seen[version] = e.Name()
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(content)
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Name:     m[2],
+			Checksum: hex.EncodeToString(sum[:]),
+			SQL:      string(content),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Latest returns the newest migration version (0 if there are none)
+func Latest(migrations []Migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// Pending checks the applied migrations against the embedded ones and returns
+// those still to run up to target (0 = latest), in order
+func Pending(migrations []Migration, applied map[int]Applied, target int) ([]Migration, error) {
+	if target <= 0 {
+		target = Latest(migrations)
+	}
+
+	known := make(map[int]Migration, len(migrations))
+	for _, m := range migrations {
+		known[m.Version] = m
+	}
+	current := 0
+	for version, a := range applied {
+		m, ok := known[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %03d_%s", ErrUnknown, version, a.Name)
+		}
+		if m.Checksum != a.Checksum {
+			return nil, fmt.Errorf("%w: %03d_%s", ErrModified, version, m.Name)
+		}
+		if version > current {
+			current = version
+		}
+	}
+	if target < current {
+		return nil, fmt.Errorf("%w: database is at version %d, target is %d", ErrDowngrade, current, target)
+	}
+	if _, ok := known[target]; !ok && target > 0 {
+		return nil, fmt.Errorf("unknown migration version %d", target)
+	}
+
+	var pending []Migration
+	for _, m := range migrations {
+		if m.Version > target {
+			break
+		}
+		if _, ok := applied[m.Version]; !ok {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Statuses lists the migrations and whether each has been applied
+func Statuses(migrations []Migration, applied map[int]Applied) []Status {
+	statuses := make([]Status, 0, len(migrations))
+	for _, m := range migrations {
+		status := Status{Version: m.Version, Name: m.Name}
+		if a, ok := applied[m.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = a.AppliedAt
+			status.Modified = a.Checksum != m.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// AchievementRepo implements AchievementRepository for PostgreSQL
+type AchievementRepo struct {
+	db dbtx
+}
+
+func (r *AchievementRepo) Create(achievement *domain.Achievement) (bool, error) {
+	achievement.AwardedAt = time.Now().UTC()
+
+	id, err := insertReturningID(r.db, `
+		INSERT INTO achievements (telegram_id, challenge_id, code, awarded_at)
+		VALUES (:telegram_id, :challenge_id, :code, :awarded_at)
+		ON CONFLICT (telegram_id, challenge_id, code) DO NOTHING
+		RETURNING id
+	`, achievement)
+	if err == sql.ErrNoRows {
+		// Already awarded
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	achievement.ID = id
+	return true, nil
+}
+
+func (r *AchievementRepo) GetByChallengeAndUser(challengeID string, telegramID int64) ([]*domain.Achievement, error) {
+	var achievements []*domain.Achievement
+	err := r.db.Select(&achievements, `
+		SELECT * FROM achievements
+		WHERE challenge_id = $1 AND telegram_id = $2
+		ORDER BY awarded_at ASC, id ASC
+	`, challengeID, telegramID)
+	return achievements, err
+}
+
+func (r *AchievementRepo) GetByUser(telegramID int64) ([]*domain.Achievement, error) {
+	var achievements []*domain.Achievement
+	err := r.db.Select(&achievements, `
+		SELECT * FROM achievements
+		WHERE telegram_id = $1
+		ORDER BY awarded_at ASC, id ASC
+	`, telegramID)
+	return achievements, err
+}
+
+func (r *AchievementRepo) GetCodesByChallengeAndUser(challengeID string, telegramID int64) ([]string, error) {
+	var codes []string
+	err := r.db.Select(&codes, `
+		SELECT code FROM achievements WHERE challenge_id = $1 AND telegram_id = $2
+	`, challengeID, telegramID)
+	return codes, err
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// ChallengeRepo implements ChallengeRepository for PostgreSQL
+type ChallengeRepo struct {
+	db dbtx
+}
+
+func (r *ChallengeRepo) Create(challenge *domain.Challenge) error {
+	challenge.CreatedAt = time.Now()
+	challenge.UpdatedAt = time.Now()
+
+	_, err := r.db.NamedExec(`
+		INSERT INTO challenges (id, name, description, creator_id, daily_task_limit, hide_future_tasks, shuffle_tasks, template_id, template_version, created_at, updated_at)
+		VALUES (:id, :name, :description, :creator_id, :daily_task_limit, :hide_future_tasks, :shuffle_tasks, :template_id, :template_version, :created_at, :updated_at)
+	`, challenge)
+	return err
+}
+
+func (r *ChallengeRepo) GetByID(id string) (*domain.Challenge, error) {
+	var challenge domain.Challenge
+	err := r.db.Get(&challenge, "SELECT * FROM challenges WHERE id = $1 AND deleted_at IS NULL", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &challenge, err
+}
+
+func (r *ChallengeRepo) GetByUserID(telegramID int64) ([]*domain.Challenge, error) {
+	var challenges []*domain.Challenge
+	err := r.db.Select(&challenges, `
+		SELECT DISTINCT c.* FROM challenges c
+		LEFT JOIN participants p ON c.id = p.challenge_id
+		WHERE (p.telegram_id = $1 OR c.creator_id = $1) AND c.deleted_at IS NULL
+		ORDER BY c.updated_at DESC
+	`, telegramID)
+	return challenges, err
+}
+
+func (r *ChallengeRepo) GetByTemplateID(templateID int64) ([]*domain.Challenge, error) {
+	var challenges []*domain.Challenge
+	err := r.db.Select(&challenges, `
+		SELECT * FROM challenges
+		WHERE template_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at ASC
+	`, templateID)
+	return challenges, err
+}
+
+func (r *ChallengeRepo) GetAll() ([]*domain.Challenge, error) {
+	var challenges []*domain.Challenge
+	err := r.db.Select(&challenges, `
+		SELECT * FROM challenges
+		WHERE deleted_at IS NULL
+		ORDER BY updated_at DESC
+	`)
+	return challenges, err
+}
+
+func (r *ChallengeRepo) Update(challenge *domain.Challenge) error {
+	challenge.UpdatedAt = time.Now()
+	_, err := r.db.NamedExec(`
+		UPDATE challenges
+		SET name = :name, description = :description, daily_task_limit = :daily_task_limit, hide_future_tasks = :hide_future_tasks, shuffle_tasks = :shuffle_tasks, updated_at = :updated_at
+		WHERE id = :id
+	`, challenge)
+	return err
+}
+
+func (r *ChallengeRepo) UpdateDailyLimit(id string, limit int) error {
+	_, err := r.db.Exec(`
+		UPDATE challenges
+		SET daily_task_limit = $1, updated_at = $2
+		WHERE id = $3
+	`, limit, time.Now(), id)
+	return err
+}
+
+func (r *ChallengeRepo) UpdateHideFutureTasks(id string, hide bool) error {
+	_, err := r.db.Exec(`
+		UPDATE challenges
+		SET hide_future_tasks = $1, updated_at = $2
+		WHERE id = $3
+	`, hide, time.Now(), id)
+	return err
+}
+
+func (r *ChallengeRepo) UpdateShuffleTasks(id string, shuffle bool) error {
+	_, err := r.db.Exec(`
+		UPDATE challenges
+		SET shuffle_tasks = $1, updated_at = $2
+		WHERE id = $3
+	`, shuffle, time.Now(), id)
+	return err
+}
+
+func (r *ChallengeRepo) UpdateTemplateVersion(id string, version int) error {
+	_, err := r.db.Exec(`
+		UPDATE challenges
+		SET template_version = $1, updated_at = $2
+		WHERE id = $3
+	`, version, time.Now(), id)
+	return err
+}
+
+func (r *ChallengeRepo) Delete(id string) error {
+	_, err := r.db.Exec(`
+		UPDATE challenges
+		SET deleted_at = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`, time.Now().UTC(), id)
+	return err
+}
+
+func (r *ChallengeRepo) Exists(id string) (bool, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM challenges WHERE id = $1", id)
+	return count > 0, err
+}
+
+func (r *ChallengeRepo) CountActive(since time.Time) (int, error) {
+	var count int
+	err := r.db.Get(&count, `
+		SELECT COUNT(DISTINCT p.challenge_id)
+		FROM task_completions tc
+		JOIN participants p ON p.id = tc.participant_id
+		JOIN challenges c ON c.id = p.challenge_id
+		WHERE tc.completed_at >= $1 AND c.deleted_at IS NULL
+	`, since.UTC())
+	return count, err
+}
+
+func (r *ChallengeRepo) GetDeleted() ([]*domain.Challenge, error) {
+	var challenges []*domain.Challenge
+	err := r.db.Select(&challenges, `
+		SELECT * FROM challenges
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`)
+	return challenges, err
+}
+
+func (r *ChallengeRepo) GetDeletedByID(id string) (*domain.Challenge, error) {
+	var challenge domain.Challenge
+	err := r.db.Get(&challenge, "SELECT * FROM challenges WHERE id = $1 AND deleted_at IS NOT NULL", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &challenge, err
+}
+
+func (r *ChallengeRepo) Restore(id string) error {
+	_, err := r.db.Exec(`
+		UPDATE challenges
+		SET deleted_at = NULL, updated_at = $1
+		WHERE id = $2 AND deleted_at IS NOT NULL
+	`, time.Now(), id)
+	return err
+}
+
+func (r *ChallengeRepo) PurgeDeleted(before time.Time) (int, error) {
+	result, err := r.db.Exec("DELETE FROM challenges WHERE deleted_at IS NOT NULL AND deleted_at < $1", before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	purged, err := result.RowsAffected()
+	return int(purged), err
+}
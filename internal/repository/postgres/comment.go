@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// CommentRepo implements CommentRepository for PostgreSQL
+type CommentRepo struct {
+	db dbtx
+}
+
+func (r *CommentRepo) Create(comment *domain.TaskComment) error {
+	comment.CreatedAt = time.Now().UTC()
+
+	id, err := insertReturningID(r.db, `
+		INSERT INTO task_comments (task_id, participant_id, text, created_at)
+		VALUES (:task_id, :participant_id, :text, :created_at)
+		RETURNING id
+	`, comment)
+	if err != nil {
+		return err
+	}
+	comment.ID = id
+	return nil
+}
+
+func (r *CommentRepo) GetByID(id int64) (*domain.TaskComment, error) {
+	var comment domain.TaskComment
+	err := r.db.Get(&comment, "SELECT * FROM task_comments WHERE id = $1", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &comment, err
+}
+
+func (r *CommentRepo) GetByTaskID(taskID int64, limit, offset int) ([]*domain.TaskComment, error) {
+	var comments []*domain.TaskComment
+	err := r.db.Select(&comments, `
+		SELECT * FROM task_comments
+		WHERE task_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, taskID, limit, offset)
+	return comments, err
+}
+
+func (r *CommentRepo) CountByTaskID(taskID int64) (int, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM task_comments WHERE task_id = $1", taskID)
+	return count, err
+}
+
+func (r *CommentRepo) Delete(id int64) error {
+	_, err := r.db.Exec("DELETE FROM task_comments WHERE id = $1", id)
+	return err
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// CompletionRepo implements CompletionRepository for PostgreSQL
+type CompletionRepo struct {
+	db dbtx
+}
+
+func (r *CompletionRepo) Create(completion *domain.TaskCompletion) error {
+	completion.CompletedAt = time.Now().UTC()
+
+	id, err := insertReturningID(r.db, `
+		INSERT INTO task_completions (task_id, participant_id, completed_at)
+		VALUES (:task_id, :participant_id, :completed_at)
+		RETURNING id
+	`, completion)
+	if err != nil {
+		return err
+	}
+	completion.ID = id
+	return nil
+}
+
+func (r *CompletionRepo) Delete(taskID, participantID int64) error {
+	_, err := r.db.Exec(`
+		DELETE FROM task_completions
+		WHERE task_id = $1 AND participant_id = $2
+	`, taskID, participantID)
+	return err
+}
+
+func (r *CompletionRepo) GetByTaskID(taskID int64) ([]*domain.TaskCompletion, error) {
+	var completions []*domain.TaskCompletion
+	err := r.db.Select(&completions, `
+		SELECT * FROM task_completions
+		WHERE task_id = $1
+		ORDER BY completed_at ASC
+	`, taskID)
+	return completions, err
+}
+
+func (r *CompletionRepo) GetByParticipantID(participantID int64) ([]*domain.TaskCompletion, error) {
+	var completions []*domain.TaskCompletion
+	err := r.db.Select(&completions, `
+		SELECT tc.* FROM task_completions tc
+		JOIN tasks t ON t.id = tc.task_id
+		WHERE tc.participant_id = $1 AND t.deleted_at IS NULL
+		ORDER BY tc.completed_at ASC
+	`, participantID)
+	return completions, err
+}
+
+func (r *CompletionRepo) GetByTaskAndParticipant(taskID, participantID int64) (*domain.TaskCompletion, error) {
+	var completion domain.TaskCompletion
+	err := r.db.Get(&completion, `
+		SELECT * FROM task_completions
+		WHERE task_id = $1 AND participant_id = $2
+	`, taskID, participantID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &completion, err
+}
+
+func (r *CompletionRepo) CountByParticipantID(participantID int64) (int, error) {
+	var count int
+	err := r.db.Get(&count, `
+		SELECT COUNT(*) FROM task_completions tc
+		JOIN tasks t ON t.id = tc.task_id
+		WHERE tc.participant_id = $1 AND t.deleted_at IS NULL
+	`, participantID)
+	return count, err
+}
+
+func (r *CompletionRepo) GetCompletedTaskIDs(participantID int64) ([]int64, error) {
+	var ids []int64
+	err := r.db.Select(&ids, `
+		SELECT tc.task_id FROM task_completions tc
+		JOIN tasks t ON t.id = tc.task_id
+		WHERE tc.participant_id = $1 AND t.deleted_at IS NULL
+	`, participantID)
+	return ids, err
+}
+
+func (r *CompletionRepo) CountCompletionsInRange(participantID int64, from, to time.Time) (int, error) {
+	var count int
+	err := r.db.Get(&count, `
+		SELECT COUNT(*) FROM task_completions
+		WHERE participant_id = $1 AND completed_at >= $2 AND completed_at < $3
+	`, participantID, from, to)
+	return count, err
+}
+
+func (r *CompletionRepo) CountSince(since time.Time) (int, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM task_completions WHERE completed_at >= $1", since.UTC())
+	return count, err
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"embed"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// PostgresRepository implements repository.Repository for PostgreSQL
+type PostgresRepository struct {
+	db           *sqlx.DB
+	tx           *sqlx.Tx // set on repositories handed out by WithTx
+	challenge    *ChallengeRepo
+	task         *TaskRepo
+	participant  *ParticipantRepo
+	completion   *CompletionRepo
+	state        *StateRepo
+	superAdmin   *SuperAdminRepo
+	template     *TemplateRepo
+	templateTask *TemplateTaskRepo
+	category     *TemplateCategoryRepo
+	version      *TemplateVersionRepo
+	comment      *CommentRepo
+	kudos        *KudosRepo
+	nudge        *NudgeRepo
+	achievement  *AchievementRepo
+	audit        *AuditRepo
+}
+
+// New connects to PostgreSQL and applies all pending migrations
+func New(databaseURL string) (*PostgresRepository, error) {
+	repo, err := Open(databaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.Migrate(0); err != nil {
+		repo.Close()
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// Open connects to PostgreSQL without touching the schema
+func Open(databaseURL string) (*PostgresRepository, error) {
+	db, err := sqlx.Connect("postgres", databaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRepository(db, db), nil
+}
+
+// newRepository wires every repo to q, which is either db itself or a transaction on it
+func newRepository(db *sqlx.DB, q dbtx) *PostgresRepository {
+	return &PostgresRepository{
+		db:           db,
+		challenge:    &ChallengeRepo{db: q},
+		task:         &TaskRepo{db: q},
+		participant:  &ParticipantRepo{db: q},
+		completion:   &CompletionRepo{db: q},
+		state:        &StateRepo{db: q},
+		superAdmin:   &SuperAdminRepo{db: q},
+		template:     &TemplateRepo{db: q},
+		templateTask: &TemplateTaskRepo{db: q},
+		category:     &TemplateCategoryRepo{db: q},
+		version:      &TemplateVersionRepo{db: q},
+		comment:      &CommentRepo{db: q},
+		kudos:        &KudosRepo{db: q},
+		nudge:        &NudgeRepo{db: q},
+		achievement:  &AchievementRepo{db: q},
+		audit:        &AuditRepo{db: q},
+	}
+}
+
+// insertReturningID runs a named INSERT ... RETURNING id and returns the new row ID
+func insertReturningID(q sqlx.Ext, query string, arg any) (int64, error) {
+	query, args, err := sqlx.Named(query, arg)
+	if err != nil {
+		return 0, err
+	}
+	var id int64
+	err = q.QueryRowx(q.Rebind(query), args...).Scan(&id)
+	return id, err
+}
+
+func (r *PostgresRepository) Challenge() repository.ChallengeRepository {
+	return r.challenge
+}
+
+func (r *PostgresRepository) Task() repository.TaskRepository {
+	return r.task
+}
+
+func (r *PostgresRepository) Participant() repository.ParticipantRepository {
+	return r.participant
+}
+
+func (r *PostgresRepository) Completion() repository.CompletionRepository {
+	return r.completion
+}
+
+func (r *PostgresRepository) State() repository.StateRepository {
+	return r.state
+}
+
+func (r *PostgresRepository) SuperAdmin() repository.SuperAdminRepository {
+	return r.superAdmin
+}
+
+func (r *PostgresRepository) Template() repository.TemplateRepository {
+	return r.template
+}
+
+func (r *PostgresRepository) TemplateTask() repository.TemplateTaskRepository {
+	return r.templateTask
+}
+
+func (r *PostgresRepository) TemplateCategory() repository.TemplateCategoryRepository {
+	return r.category
+}
+
+func (r *PostgresRepository) TemplateVersion() repository.TemplateVersionRepository {
+	return r.version
+}
+
+func (r *PostgresRepository) Comment() repository.CommentRepository {
+	return r.comment
+}
+
+func (r *PostgresRepository) Kudos() repository.KudosRepository {
+	return r.kudos
+}
+
+func (r *PostgresRepository) Nudge() repository.NudgeRepository {
+	return r.nudge
+}
+
+func (r *PostgresRepository) Achievement() repository.AchievementRepository {
+	return r.achievement
+}
+
+func (r *PostgresRepository) Audit() repository.AuditRepository {
+	return r.audit
+}
+
+// WithTx runs fn with a repository whose reads and writes share one transaction.
+// The transaction commits if fn returns nil and rolls back otherwise; calling
+// WithTx on a transactional repository joins the outer transaction
+func (r *PostgresRepository) WithTx(fn func(repo repository.Repository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	txRepo := newRepository(r.db, tx)
+	txRepo.tx = tx
+	if err := fn(txRepo); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRepository) Ping() error {
+	return r.db.Ping()
+}
+
+func (r *PostgresRepository) Close() error {
+	if r.tx != nil {
+		// The connection belongs to the repository that started the transaction
+		return nil
+	}
+	return r.db.Close()
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository/repotest"
+)
+
+// setupTestDB returns a migrated repository in a fresh schema of the database at
+// TEST_DATABASE_URL, and skips the test when it isn't set
+func setupTestDB(t *testing.T) *PostgresRepository {
+	t.Helper()
+
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := sqlx.Connect("postgres", databaseURL)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		admin.Close()
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+
+	u, err := url.Parse(databaseURL)
+	if err != nil {
+		t.Fatalf("TEST_DATABASE_URL must be a URL: %v", err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+
+	repo, err := New(u.String())
+	if err != nil {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		admin.Close()
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	t.Cleanup(func() {
+		repo.Close()
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		admin.Close()
+	})
+
+	return repo
+}
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		return setupTestDB(t)
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	repo := setupTestDB(t)
+
+	latest, err := LatestMigrationVersion()
+	if err != nil {
+		t.Fatalf("LatestMigrationVersion() error = %v", err)
+	}
+	if version, _ := repo.SchemaVersion(); version != latest {
+		t.Errorf("SchemaVersion() = %d, want %d", version, latest)
+	}
+
+	// Running again is a no-op
+	if err := repo.Migrate(0); err != nil {
+		t.Fatalf("Migrate() again error = %v", err)
+	}
+	statuses, err := repo.MigrationStatus()
+	if err != nil {
+		t.Fatalf("MigrationStatus() error = %v", err)
+	}
+	for _, s := range statuses {
+		if !s.Applied || s.Modified {
+			t.Errorf("migration %d: applied = %v, modified = %v", s.Version, s.Applied, s.Modified)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository/migration"
+)
+
+// migrationLockID is the advisory lock key held while migrating, so replicas
+// starting at the same time don't apply the same migration twice
+const migrationLockID = 0x5C4D_0001
+
+func loadMigrations() ([]migration.Migration, error) {
+	return migration.Load(migrationsFS, "migrations")
+}
+
+// LatestMigrationVersion returns the version of the newest embedded migration
+func LatestMigrationVersion() (int, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	return migration.Latest(migrations), nil
+}
+
+func (r *PostgresRepository) tableExists(name string) (bool, error) {
+	var exists bool
+	err := r.db.Get(&exists, "SELECT to_regclass($1) IS NOT NULL", name)
+	return exists, err
+}
+
+func (r *PostgresRepository) appliedMigrations() (map[int]migration.Applied, error) {
+	applied := map[int]migration.Applied{}
+	exists, err := r.tableExists("schema_migrations")
+	if err != nil || !exists {
+		return applied, err
+	}
+
+	var rows []migration.Applied
+	if err := r.db.Select(&rows, "SELECT version, name, checksum, applied_at FROM schema_migrations"); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// SchemaVersion returns the highest applied migration version (0 for an empty database)
+func (r *PostgresRepository) SchemaVersion() (int, error) {
+	exists, err := r.tableExists("schema_migrations")
+	if err != nil || !exists {
+		return 0, err
+	}
+	var version sql.NullInt64
+	if err := r.db.Get(&version, "SELECT MAX(version) FROM schema_migrations"); err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
+
+// MigrationStatus lists the embedded migrations and whether each has been applied
+func (r *PostgresRepository) MigrationStatus() ([]migration.Status, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := r.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	return migration.Statuses(migrations, applied), nil
+}
+
+// Migrate applies pending migrations up to target (0 = latest). Each migration runs once,
+// in its own transaction, and is recorded in schema_migrations with its checksum.
+func (r *PostgresRepository) Migrate(target int) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	conn, err := r.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return err
+	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	_, err = r.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`)
+	if err != nil {
+		return err
+	}
+
+	applied, err := r.appliedMigrations()
+	if err != nil {
+		return err
+	}
+	pending, err := migration.Pending(migrations, applied, target)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pending {
+		if err := r.applyMigration(m); err != nil {
+			return fmt.Errorf("migration %03d_%s: %w", m.Version, m.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyMigration runs a migration and records it in one transaction
+func (r *PostgresRepository) applyMigration(m migration.Migration) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(m.SQL); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+		m.Version, m.Name, m.Checksum,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
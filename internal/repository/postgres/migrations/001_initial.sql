@@ -0,0 +1,173 @@
+-- PostgreSQL baseline: the schema of SQLite migrations 001-014 in one file
+CREATE TABLE IF NOT EXISTS challenges (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    creator_id BIGINT NOT NULL,
+    daily_task_limit INTEGER NOT NULL DEFAULT 0,
+    hide_future_tasks BOOLEAN NOT NULL DEFAULT FALSE,
+    shuffle_tasks BOOLEAN NOT NULL DEFAULT FALSE,
+    template_id BIGINT NOT NULL DEFAULT 0,
+    template_version INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_challenges_template ON challenges(template_id);
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id BIGSERIAL PRIMARY KEY,
+    challenge_id TEXT NOT NULL REFERENCES challenges(id) ON DELETE CASCADE,
+    order_num INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    image_file_id TEXT NOT NULL DEFAULT '',
+    template_task_id BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(challenge_id, order_num)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_challenge ON tasks(challenge_id);
+
+CREATE TABLE IF NOT EXISTS participants (
+    id BIGSERIAL PRIMARY KEY,
+    challenge_id TEXT NOT NULL REFERENCES challenges(id) ON DELETE CASCADE,
+    telegram_id BIGINT NOT NULL,
+    display_name TEXT NOT NULL,
+    emoji TEXT NOT NULL,
+    notify_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    time_offset_minutes INTEGER NOT NULL DEFAULT 0,
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(challenge_id, telegram_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_participants_challenge ON participants(challenge_id);
+CREATE INDEX IF NOT EXISTS idx_participants_telegram ON participants(telegram_id);
+
+CREATE TABLE IF NOT EXISTS task_completions (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    participant_id BIGINT NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+    completed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(task_id, participant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_completions_task ON task_completions(task_id);
+CREATE INDEX IF NOT EXISTS idx_completions_participant ON task_completions(participant_id);
+
+CREATE TABLE IF NOT EXISTS user_states (
+    telegram_id BIGINT PRIMARY KEY,
+    state TEXT NOT NULL DEFAULT 'idle',
+    temp_data TEXT,
+    current_challenge TEXT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS super_admins (
+    id BIGSERIAL PRIMARY KEY,
+    telegram_id BIGINT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS template_categories (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS templates (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    daily_task_limit INTEGER NOT NULL DEFAULT 0,
+    hide_future_tasks BOOLEAN NOT NULL DEFAULT FALSE,
+    owner_id BIGINT NOT NULL DEFAULT 0,
+    share_code TEXT NOT NULL DEFAULT '',
+    category_id BIGINT NOT NULL DEFAULT 0,
+    tags TEXT NOT NULL DEFAULT '',
+    use_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_templates_owner ON templates(owner_id);
+CREATE INDEX IF NOT EXISTS idx_templates_category ON templates(category_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_templates_share_code ON templates(share_code) WHERE share_code != '';
+
+CREATE TABLE IF NOT EXISTS template_tasks (
+    id BIGSERIAL PRIMARY KEY,
+    template_id BIGINT NOT NULL REFERENCES templates(id) ON DELETE CASCADE,
+    order_num INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    image_file_id TEXT NOT NULL DEFAULT '',
+    UNIQUE(template_id, order_num)
+);
+
+CREATE INDEX IF NOT EXISTS idx_template_tasks_template ON template_tasks(template_id);
+
+CREATE TABLE IF NOT EXISTS template_versions (
+    id BIGSERIAL PRIMARY KEY,
+    template_id BIGINT NOT NULL REFERENCES templates(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    editor_id BIGINT NOT NULL DEFAULT 0,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    daily_task_limit INTEGER NOT NULL DEFAULT 0,
+    hide_future_tasks BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(template_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS template_version_tasks (
+    id BIGSERIAL PRIMARY KEY,
+    version_id BIGINT NOT NULL REFERENCES template_versions(id) ON DELETE CASCADE,
+    template_task_id BIGINT NOT NULL,
+    order_num INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    image_file_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_template_version_tasks_version ON template_version_tasks(version_id);
+
+CREATE TABLE IF NOT EXISTS task_comments (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    participant_id BIGINT NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comments_task ON task_comments(task_id);
+
+CREATE TABLE IF NOT EXISTS kudos (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    from_participant_id BIGINT NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+    to_participant_id BIGINT NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+    emoji TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(task_id, from_participant_id, to_participant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_kudos_to_participant ON kudos(to_participant_id);
+
+CREATE TABLE IF NOT EXISTS nudges (
+    id BIGSERIAL PRIMARY KEY,
+    from_participant_id BIGINT NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+    to_participant_id BIGINT NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_nudges_pair ON nudges(from_participant_id, to_participant_id);
+
+CREATE TABLE IF NOT EXISTS achievements (
+    id BIGSERIAL PRIMARY KEY,
+    telegram_id BIGINT NOT NULL,
+    challenge_id TEXT NOT NULL,
+    code TEXT NOT NULL,
+    awarded_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(telegram_id, challenge_id, code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievements_telegram ON achievements(telegram_id);
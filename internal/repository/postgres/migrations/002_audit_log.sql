@@ -0,0 +1,28 @@
+-- Append-only audit log of admin and super admin actions. Targets have no foreign keys
+-- so the history of a deleted challenge, task or template stays intact
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT NOT NULL,
+    action TEXT NOT NULL,
+    challenge_id TEXT NOT NULL DEFAULT '',
+    task_id BIGINT NOT NULL DEFAULT 0,
+    template_id BIGINT NOT NULL DEFAULT 0,
+    target_user_id BIGINT NOT NULL DEFAULT 0,
+    target_name TEXT NOT NULL DEFAULT '',
+    before_value TEXT NOT NULL DEFAULT '',
+    after_value TEXT NOT NULL DEFAULT '',
+    observer BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_challenge ON audit_log(challenge_id, id);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+BEFORE UPDATE OR DELETE ON audit_log
+FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
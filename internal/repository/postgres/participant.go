@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// ParticipantRepo implements ParticipantRepository for PostgreSQL
+type ParticipantRepo struct {
+	db dbtx
+}
+
+func (r *ParticipantRepo) Create(participant *domain.Participant) error {
+	participant.JoinedAt = time.Now()
+
+	id, err := insertReturningID(r.db, `
+		INSERT INTO participants (challenge_id, telegram_id, display_name, emoji, notify_enabled, time_offset_minutes, joined_at)
+		VALUES (:challenge_id, :telegram_id, :display_name, :emoji, :notify_enabled, :time_offset_minutes, :joined_at)
+		RETURNING id
+	`, participant)
+	if err != nil {
+		return err
+	}
+	participant.ID = id
+	return nil
+}
+
+func (r *ParticipantRepo) GetByID(id int64) (*domain.Participant, error) {
+	var participant domain.Participant
+	err := r.db.Get(&participant, "SELECT * FROM participants WHERE id = $1", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &participant, err
+}
+
+func (r *ParticipantRepo) GetByIDForUpdate(id int64) (*domain.Participant, error) {
+	var participant domain.Participant
+	err := r.db.Get(&participant, "SELECT * FROM participants WHERE id = $1 FOR UPDATE", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &participant, err
+}
+
+func (r *ParticipantRepo) GetByChallengeAndUser(challengeID string, telegramID int64) (*domain.Participant, error) {
+	var participant domain.Participant
+	err := r.db.Get(&participant, `
+		SELECT * FROM participants
+		WHERE challenge_id = $1 AND telegram_id = $2
+	`, challengeID, telegramID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &participant, err
+}
+
+func (r *ParticipantRepo) GetByChallengeID(challengeID string) ([]*domain.Participant, error) {
+	var participants []*domain.Participant
+	err := r.db.Select(&participants, `
+		SELECT * FROM participants
+		WHERE challenge_id = $1
+		ORDER BY joined_at ASC, id ASC
+	`, challengeID)
+	return participants, err
+}
+
+func (r *ParticipantRepo) Update(participant *domain.Participant) error {
+	_, err := r.db.NamedExec(`
+		UPDATE participants
+		SET display_name = :display_name, emoji = :emoji, notify_enabled = :notify_enabled, time_offset_minutes = :time_offset_minutes
+		WHERE id = :id
+	`, participant)
+	return err
+}
+
+func (r *ParticipantRepo) UpdateTimeOffset(id int64, offsetMinutes int) error {
+	_, err := r.db.Exec(`
+		UPDATE participants
+		SET time_offset_minutes = $1
+		WHERE id = $2
+	`, offsetMinutes, id)
+	return err
+}
+
+func (r *ParticipantRepo) Delete(id int64) error {
+	_, err := r.db.Exec("DELETE FROM participants WHERE id = $1", id)
+	return err
+}
+
+func (r *ParticipantRepo) CountByChallengeID(challengeID string) (int, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM participants WHERE challenge_id = $1", challengeID)
+	return count, err
+}
+
+func (r *ParticipantRepo) GetUsedEmojis(challengeID string) ([]string, error) {
+	var emojis []string
+	err := r.db.Select(&emojis, `
+		SELECT emoji FROM participants WHERE challenge_id = $1
+	`, challengeID)
+	return emojis, err
+}
+
+func (r *ParticipantRepo) Count() (int, error) {
+	var count int
+	err := r.db.Get(&count, `
+		SELECT COUNT(*) FROM participants p
+		JOIN challenges c ON c.id = p.challenge_id
+		WHERE c.deleted_at IS NULL
+	`)
+	return count, err
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// StateRepo implements StateRepository for PostgreSQL
+type StateRepo struct {
+	db dbtx
+}
+
+func (r *StateRepo) Get(telegramID int64) (*domain.UserState, error) {
+	var state domain.UserState
+	// Use COALESCE to handle NULL values for temp_data and current_challenge
+	err := r.db.Get(&state, `
+		SELECT telegram_id, state,
+			COALESCE(temp_data, '') as temp_data,
+			COALESCE(current_challenge, '') as current_challenge,
+			updated_at
+		FROM user_states WHERE telegram_id = $1`, telegramID)
+	if err == sql.ErrNoRows {
+		// Return default idle state
+		return &domain.UserState{
+			TelegramID: telegramID,
+			State:      domain.StateIdle,
+			UpdatedAt:  time.Now(),
+		}, nil
+	}
+	return &state, err
+}
+
+func (r *StateRepo) Set(state *domain.UserState) error {
+	state.UpdatedAt = time.Now()
+
+	_, err := r.db.NamedExec(`
+		INSERT INTO user_states (telegram_id, state, temp_data, current_challenge, updated_at)
+		VALUES (:telegram_id, :state, :temp_data, :current_challenge, :updated_at)
+		ON CONFLICT(telegram_id) DO UPDATE SET
+			state = EXCLUDED.state,
+			temp_data = EXCLUDED.temp_data,
+			current_challenge = EXCLUDED.current_challenge,
+			updated_at = EXCLUDED.updated_at
+	`, state)
+	return err
+}
+
+func (r *StateRepo) Reset(telegramID int64) error {
+	_, err := r.db.Exec(`
+		UPDATE user_states
+		SET state = $1, temp_data = NULL, updated_at = $2
+		WHERE telegram_id = $3
+	`, domain.StateIdle, time.Now(), telegramID)
+	return err
+}
+
+// ResetByChallenge resets all users who have a specific challenge as their current challenge
+func (r *StateRepo) ResetByChallenge(challengeID string) error {
+	_, err := r.db.Exec(`
+		UPDATE user_states
+		SET state = $1, temp_data = NULL, current_challenge = NULL, updated_at = $2
+		WHERE current_challenge = $3
+	`, domain.StateIdle, time.Now(), challengeID)
+	return err
+}
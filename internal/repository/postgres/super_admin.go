@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// SuperAdminRepo implements SuperAdminRepository for PostgreSQL
+type SuperAdminRepo struct {
+	db dbtx
+}
+
+func (r *SuperAdminRepo) Create(telegramID int64) error {
+	_, err := r.db.Exec(`
+		INSERT INTO super_admins (telegram_id, created_at)
+		VALUES ($1, $2)
+		ON CONFLICT (telegram_id) DO NOTHING
+	`, telegramID, time.Now())
+	return err
+}
+
+func (r *SuperAdminRepo) Delete(telegramID int64) error {
+	_, err := r.db.Exec("DELETE FROM super_admins WHERE telegram_id = $1", telegramID)
+	return err
+}
+
+func (r *SuperAdminRepo) IsSuperAdmin(telegramID int64) (bool, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM super_admins WHERE telegram_id = $1", telegramID)
+	return count > 0, err
+}
+
+func (r *SuperAdminRepo) GetAll() ([]*domain.SuperAdmin, error) {
+	var admins []*domain.SuperAdmin
+	err := r.db.Select(&admins, "SELECT * FROM super_admins ORDER BY created_at")
+	return admins, err
+}
+
+func (r *SuperAdminRepo) Exists(telegramID int64) (bool, error) {
+	return r.IsSuperAdmin(telegramID)
+}
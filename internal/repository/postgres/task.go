@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// TaskRepo implements TaskRepository for PostgreSQL
+type TaskRepo struct {
+	db dbtx
+}
+
+const insertTaskQuery = `
+	INSERT INTO tasks (challenge_id, order_num, title, description, image_file_id, template_task_id, created_at)
+	VALUES (:challenge_id, :order_num, :title, :description, :image_file_id, :template_task_id, :created_at)
+	RETURNING id
+`
+
+func (r *TaskRepo) Create(task *domain.Task) error {
+	task.CreatedAt = time.Now()
+
+	id, err := insertReturningID(r.db, insertTaskQuery, task)
+	if err != nil {
+		return err
+	}
+	task.ID = id
+	return nil
+}
+
+func (r *TaskRepo) CreateBatch(tasks []*domain.Task) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, task := range tasks {
+		task.CreatedAt = now
+		id, err := insertReturningID(tx, insertTaskQuery, task)
+		if err != nil {
+			return err
+		}
+		task.ID = id
+	}
+
+	return tx.Commit()
+}
+
+func (r *TaskRepo) ApplyBatch(updated []*domain.Task, created []*domain.Task) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, task := range updated {
+		_, err := tx.NamedExec(`
+			UPDATE tasks
+			SET title = :title, description = :description, image_file_id = :image_file_id, order_num = :order_num
+			WHERE id = :id
+		`, task)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	for _, task := range created {
+		task.CreatedAt = now
+		id, err := insertReturningID(tx, insertTaskQuery, task)
+		if err != nil {
+			return err
+		}
+		task.ID = id
+	}
+
+	return tx.Commit()
+}
+
+func (r *TaskRepo) GetByID(id int64) (*domain.Task, error) {
+	var task domain.Task
+	err := r.db.Get(&task, "SELECT * FROM tasks WHERE id = $1 AND deleted_at IS NULL", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &task, err
+}
+
+func (r *TaskRepo) GetByChallengeID(challengeID string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	err := r.db.Select(&tasks, `
+		SELECT * FROM tasks
+		WHERE challenge_id = $1 AND deleted_at IS NULL
+		ORDER BY order_num ASC
+	`, challengeID)
+	return tasks, err
+}
+
+func (r *TaskRepo) Update(task *domain.Task) error {
+	_, err := r.db.NamedExec(`
+		UPDATE tasks
+		SET title = :title, description = :description, image_file_id = :image_file_id, order_num = :order_num
+		WHERE id = :id
+	`, task)
+	return err
+}
+
+func (r *TaskRepo) Delete(id int64) error {
+	// A trashed task gives up its position; -id can't clash with any other task's
+	_, err := r.db.Exec(`
+		UPDATE tasks
+		SET deleted_at = $1, order_num = -id
+		WHERE id = $2 AND deleted_at IS NULL
+	`, time.Now().UTC(), id)
+	return err
+}
+
+func (r *TaskRepo) GetMaxOrderNum(challengeID string) (int, error) {
+	var maxOrder sql.NullInt64
+	err := r.db.Get(&maxOrder, `
+		SELECT MAX(order_num) FROM tasks WHERE challenge_id = $1 AND deleted_at IS NULL
+	`, challengeID)
+	if err != nil {
+		return 0, err
+	}
+	if !maxOrder.Valid {
+		return 0, nil
+	}
+	return int(maxOrder.Int64), nil
+}
+
+func (r *TaskRepo) UpdateOrderNums(challengeID string, updates map[int64]int) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// First, move them out of the way to avoid unique constraint violations. -id can't
+	// clash with the positions of other tasks, trashed ones included
+	for taskID := range updates {
+		_, err = tx.Exec("UPDATE tasks SET order_num = -id WHERE id = $1", taskID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Then set to positive values
+	for taskID, newOrder := range updates {
+		_, err = tx.Exec("UPDATE tasks SET order_num = $1 WHERE id = $2", newOrder, taskID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *TaskRepo) CountByChallengeID(challengeID string) (int, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM tasks WHERE challenge_id = $1 AND deleted_at IS NULL", challengeID)
+	return count, err
+}
+
+func (r *TaskRepo) GetDeletedByChallengeID(challengeID string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	err := r.db.Select(&tasks, `
+		SELECT * FROM tasks
+		WHERE challenge_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+	`, challengeID)
+	return tasks, err
+}
+
+func (r *TaskRepo) GetDeletedByID(id int64) (*domain.Task, error) {
+	var task domain.Task
+	err := r.db.Get(&task, "SELECT * FROM tasks WHERE id = $1 AND deleted_at IS NOT NULL", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &task, err
+}
+
+func (r *TaskRepo) Restore(id int64, orderNum int) error {
+	_, err := r.db.Exec(`
+		UPDATE tasks
+		SET deleted_at = NULL, order_num = $1
+		WHERE id = $2 AND deleted_at IS NOT NULL
+	`, orderNum, id)
+	return err
+}
+
+func (r *TaskRepo) PurgeDeleted(before time.Time) (int, error) {
+	result, err := r.db.Exec("DELETE FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < $1", before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	purged, err := result.RowsAffected()
+	return int(purged), err
+}
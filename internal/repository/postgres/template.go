@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// TemplateRepo implements TemplateRepository for PostgreSQL
+type TemplateRepo struct {
+	db dbtx
+}
+
+func (r *TemplateRepo) Create(template *domain.Template) error {
+	template.CreatedAt = time.Now()
+
+	id, err := insertReturningID(r.db, `
+		INSERT INTO templates (name, description, daily_task_limit, hide_future_tasks, owner_id, created_at)
+		VALUES (:name, :description, :daily_task_limit, :hide_future_tasks, :owner_id, :created_at)
+		RETURNING id
+	`, template)
+	if err != nil {
+		return err
+	}
+	template.ID = id
+	return nil
+}
+
+func (r *TemplateRepo) GetByID(id int64) (*domain.Template, error) {
+	var template domain.Template
+	err := r.db.Get(&template, "SELECT * FROM templates WHERE id = $1", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &template, err
+}
+
+func (r *TemplateRepo) GetByShareCode(code string) (*domain.Template, error) {
+	var template domain.Template
+	err := r.db.Get(&template, "SELECT * FROM templates WHERE share_code = $1 AND share_code != ''", code)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &template, err
+}
+
+func (r *TemplateRepo) ExistsByShareCode(code string) (bool, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM templates WHERE share_code = $1", code)
+	return count > 0, err
+}
+
+func (r *TemplateRepo) UpdateShareCode(id int64, code string) error {
+	_, err := r.db.Exec("UPDATE templates SET share_code = $1 WHERE id = $2", code, id)
+	return err
+}
+
+// filterWhere builds the WHERE clause selecting global templates that match the filter.
+// Placeholders are numbered from 1; callers append further arguments after the returned ones.
+func filterWhere(filter domain.TemplateFilter) (string, []any) {
+	where := "owner_id = 0"
+	var args []any
+	if filter.CategoryID > 0 {
+		args = append(args, filter.CategoryID)
+		where += fmt.Sprintf(" AND category_id = $%d", len(args))
+	}
+	if filter.Query != "" {
+		// ILIKE matches SQLite's case-insensitive LIKE
+		args = append(args, "%"+likeEscaper.Replace(filter.Query)+"%")
+		n := len(args)
+		where += fmt.Sprintf(` AND (name ILIKE $%d ESCAPE '\' OR description ILIKE $%d ESCAPE '\' OR tags ILIKE $%d ESCAPE '\')`, n, n, n)
+	}
+	return where, args
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *TemplateRepo) Find(filter domain.TemplateFilter, limit, offset int) ([]*domain.Template, error) {
+	where, args := filterWhere(filter)
+	order := "created_at DESC, id DESC"
+	if filter.Sort == domain.TemplateSortPopular {
+		order = "use_count DESC, " + order
+	}
+	query := fmt.Sprintf("SELECT * FROM templates WHERE %s ORDER BY %s LIMIT $%d OFFSET $%d", where, order, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	var templates []*domain.Template
+	err := r.db.Select(&templates, query, args...)
+	return templates, err
+}
+
+func (r *TemplateRepo) CountFiltered(filter domain.TemplateFilter) (int, error) {
+	where, args := filterWhere(filter)
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM templates WHERE "+where, args...)
+	return count, err
+}
+
+func (r *TemplateRepo) CountByCategory() (map[int64]int, error) {
+	var rows []struct {
+		CategoryID int64 `db:"category_id"`
+		Count      int   `db:"count"`
+	}
+	err := r.db.Select(&rows, "SELECT category_id, COUNT(*) AS count FROM templates WHERE owner_id = 0 GROUP BY category_id")
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *TemplateRepo) UpdateCategory(id int64, categoryID int64) error {
+	_, err := r.db.Exec("UPDATE templates SET category_id = $1 WHERE id = $2", categoryID, id)
+	return err
+}
+
+func (r *TemplateRepo) UpdateTags(id int64, tags string) error {
+	_, err := r.db.Exec("UPDATE templates SET tags = $1 WHERE id = $2", tags, id)
+	return err
+}
+
+func (r *TemplateRepo) IncrementUseCount(id int64) error {
+	_, err := r.db.Exec("UPDATE templates SET use_count = use_count + 1 WHERE id = $1", id)
+	return err
+}
+
+func (r *TemplateRepo) GetGlobal() ([]*domain.Template, error) {
+	var templates []*domain.Template
+	err := r.db.Select(&templates, "SELECT * FROM templates WHERE owner_id = 0 ORDER BY created_at DESC")
+	return templates, err
+}
+
+func (r *TemplateRepo) GetByOwner(ownerID int64) ([]*domain.Template, error) {
+	var templates []*domain.Template
+	err := r.db.Select(&templates, "SELECT * FROM templates WHERE owner_id = $1 ORDER BY created_at DESC", ownerID)
+	return templates, err
+}
+
+func (r *TemplateRepo) Delete(id int64) error {
+	_, err := r.db.Exec("DELETE FROM templates WHERE id = $1", id)
+	return err
+}
+
+func (r *TemplateRepo) CountByOwner(ownerID int64) (int, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM templates WHERE owner_id = $1", ownerID)
+	return count, err
+}
+
+func (r *TemplateRepo) ExistsByName(name string, ownerID int64) (bool, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM templates WHERE name = $1 AND owner_id = $2", name, ownerID)
+	return count > 0, err
+}
+
+func (r *TemplateRepo) UpdateName(id int64, name string) error {
+	_, err := r.db.Exec("UPDATE templates SET name = $1 WHERE id = $2", name, id)
+	return err
+}
+
+func (r *TemplateRepo) UpdateDescription(id int64, description string) error {
+	_, err := r.db.Exec("UPDATE templates SET description = $1 WHERE id = $2", description, id)
+	return err
+}
+
+func (r *TemplateRepo) UpdateDailyLimit(id int64, limit int) error {
+	_, err := r.db.Exec("UPDATE templates SET daily_task_limit = $1 WHERE id = $2", limit, id)
+	return err
+}
+
+func (r *TemplateRepo) UpdateHideFutureTasks(id int64, hide bool) error {
+	_, err := r.db.Exec("UPDATE templates SET hide_future_tasks = $1 WHERE id = $2", hide, id)
+	return err
+}
+
+func (r *TemplateRepo) Restore(template *domain.Template, tasks []*domain.TemplateTask) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.NamedExec(`
+		UPDATE templates
+		SET name = :name, description = :description, daily_task_limit = :daily_task_limit, hide_future_tasks = :hide_future_tasks
+		WHERE id = :id
+	`, template)
+	if err != nil {
+		return err
+	}
+
+	var existingIDs []int64
+	if err := tx.Select(&existingIDs, "SELECT id FROM template_tasks WHERE template_id = $1", template.ID); err != nil {
+		return err
+	}
+	keep := make(map[int64]bool, len(tasks))
+	for _, task := range tasks {
+		keep[task.ID] = true
+	}
+	existing := make(map[int64]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		if !keep[id] {
+			if _, err := tx.Exec("DELETE FROM template_tasks WHERE id = $1", id); err != nil {
+				return err
+			}
+			continue
+		}
+		existing[id] = true
+	}
+
+	// Move remaining tasks out of the way to avoid unique constraint violations
+	if _, err := tx.Exec("UPDATE template_tasks SET order_num = -id WHERE template_id = $1", template.ID); err != nil {
+		return err
+	}
+
+	// Tasks deleted since the version are re-created with their original ID,
+	// so challenges linked to them keep matching. IDs come from the sequence
+	// and are never handed out again, so the explicit insert can't collide.
+	for _, task := range tasks {
+		task.TemplateID = template.ID
+		query := `
+			INSERT INTO template_tasks (id, template_id, order_num, title, description, image_file_id)
+			VALUES (:id, :template_id, :order_num, :title, :description, :image_file_id)
+		`
+		if existing[task.ID] {
+			query = `
+				UPDATE template_tasks
+				SET order_num = :order_num, title = :title, description = :description, image_file_id = :image_file_id
+				WHERE id = :id
+			`
+		}
+		if _, err := tx.NamedExec(query, task); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
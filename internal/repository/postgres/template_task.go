@@ -0,0 +1,131 @@
+package postgres
+
+import "github.com/rgeraskin/squad-challenge-bot/internal/domain"
+
+// TemplateTaskRepo implements TemplateTaskRepository for PostgreSQL
+type TemplateTaskRepo struct {
+	db dbtx
+}
+
+const insertTemplateTaskQuery = `
+	INSERT INTO template_tasks (template_id, order_num, title, description, image_file_id)
+	VALUES (:template_id, :order_num, :title, :description, :image_file_id)
+	RETURNING id
+`
+
+func (r *TemplateTaskRepo) Create(task *domain.TemplateTask) error {
+	id, err := insertReturningID(r.db, insertTemplateTaskQuery, task)
+	if err != nil {
+		return err
+	}
+	task.ID = id
+	return nil
+}
+
+func (r *TemplateTaskRepo) CreateBatch(tasks []*domain.TemplateTask) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, task := range tasks {
+		id, err := insertReturningID(tx, insertTemplateTaskQuery, task)
+		if err != nil {
+			return err
+		}
+		task.ID = id
+	}
+
+	return tx.Commit()
+}
+
+func (r *TemplateTaskRepo) GetByID(id int64) (*domain.TemplateTask, error) {
+	var task domain.TemplateTask
+	err := r.db.Get(&task, "SELECT * FROM template_tasks WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *TemplateTaskRepo) GetByTemplateID(templateID int64) ([]*domain.TemplateTask, error) {
+	var tasks []*domain.TemplateTask
+	err := r.db.Select(&tasks, `
+		SELECT * FROM template_tasks
+		WHERE template_id = $1
+		ORDER BY order_num ASC
+	`, templateID)
+	return tasks, err
+}
+
+func (r *TemplateTaskRepo) DeleteByTemplateID(templateID int64) error {
+	_, err := r.db.Exec("DELETE FROM template_tasks WHERE template_id = $1", templateID)
+	return err
+}
+
+func (r *TemplateTaskRepo) CountByTemplateID(templateID int64) (int, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM template_tasks WHERE template_id = $1", templateID)
+	return count, err
+}
+
+func (r *TemplateTaskRepo) Delete(id int64) error {
+	_, err := r.db.Exec("DELETE FROM template_tasks WHERE id = $1", id)
+	return err
+}
+
+func (r *TemplateTaskRepo) UpdateTitle(id int64, title string) error {
+	_, err := r.db.Exec("UPDATE template_tasks SET title = $1 WHERE id = $2", title, id)
+	return err
+}
+
+func (r *TemplateTaskRepo) UpdateDescription(id int64, description string) error {
+	_, err := r.db.Exec("UPDATE template_tasks SET description = $1 WHERE id = $2", description, id)
+	return err
+}
+
+func (r *TemplateTaskRepo) UpdateImage(id int64, imageFileID string) error {
+	_, err := r.db.Exec("UPDATE template_tasks SET image_file_id = $1 WHERE id = $2", imageFileID, id)
+	return err
+}
+
+func (r *TemplateTaskRepo) GetMaxOrderNum(templateID int64) (int, error) {
+	var maxOrder *int
+	err := r.db.Get(&maxOrder, "SELECT MAX(order_num) FROM template_tasks WHERE template_id = $1", templateID)
+	if err != nil || maxOrder == nil {
+		return 0, err
+	}
+	return *maxOrder, nil
+}
+
+func (r *TemplateTaskRepo) UpdateOrderNum(id int64, orderNum int) error {
+	_, err := r.db.Exec("UPDATE template_tasks SET order_num = $1 WHERE id = $2", orderNum, id)
+	return err
+}
+
+func (r *TemplateTaskRepo) UpdateOrderNums(templateID int64, updates map[int64]int) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// First, set all to negative values to avoid unique constraint violations
+	for taskID, newOrder := range updates {
+		_, err = tx.Exec("UPDATE template_tasks SET order_num = $1 WHERE id = $2", -newOrder, taskID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Then set to positive values
+	for taskID, newOrder := range updates {
+		_, err = tx.Exec("UPDATE template_tasks SET order_num = $1 WHERE id = $2", newOrder, taskID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// dbtx is implemented by both *sqlx.DB and *sqlx.Tx, so repos work the same
+// inside and outside a transaction
+type dbtx interface {
+	sqlx.Ext
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+	NamedExec(query string, arg interface{}) (sql.Result, error)
+}
+
+// txHandle is a transaction started by a repo method. When the repo already runs
+// inside a transaction the handle joins it, and commit/rollback are left to its owner
+type txHandle struct {
+	*sqlx.Tx
+	joined bool
+}
+
+// begin starts a transaction on db, or joins db if it already is one
+func begin(db dbtx) (*txHandle, error) {
+	if tx, ok := db.(*sqlx.Tx); ok {
+		return &txHandle{Tx: tx, joined: true}, nil
+	}
+	tx, err := db.(*sqlx.DB).Beginx()
+	if err != nil {
+		return nil, err
+	}
+	return &txHandle{Tx: tx}, nil
+}
+
+func (t *txHandle) Commit() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *txHandle) Rollback() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
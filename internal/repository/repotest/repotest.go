@@ -0,0 +1,786 @@
+// Package repotest is a conformance suite that every repository.Repository backend must pass
+package repotest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+// bigTelegramID doesn't fit in 32 bits, like many real Telegram user IDs
+const bigTelegramID int64 = 7_000_000_001
+
+// Run runs the suite; newRepo must return an empty, fully migrated repository for each test
+func Run(t *testing.T, newRepo func(t *testing.T) repository.Repository) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo repository.Repository)
+	}{
+		{"Challenges", testChallenges},
+		{"Tasks", testTasks},
+		{"Participants", testParticipants},
+		{"Completions", testCompletions},
+		{"States", testStates},
+		{"SuperAdmins", testSuperAdmins},
+		{"Templates", testTemplates},
+		{"TemplateSearch", testTemplateSearch},
+		{"TemplateCategories", testTemplateCategories},
+		{"TemplateVersions", testTemplateVersions},
+		{"TemplateRestore", testTemplateRestore},
+		{"Social", testSocial},
+		{"Achievements", testAchievements},
+		{"TaskTrash", testTaskTrash},
+		{"CascadePurge", testCascadePurge},
+		{"Transactions", testTransactions},
+		{"Audit", testAudit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func createChallenge(t *testing.T, repo repository.Repository, id string) *domain.Challenge {
+	t.Helper()
+	challenge := &domain.Challenge{ID: id, Name: "Challenge " + id, CreatorID: bigTelegramID}
+	must(t, repo.Challenge().Create(challenge))
+	return challenge
+}
+
+func createTasks(t *testing.T, repo repository.Repository, challengeID string, n int) []*domain.Task {
+	t.Helper()
+	tasks := make([]*domain.Task, n)
+	for i := range tasks {
+		tasks[i] = &domain.Task{ChallengeID: challengeID, OrderNum: i + 1, Title: fmt.Sprintf("Task %d", i+1)}
+	}
+	must(t, repo.Task().CreateBatch(tasks))
+	return tasks
+}
+
+func createParticipant(t *testing.T, repo repository.Repository, challengeID string, telegramID int64, emoji string) *domain.Participant {
+	t.Helper()
+	p := &domain.Participant{ChallengeID: challengeID, TelegramID: telegramID, DisplayName: "User", Emoji: emoji, NotifyEnabled: true}
+	must(t, repo.Participant().Create(p))
+	return p
+}
+
+func testChallenges(t *testing.T, repo repository.Repository) {
+	if got, err := repo.Challenge().GetByID("missing1"); err != nil || got != nil {
+		t.Fatalf("GetByID(missing) = %v, %v, want nil, nil", got, err)
+	}
+
+	challenge := createChallenge(t, repo, "abc12345")
+	must(t, repo.Challenge().UpdateHideFutureTasks(challenge.ID, true))
+	must(t, repo.Challenge().UpdateShuffleTasks(challenge.ID, true))
+	must(t, repo.Challenge().UpdateDailyLimit(challenge.ID, 3))
+	must(t, repo.Challenge().UpdateTemplateVersion(challenge.ID, 7))
+
+	got, err := repo.Challenge().GetByID(challenge.ID)
+	must(t, err)
+	if got.CreatorID != bigTelegramID || !got.HideFutureTasks || !got.ShuffleTasks || got.DailyTaskLimit != 3 || got.TemplateVersion != 7 {
+		t.Errorf("GetByID() = %+v", got)
+	}
+	if got.CreatedAt.IsZero() {
+		t.Error("CreatedAt should round-trip")
+	}
+
+	got.Name = "Renamed"
+	got.HideFutureTasks = false
+	must(t, repo.Challenge().Update(got))
+	got, _ = repo.Challenge().GetByID(challenge.ID)
+	if got.Name != "Renamed" || got.HideFutureTasks {
+		t.Errorf("after Update() = %+v", got)
+	}
+
+	// Listed for the creator and for participants, once each
+	other := createChallenge(t, repo, "def67890")
+	createParticipant(t, repo, other.ID, bigTelegramID, "🔥")
+	createParticipant(t, repo, challenge.ID, bigTelegramID, "🔥")
+	mine, err := repo.Challenge().GetByUserID(bigTelegramID)
+	must(t, err)
+	if len(mine) != 2 {
+		t.Errorf("GetByUserID() returned %d challenges, want 2", len(mine))
+	}
+
+	exists, err := repo.Challenge().Exists(challenge.ID)
+	must(t, err)
+	if !exists {
+		t.Error("Exists() = false, want true")
+	}
+}
+
+func testTasks(t *testing.T, repo repository.Repository) {
+	challenge := createChallenge(t, repo, "tasks123")
+	tasks := createTasks(t, repo, challenge.ID, 3)
+	if tasks[0].ID == 0 || tasks[0].ID == tasks[1].ID {
+		t.Fatalf("CreateBatch() assigned IDs %d, %d", tasks[0].ID, tasks[1].ID)
+	}
+
+	single := &domain.Task{ChallengeID: challenge.ID, OrderNum: 4, Title: "Task 4", TemplateTaskID: 42}
+	must(t, repo.Task().Create(single))
+	if single.ID <= tasks[2].ID {
+		t.Errorf("Create() ID = %d, want > %d", single.ID, tasks[2].ID)
+	}
+
+	maxOrder, err := repo.Task().GetMaxOrderNum(challenge.ID)
+	must(t, err)
+	if maxOrder != 4 {
+		t.Errorf("GetMaxOrderNum() = %d, want 4", maxOrder)
+	}
+	if n, _ := repo.Task().GetMaxOrderNum("empty123"); n != 0 {
+		t.Errorf("GetMaxOrderNum() of empty challenge = %d, want 0", n)
+	}
+
+	// Swapping order numbers must not trip the unique constraint
+	must(t, repo.Task().UpdateOrderNums(challenge.ID, map[int64]int{tasks[0].ID: 2, tasks[1].ID: 1}))
+	list, err := repo.Task().GetByChallengeID(challenge.ID)
+	must(t, err)
+	if list[0].ID != tasks[1].ID || list[1].ID != tasks[0].ID || list[3].TemplateTaskID != 42 {
+		t.Errorf("order after swap = %d, %d", list[0].ID, list[1].ID)
+	}
+
+	tasks[2].Title = "Edited"
+	created := []*domain.Task{{ChallengeID: challenge.ID, OrderNum: 5, Title: "Task 5"}}
+	must(t, repo.Task().ApplyBatch([]*domain.Task{tasks[2]}, created))
+	if created[0].ID == 0 {
+		t.Error("ApplyBatch() should assign IDs to created tasks")
+	}
+	got, _ := repo.Task().GetByID(tasks[2].ID)
+	if got.Title != "Edited" {
+		t.Errorf("Title after ApplyBatch() = %q", got.Title)
+	}
+
+	must(t, repo.Task().Delete(single.ID))
+	if got, err := repo.Task().GetByID(single.ID); err != nil || got != nil {
+		t.Errorf("GetByID() after Delete() = %v, %v", got, err)
+	}
+	if n, _ := repo.Task().CountByChallengeID(challenge.ID); n != 4 {
+		t.Errorf("CountByChallengeID() = %d, want 4", n)
+	}
+}
+
+func testParticipants(t *testing.T, repo repository.Repository) {
+	challenge := createChallenge(t, repo, "parts123")
+	first := createParticipant(t, repo, challenge.ID, bigTelegramID, "🔥")
+	createParticipant(t, repo, challenge.ID, 2, "🚀")
+
+	// One membership per user and challenge
+	if err := repo.Participant().Create(&domain.Participant{ChallengeID: challenge.ID, TelegramID: bigTelegramID, DisplayName: "Dup", Emoji: "⭐"}); err == nil {
+		t.Error("Create() of a duplicate participant should fail")
+	}
+
+	got, err := repo.Participant().GetByChallengeAndUser(challenge.ID, bigTelegramID)
+	must(t, err)
+	if got == nil || got.ID != first.ID || !got.NotifyEnabled {
+		t.Fatalf("GetByChallengeAndUser() = %+v", got)
+	}
+	if got, _ := repo.Participant().GetByChallengeAndUser(challenge.ID, 999); got != nil {
+		t.Error("GetByChallengeAndUser() for a stranger should be nil")
+	}
+
+	got.NotifyEnabled = false
+	got.DisplayName = "Renamed"
+	must(t, repo.Participant().Update(got))
+	must(t, repo.Participant().UpdateTimeOffset(got.ID, -90))
+	got, _ = repo.Participant().GetByID(first.ID)
+	if got.NotifyEnabled || got.DisplayName != "Renamed" || got.TimeOffsetMinutes != -90 {
+		t.Errorf("after updates = %+v", got)
+	}
+
+	must(t, repo.WithTx(func(tx repository.Repository) error {
+		locked, err := tx.Participant().GetByIDForUpdate(first.ID)
+		if err != nil {
+			return err
+		}
+		if locked == nil || locked.DisplayName != "Renamed" {
+			t.Errorf("GetByIDForUpdate() = %+v", locked)
+		}
+		if missing, err := tx.Participant().GetByIDForUpdate(-1); err != nil || missing != nil {
+			t.Errorf("GetByIDForUpdate(missing) = %v, %v, want nil, nil", missing, err)
+		}
+		return nil
+	}))
+
+	emojis, err := repo.Participant().GetUsedEmojis(challenge.ID)
+	must(t, err)
+	if len(emojis) != 2 {
+		t.Errorf("GetUsedEmojis() = %v", emojis)
+	}
+	list, _ := repo.Participant().GetByChallengeID(challenge.ID)
+	if len(list) != 2 || list[0].ID != first.ID {
+		t.Errorf("GetByChallengeID() should list participants in join order")
+	}
+}
+
+func testCompletions(t *testing.T, repo repository.Repository) {
+	challenge := createChallenge(t, repo, "compl123")
+	tasks := createTasks(t, repo, challenge.ID, 2)
+	p := createParticipant(t, repo, challenge.ID, 1, "🔥")
+
+	before := time.Now().Add(-time.Minute)
+	completion := &domain.TaskCompletion{TaskID: tasks[0].ID, ParticipantID: p.ID}
+	must(t, repo.Completion().Create(completion))
+	if completion.ID == 0 {
+		t.Error("Create() should assign an ID")
+	}
+	if err := repo.Completion().Create(&domain.TaskCompletion{TaskID: tasks[0].ID, ParticipantID: p.ID}); err == nil {
+		t.Error("completing a task twice should fail")
+	}
+
+	if n, _ := repo.Completion().CountCompletionsInRange(p.ID, before, time.Now().Add(time.Minute)); n != 1 {
+		t.Errorf("CountCompletionsInRange() = %d, want 1", n)
+	}
+	if n, _ := repo.Completion().CountCompletionsInRange(p.ID, time.Now().Add(time.Minute), time.Now().Add(time.Hour)); n != 0 {
+		t.Errorf("CountCompletionsInRange() in the future = %d, want 0", n)
+	}
+
+	// Global counts, with an idle challenge that must not count as active
+	createChallenge(t, repo, "idle1234")
+	if n, _ := repo.Completion().CountSince(before); n != 1 {
+		t.Errorf("CountSince() = %d, want 1", n)
+	}
+	if n, _ := repo.Completion().CountSince(time.Now().Add(time.Minute)); n != 0 {
+		t.Errorf("CountSince() in the future = %d, want 0", n)
+	}
+	if n, _ := repo.Challenge().CountActive(before); n != 1 {
+		t.Errorf("CountActive() = %d, want 1", n)
+	}
+	if n, _ := repo.Participant().Count(); n != 1 {
+		t.Errorf("Participant().Count() = %d, want 1", n)
+	}
+
+	got, err := repo.Completion().GetByTaskAndParticipant(tasks[0].ID, p.ID)
+	must(t, err)
+	if got == nil || got.CompletedAt.Before(before) {
+		t.Errorf("GetByTaskAndParticipant() = %+v", got)
+	}
+	ids, _ := repo.Completion().GetCompletedTaskIDs(p.ID)
+	if len(ids) != 1 || ids[0] != tasks[0].ID {
+		t.Errorf("GetCompletedTaskIDs() = %v", ids)
+	}
+
+	must(t, repo.Completion().Delete(tasks[0].ID, p.ID))
+	if got, _ := repo.Completion().GetByTaskAndParticipant(tasks[0].ID, p.ID); got != nil {
+		t.Error("completion should be gone after Delete()")
+	}
+}
+
+func testStates(t *testing.T, repo repository.Repository) {
+	state, err := repo.State().Get(bigTelegramID)
+	must(t, err)
+	if state.State != domain.StateIdle {
+		t.Errorf("default state = %q, want idle", state.State)
+	}
+
+	must(t, repo.State().Set(&domain.UserState{TelegramID: bigTelegramID, State: "awaiting_name", TempData: `{"a":1}`, CurrentChallenge: "abc12345"}))
+	// Upsert
+	must(t, repo.State().Set(&domain.UserState{TelegramID: bigTelegramID, State: "awaiting_emoji", TempData: `{"b":2}`, CurrentChallenge: "abc12345"}))
+	state, _ = repo.State().Get(bigTelegramID)
+	if state.State != "awaiting_emoji" || state.TempData != `{"b":2}` {
+		t.Errorf("state after upsert = %+v", state)
+	}
+
+	must(t, repo.State().Reset(bigTelegramID))
+	state, _ = repo.State().Get(bigTelegramID)
+	if state.State != domain.StateIdle || state.TempData != "" || state.CurrentChallenge != "abc12345" {
+		t.Errorf("state after Reset() = %+v", state)
+	}
+
+	must(t, repo.State().ResetByChallenge("abc12345"))
+	state, _ = repo.State().Get(bigTelegramID)
+	if state.CurrentChallenge != "" {
+		t.Errorf("CurrentChallenge after ResetByChallenge() = %q", state.CurrentChallenge)
+	}
+}
+
+func testSuperAdmins(t *testing.T, repo repository.Repository) {
+	must(t, repo.SuperAdmin().Create(bigTelegramID))
+	// Adding twice is a no-op
+	must(t, repo.SuperAdmin().Create(bigTelegramID))
+
+	admins, err := repo.SuperAdmin().GetAll()
+	must(t, err)
+	if len(admins) != 1 || admins[0].TelegramID != bigTelegramID {
+		t.Errorf("GetAll() = %+v", admins)
+	}
+
+	must(t, repo.SuperAdmin().Delete(bigTelegramID))
+	if ok, _ := repo.SuperAdmin().IsSuperAdmin(bigTelegramID); ok {
+		t.Error("IsSuperAdmin() after Delete() = true")
+	}
+}
+
+func testTemplates(t *testing.T, repo repository.Repository) {
+	global := &domain.Template{Name: "Global", HideFutureTasks: true, DailyTaskLimit: 2}
+	personal := &domain.Template{Name: "Mine", OwnerID: bigTelegramID}
+	must(t, repo.Template().Create(global))
+	must(t, repo.Template().Create(personal))
+
+	if got, err := repo.Template().GetByID(99999); err != nil || got != nil {
+		t.Errorf("GetByID(missing) = %v, %v", got, err)
+	}
+	got, _ := repo.Template().GetByID(global.ID)
+	if !got.HideFutureTasks || got.DailyTaskLimit != 2 {
+		t.Errorf("GetByID() = %+v", got)
+	}
+
+	if list, _ := repo.Template().GetGlobal(); len(list) != 1 || list[0].ID != global.ID {
+		t.Errorf("GetGlobal() = %+v", list)
+	}
+	if n, _ := repo.Template().CountByOwner(bigTelegramID); n != 1 {
+		t.Errorf("CountByOwner() = %d, want 1", n)
+	}
+	if ok, _ := repo.Template().ExistsByName("Mine", bigTelegramID); !ok {
+		t.Error("ExistsByName() = false for the owner's template")
+	}
+
+	// Share codes are unique, but many templates can have none
+	must(t, repo.Template().UpdateShareCode(global.ID, "code1234"))
+	if err := repo.Template().UpdateShareCode(personal.ID, "code1234"); err == nil {
+		t.Error("UpdateShareCode() with a taken code should fail")
+	}
+	other := &domain.Template{Name: "Other"}
+	must(t, repo.Template().Create(other))
+	if got, _ := repo.Template().GetByShareCode(""); got != nil {
+		t.Error("GetByShareCode(\"\") should not match unshared templates")
+	}
+	if got, _ := repo.Template().GetByShareCode("code1234"); got == nil || got.ID != global.ID {
+		t.Errorf("GetByShareCode() = %+v", got)
+	}
+
+	tasks := []*domain.TemplateTask{
+		{TemplateID: global.ID, OrderNum: 1, Title: "One"},
+		{TemplateID: global.ID, OrderNum: 2, Title: "Two"},
+	}
+	must(t, repo.TemplateTask().CreateBatch(tasks))
+	must(t, repo.TemplateTask().UpdateOrderNums(global.ID, map[int64]int{tasks[0].ID: 2, tasks[1].ID: 1}))
+	must(t, repo.TemplateTask().UpdateImage(tasks[0].ID, "file-id"))
+	list, _ := repo.TemplateTask().GetByTemplateID(global.ID)
+	if len(list) != 2 || list[1].ID != tasks[0].ID || list[1].ImageFileID != "file-id" {
+		t.Errorf("template tasks after swap = %+v", list)
+	}
+	if n, _ := repo.TemplateTask().GetMaxOrderNum(global.ID); n != 2 {
+		t.Errorf("GetMaxOrderNum() = %d, want 2", n)
+	}
+
+	must(t, repo.Template().Delete(global.ID))
+	if n, _ := repo.TemplateTask().CountByTemplateID(global.ID); n != 0 {
+		t.Errorf("template tasks left after Delete() = %d", n)
+	}
+}
+
+func testTemplateSearch(t *testing.T, repo repository.Repository) {
+	yoga := &domain.Template{Name: "Morning Yoga"}
+	reading := &domain.Template{Name: "Reading", Description: "A book a week"}
+	percent := &domain.Template{Name: "100% Focus"}
+	personal := &domain.Template{Name: "Morning Pages", OwnerID: bigTelegramID}
+	for _, tpl := range []*domain.Template{yoga, reading, percent, personal} {
+		must(t, repo.Template().Create(tpl))
+	}
+	category := &domain.TemplateCategory{Name: "Health"}
+	must(t, repo.TemplateCategory().Create(category))
+	must(t, repo.Template().UpdateCategory(yoga.ID, category.ID))
+	must(t, repo.Template().UpdateTags(yoga.ID, "fitness,morning"))
+	must(t, repo.Template().IncrementUseCount(reading.ID))
+
+	tests := []struct {
+		name   string
+		filter domain.TemplateFilter
+		want   []int64
+	}{
+		{"newest first", domain.TemplateFilter{}, []int64{percent.ID, reading.ID, yoga.ID}},
+		{"popular first", domain.TemplateFilter{Sort: domain.TemplateSortPopular}, []int64{reading.ID, percent.ID, yoga.ID}},
+		{"category", domain.TemplateFilter{CategoryID: category.ID}, []int64{yoga.ID}},
+		{"case-insensitive", domain.TemplateFilter{Query: "MORNING"}, []int64{yoga.ID}},
+		{"description", domain.TemplateFilter{Query: "book"}, []int64{reading.ID}},
+		{"tags with category", domain.TemplateFilter{Query: "fitness", CategoryID: category.ID}, []int64{yoga.ID}},
+		{"wildcards are literal", domain.TemplateFilter{Query: "%"}, []int64{percent.ID}},
+	}
+	for _, tt := range tests {
+		got, err := repo.Template().Find(tt.filter, 10, 0)
+		must(t, err)
+		if fmt.Sprint(templateIDs(got)) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: Find() = %v, want %v", tt.name, templateIDs(got), tt.want)
+		}
+		if n, _ := repo.Template().CountFiltered(tt.filter); n != len(tt.want) {
+			t.Errorf("%s: CountFiltered() = %d, want %d", tt.name, n, len(tt.want))
+		}
+	}
+
+	page, _ := repo.Template().Find(domain.TemplateFilter{}, 1, 1)
+	if len(page) != 1 || page[0].ID != reading.ID {
+		t.Errorf("Find() second page = %v, want [%d]", templateIDs(page), reading.ID)
+	}
+
+	counts, err := repo.Template().CountByCategory()
+	must(t, err)
+	if counts[category.ID] != 1 || counts[0] != 2 {
+		t.Errorf("CountByCategory() = %v", counts)
+	}
+}
+
+func templateIDs(templates []*domain.Template) []int64 {
+	ids := make([]int64, len(templates))
+	for i, tpl := range templates {
+		ids[i] = tpl.ID
+	}
+	return ids
+}
+
+func testTemplateCategories(t *testing.T, repo repository.Repository) {
+	category := &domain.TemplateCategory{Name: "Health"}
+	must(t, repo.TemplateCategory().Create(category))
+	if err := repo.TemplateCategory().Create(&domain.TemplateCategory{Name: "Health"}); err == nil {
+		t.Error("Create() of a duplicate category name should fail")
+	}
+
+	tpl := &domain.Template{Name: "Yoga"}
+	must(t, repo.Template().Create(tpl))
+	must(t, repo.Template().UpdateCategory(tpl.ID, category.ID))
+
+	must(t, repo.TemplateCategory().Delete(category.ID))
+	if got, _ := repo.TemplateCategory().GetByID(category.ID); got != nil {
+		t.Error("category should be gone after Delete()")
+	}
+	got, _ := repo.Template().GetByID(tpl.ID)
+	if got.CategoryID != 0 {
+		t.Errorf("CategoryID after category Delete() = %d, want 0", got.CategoryID)
+	}
+}
+
+func testTemplateVersions(t *testing.T, repo repository.Repository) {
+	tpl := &domain.Template{Name: "Versioned"}
+	must(t, repo.Template().Create(tpl))
+
+	if got, err := repo.TemplateVersion().GetLatest(tpl.ID); err != nil || got != nil {
+		t.Errorf("GetLatest() without versions = %v, %v", got, err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		version := &domain.TemplateVersion{TemplateID: tpl.ID, EditorID: bigTelegramID, Name: tpl.Name, HideFutureTasks: i == 3}
+		tasks := []*domain.TemplateVersionTask{{TemplateTaskID: int64(i), OrderNum: 1, Title: "Task"}}
+		must(t, repo.TemplateVersion().Create(version, tasks))
+		if version.Version != i || version.ID == 0 || tasks[0].VersionID != version.ID {
+			t.Fatalf("Create() #%d assigned version %d, id %d", i, version.Version, version.ID)
+		}
+	}
+
+	latest, err := repo.TemplateVersion().GetLatest(tpl.ID)
+	must(t, err)
+	if latest.Version != 3 || !latest.HideFutureTasks || latest.EditorID != bigTelegramID {
+		t.Errorf("GetLatest() = %+v", latest)
+	}
+	second, err := repo.TemplateVersion().GetByVersion(tpl.ID, 2)
+	must(t, err)
+	if second == nil || second.Version != 2 || second.HideFutureTasks {
+		t.Errorf("GetByVersion(2) = %+v", second)
+	}
+	if got, err := repo.TemplateVersion().GetByVersion(tpl.ID, 4); err != nil || got != nil {
+		t.Errorf("GetByVersion(4) = %v, %v, want nil", got, err)
+	}
+	page, _ := repo.TemplateVersion().GetByTemplateID(tpl.ID, 2, 1)
+	if len(page) != 2 || page[0].Version != 2 || page[1].Version != 1 {
+		t.Errorf("GetByTemplateID() second page = %+v", page)
+	}
+	tasks, _ := repo.TemplateVersion().GetTasks(latest.ID)
+	if len(tasks) != 1 || tasks[0].TemplateTaskID != 3 {
+		t.Errorf("GetTasks() = %+v", tasks)
+	}
+}
+
+func testTemplateRestore(t *testing.T, repo repository.Repository) {
+	tpl := &domain.Template{Name: "Original"}
+	must(t, repo.Template().Create(tpl))
+	tasks := []*domain.TemplateTask{
+		{TemplateID: tpl.ID, OrderNum: 1, Title: "One"},
+		{TemplateID: tpl.ID, OrderNum: 2, Title: "Two"},
+	}
+	must(t, repo.TemplateTask().CreateBatch(tasks))
+	snapshot := []*domain.TemplateTask{
+		{ID: tasks[1].ID, OrderNum: 1, Title: "Two"},
+		{ID: tasks[0].ID, OrderNum: 2, Title: "One"},
+	}
+
+	// Delete a task and add another, then restore the snapshot
+	must(t, repo.TemplateTask().Delete(tasks[0].ID))
+	extra := &domain.TemplateTask{TemplateID: tpl.ID, OrderNum: 3, Title: "Extra"}
+	must(t, repo.TemplateTask().Create(extra))
+	must(t, repo.Template().UpdateName(tpl.ID, "Renamed"))
+
+	must(t, repo.Template().Restore(&domain.Template{ID: tpl.ID, Name: "Original"}, snapshot))
+
+	got, _ := repo.Template().GetByID(tpl.ID)
+	if got.Name != "Original" {
+		t.Errorf("Name after Restore() = %q", got.Name)
+	}
+	list, _ := repo.TemplateTask().GetByTemplateID(tpl.ID)
+	if len(list) != 2 || list[0].ID != tasks[1].ID || list[1].ID != tasks[0].ID || list[1].Title != "One" {
+		t.Errorf("tasks after Restore() = %+v", list)
+	}
+
+	// New tasks after a restore still get fresh IDs
+	next := &domain.TemplateTask{TemplateID: tpl.ID, OrderNum: 4, Title: "Next"}
+	must(t, repo.TemplateTask().Create(next))
+	if next.ID <= extra.ID {
+		t.Errorf("new task ID = %d, want > %d", next.ID, extra.ID)
+	}
+}
+
+func testSocial(t *testing.T, repo repository.Repository) {
+	challenge := createChallenge(t, repo, "social12")
+	tasks := createTasks(t, repo, challenge.ID, 1)
+	alice := createParticipant(t, repo, challenge.ID, 1, "🔥")
+	bob := createParticipant(t, repo, challenge.ID, 2, "🚀")
+
+	for i := 0; i < 3; i++ {
+		must(t, repo.Comment().Create(&domain.TaskComment{TaskID: tasks[0].ID, ParticipantID: alice.ID, Text: fmt.Sprintf("c%d", i)}))
+	}
+	comments, err := repo.Comment().GetByTaskID(tasks[0].ID, 2, 0)
+	must(t, err)
+	if len(comments) != 2 || comments[0].Text != "c2" {
+		t.Errorf("GetByTaskID() newest first = %+v", comments)
+	}
+	if n, _ := repo.Comment().CountByTaskID(tasks[0].ID); n != 3 {
+		t.Errorf("CountByTaskID() = %d, want 3", n)
+	}
+
+	must(t, repo.Kudos().Create(&domain.Kudos{TaskID: tasks[0].ID, FromParticipantID: alice.ID, ToParticipantID: bob.ID, Emoji: "👏"}))
+	if err := repo.Kudos().Create(&domain.Kudos{TaskID: tasks[0].ID, FromParticipantID: alice.ID, ToParticipantID: bob.ID, Emoji: "🔥"}); err == nil {
+		t.Error("giving kudos twice for the same task should fail")
+	}
+	if ok, _ := repo.Kudos().Exists(tasks[0].ID, alice.ID, bob.ID); !ok {
+		t.Error("Kudos().Exists() = false")
+	}
+	counts, _ := repo.Kudos().CountByChallengeID(challenge.ID)
+	if counts[bob.ID] != 1 || counts[alice.ID] != 0 {
+		t.Errorf("Kudos().CountByChallengeID() = %v", counts)
+	}
+
+	if got, err := repo.Nudge().GetLast(alice.ID, bob.ID); err != nil || got != nil {
+		t.Errorf("Nudge().GetLast() without nudges = %v, %v", got, err)
+	}
+	first := &domain.Nudge{FromParticipantID: alice.ID, ToParticipantID: bob.ID}
+	second := &domain.Nudge{FromParticipantID: alice.ID, ToParticipantID: bob.ID}
+	must(t, repo.Nudge().Create(first))
+	must(t, repo.Nudge().Create(second))
+	if got, _ := repo.Nudge().GetLast(alice.ID, bob.ID); got == nil || got.ID != second.ID {
+		t.Errorf("Nudge().GetLast() = %+v, want #%d", got, second.ID)
+	}
+}
+
+func testAchievements(t *testing.T, repo repository.Repository) {
+	a := &domain.Achievement{TelegramID: bigTelegramID, ChallengeID: "ach12345", Code: "first_task"}
+	created, err := repo.Achievement().Create(a)
+	must(t, err)
+	if !created || a.ID == 0 {
+		t.Errorf("Create() = %v, id %d", created, a.ID)
+	}
+
+	created, err = repo.Achievement().Create(&domain.Achievement{TelegramID: bigTelegramID, ChallengeID: "ach12345", Code: "first_task"})
+	must(t, err)
+	if created {
+		t.Error("Create() of an awarded badge should return false")
+	}
+
+	codes, _ := repo.Achievement().GetCodesByChallengeAndUser("ach12345", bigTelegramID)
+	if len(codes) != 1 {
+		t.Errorf("GetCodesByChallengeAndUser() = %v", codes)
+	}
+	if list, _ := repo.Achievement().GetByUser(bigTelegramID); len(list) != 1 {
+		t.Errorf("GetByUser() = %+v", list)
+	}
+}
+
+func testTaskTrash(t *testing.T, repo repository.Repository) {
+	challenge := createChallenge(t, repo, "trash123")
+	tasks := createTasks(t, repo, challenge.ID, 3)
+	p := createParticipant(t, repo, challenge.ID, 1, "🔥")
+	must(t, repo.Completion().Create(&domain.TaskCompletion{TaskID: tasks[0].ID, ParticipantID: p.ID}))
+
+	must(t, repo.Task().Delete(tasks[0].ID))
+
+	if got, _ := repo.Task().GetByID(tasks[0].ID); got != nil {
+		t.Error("GetByID() should skip a trashed task")
+	}
+	if n, _ := repo.Task().CountByChallengeID(challenge.ID); n != 2 {
+		t.Errorf("CountByChallengeID() = %d, want 2", n)
+	}
+	if maxOrder, _ := repo.Task().GetMaxOrderNum(challenge.ID); maxOrder != 3 {
+		t.Errorf("GetMaxOrderNum() = %d, want 3", maxOrder)
+	}
+	if n, _ := repo.Completion().CountByParticipantID(p.ID); n != 0 {
+		t.Errorf("CountByParticipantID() = %d, want completions of trashed tasks skipped", n)
+	}
+
+	// The freed position can be reused and reordering doesn't clash with the trashed task
+	must(t, repo.Task().UpdateOrderNums(challenge.ID, map[int64]int{tasks[1].ID: 1, tasks[2].ID: 2}))
+
+	trashed, err := repo.Task().GetDeletedByChallengeID(challenge.ID)
+	must(t, err)
+	if len(trashed) != 1 || trashed[0].ID != tasks[0].ID || trashed[0].DeletedAt == nil {
+		t.Fatalf("GetDeletedByChallengeID() = %+v, want the trashed task", trashed)
+	}
+	if got, _ := repo.Task().GetDeletedByID(tasks[1].ID); got != nil {
+		t.Error("GetDeletedByID() should skip a task that isn't trashed")
+	}
+
+	must(t, repo.Task().Restore(tasks[0].ID, 3))
+	got, _ := repo.Task().GetByID(tasks[0].ID)
+	if got == nil || got.OrderNum != 3 || got.DeletedAt != nil {
+		t.Errorf("GetByID() after Restore() = %+v, want task at position 3", got)
+	}
+	if n, _ := repo.Completion().CountByParticipantID(p.ID); n != 1 {
+		t.Errorf("CountByParticipantID() after Restore() = %d, want 1", n)
+	}
+}
+
+func testCascadePurge(t *testing.T, repo repository.Repository) {
+	challenge := createChallenge(t, repo, "casc1234")
+	tasks := createTasks(t, repo, challenge.ID, 1)
+	p := createParticipant(t, repo, challenge.ID, 1, "🔥")
+	must(t, repo.Completion().Create(&domain.TaskCompletion{TaskID: tasks[0].ID, ParticipantID: p.ID}))
+	must(t, repo.Comment().Create(&domain.TaskComment{TaskID: tasks[0].ID, ParticipantID: p.ID, Text: "hi"}))
+
+	must(t, repo.Challenge().Delete(challenge.ID))
+
+	if got, _ := repo.Challenge().GetByID(challenge.ID); got != nil {
+		t.Error("GetByID() should skip a trashed challenge")
+	}
+	if exists, _ := repo.Challenge().Exists(challenge.ID); !exists {
+		t.Error("Exists() should include a trashed challenge")
+	}
+	if trashed, _ := repo.Challenge().GetDeleted(); len(trashed) != 1 || trashed[0].ID != challenge.ID {
+		t.Errorf("GetDeleted() = %+v, want the trashed challenge", trashed)
+	}
+	if n, _ := repo.Completion().CountByParticipantID(p.ID); n != 1 {
+		t.Errorf("completions of a trashed challenge = %d, want 1", n)
+	}
+
+	// Purging only removes what was trashed before the cutoff
+	purged, err := repo.Challenge().PurgeDeleted(time.Now().Add(-time.Hour))
+	must(t, err)
+	if purged != 0 {
+		t.Errorf("PurgeDeleted() before the delete = %d, want 0", purged)
+	}
+	purged, err = repo.Challenge().PurgeDeleted(time.Now().Add(time.Minute))
+	must(t, err)
+	if purged != 1 {
+		t.Errorf("PurgeDeleted() = %d, want 1", purged)
+	}
+
+	if got, _ := repo.Challenge().GetDeletedByID(challenge.ID); got != nil {
+		t.Error("purged challenge should be gone")
+	}
+	if got, _ := repo.Participant().GetByID(p.ID); got != nil {
+		t.Error("participant should be purged with the challenge")
+	}
+	if got, _ := repo.Completion().GetByTaskAndParticipant(tasks[0].ID, p.ID); got != nil {
+		t.Error("completion should be purged with the challenge")
+	}
+	if n, _ := repo.Comment().CountByTaskID(tasks[0].ID); n != 0 {
+		t.Errorf("comments left = %d", n)
+	}
+}
+
+func testTransactions(t *testing.T, repo repository.Repository) {
+	// Committed work is visible afterwards, including a repo-level batch joined into the transaction
+	must(t, repo.WithTx(func(tx repository.Repository) error {
+		createChallenge(t, tx, "txok1234")
+		createTasks(t, tx, "txok1234", 2)
+		return nil
+	}))
+	if n, _ := repo.Task().CountByChallengeID("txok1234"); n != 2 {
+		t.Errorf("tasks after commit = %d, want 2", n)
+	}
+
+	// A failure anywhere rolls back everything, nested WithTx included
+	errBoom := errors.New("boom")
+	err := repo.WithTx(func(tx repository.Repository) error {
+		createChallenge(t, tx, "txno1234")
+		must(t, tx.WithTx(func(inner repository.Repository) error {
+			createTasks(t, inner, "txno1234", 1)
+			return nil
+		}))
+		return errBoom
+	})
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("WithTx() error = %v, want %v", err, errBoom)
+	}
+	if got, _ := repo.Challenge().GetByID("txno1234"); got != nil {
+		t.Error("challenge created in a rolled back transaction should not exist")
+	}
+	if n, _ := repo.Task().CountByChallengeID("txno1234"); n != 0 {
+		t.Errorf("tasks after rollback = %d, want 0", n)
+	}
+
+	// A failing statement surfaces its error and leaves nothing behind
+	err = repo.WithTx(func(tx repository.Repository) error {
+		createChallenge(t, tx, "txdup123")
+		return tx.Task().CreateBatch([]*domain.Task{
+			{ChallengeID: "txdup123", OrderNum: 1, Title: "A"},
+			{ChallengeID: "txdup123", OrderNum: 1, Title: "B"},
+		})
+	})
+	if err == nil {
+		t.Fatal("WithTx() should fail on a duplicate order number")
+	}
+	if got, _ := repo.Challenge().GetByID("txdup123"); got != nil {
+		t.Error("challenge should be rolled back with its failed tasks")
+	}
+}
+
+func testAudit(t *testing.T, repo repository.Repository) {
+	for i := 1; i <= 3; i++ {
+		must(t, repo.Audit().Create(&domain.AuditEntry{
+			ActorID:     bigTelegramID,
+			Action:      domain.AuditChallengeRename,
+			ChallengeID: "audit123",
+			Before:      fmt.Sprintf("Name %d", i-1),
+			After:       fmt.Sprintf("Name %d", i),
+			Observer:    true,
+		}))
+	}
+	grant := &domain.AuditEntry{ActorID: bigTelegramID, Action: domain.AuditSuperAdminGrant, TargetUserID: bigTelegramID + 1}
+	must(t, repo.Audit().Create(grant))
+	if grant.ID == 0 || grant.CreatedAt.IsZero() {
+		t.Errorf("Create() should set ID and CreatedAt, got %+v", grant)
+	}
+
+	entries, err := repo.Audit().GetByChallengeID("audit123", 2, 0)
+	must(t, err)
+	if len(entries) != 2 || entries[0].After != "Name 3" || entries[1].After != "Name 2" {
+		t.Fatalf("GetByChallengeID() first page = %+v, want newest two entries", entries)
+	}
+	if e := entries[0]; e.ActorID != bigTelegramID || e.Before != "Name 2" || !e.Observer {
+		t.Errorf("entry = %+v, want actor, before value and observer flag round-tripped", e)
+	}
+	entries, err = repo.Audit().GetByChallengeID("audit123", 2, 2)
+	must(t, err)
+	if len(entries) != 1 || entries[0].After != "Name 1" {
+		t.Errorf("GetByChallengeID() second page = %+v, want the oldest entry", entries)
+	}
+	if n, _ := repo.Audit().CountByChallengeID("audit123"); n != 3 {
+		t.Errorf("CountByChallengeID() = %d, want 3", n)
+	}
+
+	all, err := repo.Audit().GetAll(10, 0)
+	must(t, err)
+	if len(all) != 4 || all[0].Action != domain.AuditSuperAdminGrant || all[0].TargetUserID != bigTelegramID+1 {
+		t.Errorf("GetAll() = %+v, want all 4 entries newest first", all)
+	}
+	if n, _ := repo.Audit().Count(); n != 4 {
+		t.Errorf("Count() = %d, want 4", n)
+	}
+}
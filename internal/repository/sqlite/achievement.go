@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// AchievementRepo implements AchievementRepository for SQLite
+type AchievementRepo struct {
+	db dbtx
+}
+
+func (r *AchievementRepo) Create(achievement *domain.Achievement) (bool, error) {
+	achievement.AwardedAt = time.Now().UTC()
+
+	result, err := r.db.NamedExec(`
+		INSERT OR IGNORE INTO achievements (telegram_id, challenge_id, code, awarded_at)
+		VALUES (:telegram_id, :challenge_id, :code, :awarded_at)
+	`, achievement)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return false, err
+	}
+	achievement.ID = id
+	return true, nil
+}
+
+func (r *AchievementRepo) GetByChallengeAndUser(challengeID string, telegramID int64) ([]*domain.Achievement, error) {
+	var achievements []*domain.Achievement
+	err := r.db.Select(&achievements, `
+		SELECT * FROM achievements
+		WHERE challenge_id = ? AND telegram_id = ?
+		ORDER BY awarded_at ASC, id ASC
+	`, challengeID, telegramID)
+	return achievements, err
+}
+
+func (r *AchievementRepo) GetByUser(telegramID int64) ([]*domain.Achievement, error) {
+	var achievements []*domain.Achievement
+	err := r.db.Select(&achievements, `
+		SELECT * FROM achievements
+		WHERE telegram_id = ?
+		ORDER BY awarded_at ASC, id ASC
+	`, telegramID)
+	return achievements, err
+}
+
+func (r *AchievementRepo) GetCodesByChallengeAndUser(challengeID string, telegramID int64) ([]string, error) {
+	var codes []string
+	err := r.db.Select(&codes, `
+		SELECT code FROM achievements WHERE challenge_id = ? AND telegram_id = ?
+	`, challengeID, telegramID)
+	return codes, err
+}
@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestAchievementRepo_Create(t *testing.T) {
+	repo := setupTestDB(t)
+
+	achievement := &domain.Achievement{TelegramID: 12345, ChallengeID: "TEST1234", Code: domain.AchievementFirstTask}
+	created, err := repo.Achievement().Create(achievement)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !created || achievement.ID == 0 {
+		t.Error("Create() should create the achievement and set its ID")
+	}
+
+	// Same badge in the same challenge is ignored
+	dup := &domain.Achievement{TelegramID: 12345, ChallengeID: "TEST1234", Code: domain.AchievementFirstTask}
+	created, err = repo.Achievement().Create(dup)
+	if err != nil {
+		t.Fatalf("Create() duplicate error = %v", err)
+	}
+	if created {
+		t.Error("Create() duplicate should return false")
+	}
+
+	// Same badge in another challenge counts separately
+	other := &domain.Achievement{TelegramID: 12345, ChallengeID: "OTHER123", Code: domain.AchievementFirstTask}
+	repo.Achievement().Create(other)
+
+	codes, _ := repo.Achievement().GetCodesByChallengeAndUser("TEST1234", 12345)
+	if len(codes) != 1 {
+		t.Errorf("GetCodesByChallengeAndUser() count = %d, want 1", len(codes))
+	}
+
+	all, _ := repo.Achievement().GetByUser(12345)
+	if len(all) != 2 {
+		t.Errorf("GetByUser() count = %d, want 2", len(all))
+	}
+}
@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// AuditRepo implements AuditRepository for SQLite
+type AuditRepo struct {
+	db dbtx
+}
+
+func (r *AuditRepo) Create(entry *domain.AuditEntry) error {
+	entry.CreatedAt = time.Now()
+	result, err := r.db.NamedExec(`
+		INSERT INTO audit_log (actor_id, action, challenge_id, task_id, template_id, target_user_id, target_name, before_value, after_value, observer, created_at)
+		VALUES (:actor_id, :action, :challenge_id, :task_id, :template_id, :target_user_id, :target_name, :before_value, :after_value, :observer, :created_at)
+	`, entry)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	entry.ID = id
+	return nil
+}
+
+func (r *AuditRepo) GetByChallengeID(challengeID string, limit, offset int) ([]*domain.AuditEntry, error) {
+	var entries []*domain.AuditEntry
+	err := r.db.Select(&entries, `
+		SELECT * FROM audit_log
+		WHERE challenge_id = ?
+		ORDER BY id DESC
+		LIMIT ? OFFSET ?
+	`, challengeID, limit, offset)
+	return entries, err
+}
+
+func (r *AuditRepo) CountByChallengeID(challengeID string) (int, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM audit_log WHERE challenge_id = ?", challengeID)
+	return count, err
+}
+
+func (r *AuditRepo) GetAll(limit, offset int) ([]*domain.AuditEntry, error) {
+	var entries []*domain.AuditEntry
+	err := r.db.Select(&entries, `
+		SELECT * FROM audit_log
+		ORDER BY id DESC
+		LIMIT ? OFFSET ?
+	`, limit, offset)
+	return entries, err
+}
+
+func (r *AuditRepo) Count() (int, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM audit_log")
+	return count, err
+}
@@ -0,0 +1,26 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestAuditRepo_AppendOnly(t *testing.T) {
+	repo := setupTestDB(t)
+
+	entry := &domain.AuditEntry{ActorID: 12345, Action: domain.AuditChallengeDelete, ChallengeID: "TEST1234"}
+	if err := repo.Audit().Create(entry); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := repo.db.Exec("UPDATE audit_log SET actor_id = 1 WHERE id = ?", entry.ID); err == nil {
+		t.Error("updating an audit entry should fail")
+	}
+	if _, err := repo.db.Exec("DELETE FROM audit_log WHERE id = ?", entry.ID); err == nil {
+		t.Error("deleting an audit entry should fail")
+	}
+	if n, _ := repo.Audit().Count(); n != 1 {
+		t.Errorf("Count() = %d, want the entry to survive", n)
+	}
+}
@@ -4,13 +4,12 @@ import (
 	"database/sql"
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 )
 
 // ChallengeRepo implements ChallengeRepository for SQLite
 type ChallengeRepo struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func (r *ChallengeRepo) Create(challenge *domain.Challenge) error {
@@ -18,15 +17,15 @@ func (r *ChallengeRepo) Create(challenge *domain.Challenge) error {
 	challenge.UpdatedAt = time.Now()
 
 	_, err := r.db.NamedExec(`
-		INSERT INTO challenges (id, name, description, creator_id, daily_task_limit, hide_future_tasks, created_at, updated_at)
-		VALUES (:id, :name, :description, :creator_id, :daily_task_limit, :hide_future_tasks, :created_at, :updated_at)
+		INSERT INTO challenges (id, name, description, creator_id, daily_task_limit, hide_future_tasks, shuffle_tasks, template_id, template_version, created_at, updated_at)
+		VALUES (:id, :name, :description, :creator_id, :daily_task_limit, :hide_future_tasks, :shuffle_tasks, :template_id, :template_version, :created_at, :updated_at)
 	`, challenge)
 	return err
 }
 
 func (r *ChallengeRepo) GetByID(id string) (*domain.Challenge, error) {
 	var challenge domain.Challenge
-	err := r.db.Get(&challenge, "SELECT * FROM challenges WHERE id = ?", id)
+	err := r.db.Get(&challenge, "SELECT * FROM challenges WHERE id = ? AND deleted_at IS NULL", id)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -38,16 +37,27 @@ func (r *ChallengeRepo) GetByUserID(telegramID int64) ([]*domain.Challenge, erro
 	err := r.db.Select(&challenges, `
 		SELECT DISTINCT c.* FROM challenges c
 		LEFT JOIN participants p ON c.id = p.challenge_id
-		WHERE p.telegram_id = ? OR c.creator_id = ?
+		WHERE (p.telegram_id = ? OR c.creator_id = ?) AND c.deleted_at IS NULL
 		ORDER BY c.updated_at DESC
 	`, telegramID, telegramID)
 	return challenges, err
 }
 
+func (r *ChallengeRepo) GetByTemplateID(templateID int64) ([]*domain.Challenge, error) {
+	var challenges []*domain.Challenge
+	err := r.db.Select(&challenges, `
+		SELECT * FROM challenges
+		WHERE template_id = ? AND deleted_at IS NULL
+		ORDER BY created_at ASC
+	`, templateID)
+	return challenges, err
+}
+
 func (r *ChallengeRepo) GetAll() ([]*domain.Challenge, error) {
 	var challenges []*domain.Challenge
 	err := r.db.Select(&challenges, `
 		SELECT * FROM challenges
+		WHERE deleted_at IS NULL
 		ORDER BY updated_at DESC
 	`)
 	return challenges, err
@@ -57,7 +67,7 @@ func (r *ChallengeRepo) Update(challenge *domain.Challenge) error {
 	challenge.UpdatedAt = time.Now()
 	_, err := r.db.NamedExec(`
 		UPDATE challenges
-		SET name = :name, description = :description, daily_task_limit = :daily_task_limit, hide_future_tasks = :hide_future_tasks, updated_at = :updated_at
+		SET name = :name, description = :description, daily_task_limit = :daily_task_limit, hide_future_tasks = :hide_future_tasks, shuffle_tasks = :shuffle_tasks, updated_at = :updated_at
 		WHERE id = :id
 	`, challenge)
 	return err
@@ -81,8 +91,30 @@ func (r *ChallengeRepo) UpdateHideFutureTasks(id string, hide bool) error {
 	return err
 }
 
+func (r *ChallengeRepo) UpdateShuffleTasks(id string, shuffle bool) error {
+	_, err := r.db.Exec(`
+		UPDATE challenges
+		SET shuffle_tasks = ?, updated_at = ?
+		WHERE id = ?
+	`, shuffle, time.Now(), id)
+	return err
+}
+
+func (r *ChallengeRepo) UpdateTemplateVersion(id string, version int) error {
+	_, err := r.db.Exec(`
+		UPDATE challenges
+		SET template_version = ?, updated_at = ?
+		WHERE id = ?
+	`, version, time.Now(), id)
+	return err
+}
+
 func (r *ChallengeRepo) Delete(id string) error {
-	_, err := r.db.Exec("DELETE FROM challenges WHERE id = ?", id)
+	_, err := r.db.Exec(`
+		UPDATE challenges
+		SET deleted_at = ?
+		WHERE id = ? AND deleted_at IS NULL
+	`, time.Now().UTC(), id)
 	return err
 }
 
@@ -91,3 +123,52 @@ func (r *ChallengeRepo) Exists(id string) (bool, error) {
 	err := r.db.Get(&count, "SELECT COUNT(*) FROM challenges WHERE id = ?", id)
 	return count > 0, err
 }
+
+func (r *ChallengeRepo) CountActive(since time.Time) (int, error) {
+	var count int
+	err := r.db.Get(&count, `
+		SELECT COUNT(DISTINCT p.challenge_id)
+		FROM task_completions tc
+		JOIN participants p ON p.id = tc.participant_id
+		JOIN challenges c ON c.id = p.challenge_id
+		WHERE tc.completed_at >= ? AND c.deleted_at IS NULL
+	`, since.UTC())
+	return count, err
+}
+
+func (r *ChallengeRepo) GetDeleted() ([]*domain.Challenge, error) {
+	var challenges []*domain.Challenge
+	err := r.db.Select(&challenges, `
+		SELECT * FROM challenges
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`)
+	return challenges, err
+}
+
+func (r *ChallengeRepo) GetDeletedByID(id string) (*domain.Challenge, error) {
+	var challenge domain.Challenge
+	err := r.db.Get(&challenge, "SELECT * FROM challenges WHERE id = ? AND deleted_at IS NOT NULL", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &challenge, err
+}
+
+func (r *ChallengeRepo) Restore(id string) error {
+	_, err := r.db.Exec(`
+		UPDATE challenges
+		SET deleted_at = NULL, updated_at = ?
+		WHERE id = ? AND deleted_at IS NOT NULL
+	`, time.Now(), id)
+	return err
+}
+
+func (r *ChallengeRepo) PurgeDeleted(before time.Time) (int, error) {
+	result, err := r.db.Exec("DELETE FROM challenges WHERE deleted_at IS NOT NULL AND deleted_at < ?", before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	purged, err := result.RowsAffected()
+	return int(purged), err
+}
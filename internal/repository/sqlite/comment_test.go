@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestCommentRepo_CreateAndGetByTaskID(t *testing.T) {
+	repo := setupTestDB(t)
+
+	// Setup
+	challenge := &domain.Challenge{ID: "TEST1234", Name: "Test", CreatorID: 12345}
+	repo.Challenge().Create(challenge)
+
+	task := &domain.Task{ChallengeID: "TEST1234", OrderNum: 1, Title: "Task 1"}
+	repo.Task().Create(task)
+
+	participant := &domain.Participant{ChallengeID: "TEST1234", TelegramID: 12345, DisplayName: "User", Emoji: "💪", NotifyEnabled: true}
+	repo.Participant().Create(participant)
+
+	for _, text := range []string{"first", "second", "third"} {
+		comment := &domain.TaskComment{TaskID: task.ID, ParticipantID: participant.ID, Text: text}
+		if err := repo.Comment().Create(comment); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if comment.ID == 0 {
+			t.Error("Create() should set comment ID")
+		}
+	}
+
+	count, err := repo.Comment().CountByTaskID(task.ID)
+	if err != nil {
+		t.Fatalf("CountByTaskID() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("CountByTaskID() = %d, want 3", count)
+	}
+
+	// Newest first
+	comments, err := repo.Comment().GetByTaskID(task.ID, 2, 0)
+	if err != nil {
+		t.Fatalf("GetByTaskID() error = %v", err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("GetByTaskID() count = %d, want 2", len(comments))
+	}
+	if comments[0].Text != "third" || comments[1].Text != "second" {
+		t.Errorf("GetByTaskID() order = [%s, %s], want [third, second]", comments[0].Text, comments[1].Text)
+	}
+
+	// Offset
+	comments, _ = repo.Comment().GetByTaskID(task.ID, 2, 2)
+	if len(comments) != 1 || comments[0].Text != "first" {
+		t.Errorf("GetByTaskID() with offset should return only the oldest comment")
+	}
+}
+
+func TestCommentRepo_Delete(t *testing.T) {
+	repo := setupTestDB(t)
+
+	// Setup
+	challenge := &domain.Challenge{ID: "TEST1234", Name: "Test", CreatorID: 12345}
+	repo.Challenge().Create(challenge)
+
+	task := &domain.Task{ChallengeID: "TEST1234", OrderNum: 1, Title: "Task 1"}
+	repo.Task().Create(task)
+
+	participant := &domain.Participant{ChallengeID: "TEST1234", TelegramID: 12345, DisplayName: "User", Emoji: "💪", NotifyEnabled: true}
+	repo.Participant().Create(participant)
+
+	comment := &domain.TaskComment{TaskID: task.ID, ParticipantID: participant.ID, Text: "hello"}
+	repo.Comment().Create(comment)
+
+	if err := repo.Comment().Delete(comment.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	got, err := repo.Comment().GetByID(comment.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got != nil {
+		t.Error("GetByID() should return nil after Delete()")
+	}
+}
+
+func TestCommentRepo_CascadeOnTaskPurge(t *testing.T) {
+	repo := setupTestDB(t)
+
+	// Setup
+	challenge := &domain.Challenge{ID: "TEST1234", Name: "Test", CreatorID: 12345}
+	repo.Challenge().Create(challenge)
+
+	task := &domain.Task{ChallengeID: "TEST1234", OrderNum: 1, Title: "Task 1"}
+	repo.Task().Create(task)
+
+	participant := &domain.Participant{ChallengeID: "TEST1234", TelegramID: 12345, DisplayName: "User", Emoji: "💪", NotifyEnabled: true}
+	repo.Participant().Create(participant)
+
+	comment := &domain.TaskComment{TaskID: task.ID, ParticipantID: participant.ID, Text: "hello"}
+	repo.Comment().Create(comment)
+
+	repo.Task().Delete(task.ID)
+
+	// Trashed tasks keep their comments, so a restore brings them back
+	got, _ := repo.Comment().GetByID(comment.ID)
+	if got == nil {
+		t.Error("Comment should be kept while its task is in the trash")
+	}
+
+	repo.Task().PurgeDeleted(time.Now().Add(time.Minute))
+
+	got, _ = repo.Comment().GetByID(comment.ID)
+	if got != nil {
+		t.Error("Comment should be deleted when its task is purged")
+	}
+}
@@ -4,13 +4,12 @@ import (
 	"database/sql"
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 )
 
 // CompletionRepo implements CompletionRepository for SQLite
 type CompletionRepo struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func (r *CompletionRepo) Create(completion *domain.TaskCompletion) error {
@@ -53,9 +52,10 @@ func (r *CompletionRepo) GetByTaskID(taskID int64) ([]*domain.TaskCompletion, er
 func (r *CompletionRepo) GetByParticipantID(participantID int64) ([]*domain.TaskCompletion, error) {
 	var completions []*domain.TaskCompletion
 	err := r.db.Select(&completions, `
-		SELECT * FROM task_completions
-		WHERE participant_id = ?
-		ORDER BY completed_at ASC
+		SELECT tc.* FROM task_completions tc
+		JOIN tasks t ON t.id = tc.task_id
+		WHERE tc.participant_id = ? AND t.deleted_at IS NULL
+		ORDER BY tc.completed_at ASC
 	`, participantID)
 	return completions, err
 }
@@ -75,7 +75,9 @@ func (r *CompletionRepo) GetByTaskAndParticipant(taskID, participantID int64) (*
 func (r *CompletionRepo) CountByParticipantID(participantID int64) (int, error) {
 	var count int
 	err := r.db.Get(&count, `
-		SELECT COUNT(*) FROM task_completions WHERE participant_id = ?
+		SELECT COUNT(*) FROM task_completions tc
+		JOIN tasks t ON t.id = tc.task_id
+		WHERE tc.participant_id = ? AND t.deleted_at IS NULL
 	`, participantID)
 	return count, err
 }
@@ -83,7 +85,9 @@ func (r *CompletionRepo) CountByParticipantID(participantID int64) (int, error)
 func (r *CompletionRepo) GetCompletedTaskIDs(participantID int64) ([]int64, error) {
 	var ids []int64
 	err := r.db.Select(&ids, `
-		SELECT task_id FROM task_completions WHERE participant_id = ?
+		SELECT tc.task_id FROM task_completions tc
+		JOIN tasks t ON t.id = tc.task_id
+		WHERE tc.participant_id = ? AND t.deleted_at IS NULL
 	`, participantID)
 	return ids, err
 }
@@ -96,3 +100,9 @@ func (r *CompletionRepo) CountCompletionsInRange(participantID int64, from, to t
 	`, participantID, from, to)
 	return count, err
 }
+
+func (r *CompletionRepo) CountSince(since time.Time) (int, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM task_completions WHERE completed_at >= ?", since.UTC())
+	return count, err
+}
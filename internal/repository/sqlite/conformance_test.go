@@ -0,0 +1,14 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		return setupTestDB(t)
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"embed"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/jmoiron/sqlx"
 	_ "modernc.org/sqlite"
@@ -17,6 +16,7 @@ var migrationsFS embed.FS
 // SQLiteRepository implements repository.Repository for SQLite
 type SQLiteRepository struct {
 	db           *sqlx.DB
+	tx           *sqlx.Tx // set on repositories handed out by WithTx
 	challenge    *ChallengeRepo
 	task         *TaskRepo
 	participant  *ParticipantRepo
@@ -25,70 +25,71 @@ type SQLiteRepository struct {
 	superAdmin   *SuperAdminRepo
 	template     *TemplateRepo
 	templateTask *TemplateTaskRepo
+	category     *TemplateCategoryRepo
+	version      *TemplateVersionRepo
+	comment      *CommentRepo
+	kudos        *KudosRepo
+	nudge        *NudgeRepo
+	achievement  *AchievementRepo
+	audit        *AuditRepo
 }
 
-// New creates a new SQLite repository
+// New creates a new SQLite repository and applies all pending migrations
 func New(dbPath string) (*SQLiteRepository, error) {
-	// Ensure directory exists
-	dir := filepath.Dir(dbPath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return nil, err
-	}
-
-	db, err := sqlx.Connect("sqlite", dbPath)
+	repo, err := Open(dbPath)
 	if err != nil {
 		return nil, err
 	}
 
-	// Enable foreign keys (must be set per connection)
-	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
-		db.Close()
+	if err := repo.Migrate(0); err != nil {
+		repo.Close()
 		return nil, err
 	}
 
-	repo := &SQLiteRepository{
-		db:           db,
-		challenge:    &ChallengeRepo{db: db},
-		task:         &TaskRepo{db: db},
-		participant:  &ParticipantRepo{db: db},
-		completion:   &CompletionRepo{db: db},
-		state:        &StateRepo{db: db},
-		superAdmin:   &SuperAdminRepo{db: db},
-		template:     &TemplateRepo{db: db},
-		templateTask: &TemplateTaskRepo{db: db},
+	return repo, nil
+}
+
+// Open opens a SQLite repository without touching the schema
+func Open(dbPath string) (*SQLiteRepository, error) {
+	// Ensure directory exists
+	dir := filepath.Dir(dbPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
 	}
 
-	if err := repo.migrate(); err != nil {
-		db.Close()
+	// Pragmas are set in the DSN so that every pooled connection gets them, not just
+	// the first: foreign keys for cascades, a busy timeout so concurrent writers wait
+	// for each other, and immediate transactions so a transaction never fails upgrading
+	// from a read to a write lock
+	dsn := dbPath + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate"
+	db, err := sqlx.Connect("sqlite", dsn)
+	if err != nil {
 		return nil, err
 	}
 
-	return repo, nil
+	return newRepository(db, db), nil
 }
 
-func (r *SQLiteRepository) migrate() error {
-	migrations := []string{
-		"migrations/001_initial.sql",
-		"migrations/002_super_admins.sql",
-		"migrations/003_templates.sql",
-		"migrations/004_template_task_image.sql",
-	}
-
-	for _, m := range migrations {
-		migration, err := migrationsFS.ReadFile(m)
-		if err != nil {
-			return err
-		}
-		_, err = r.db.Exec(string(migration))
-		if err != nil {
-			// Ignore "duplicate column" errors for ALTER TABLE migrations
-			if strings.Contains(err.Error(), "duplicate column") {
-				continue
-			}
-			return err
-		}
+// newRepository wires every repo to q, which is either db itself or a transaction on it
+func newRepository(db *sqlx.DB, q dbtx) *SQLiteRepository {
+	return &SQLiteRepository{
+		db:           db,
+		challenge:    &ChallengeRepo{db: q},
+		task:         &TaskRepo{db: q},
+		participant:  &ParticipantRepo{db: q},
+		completion:   &CompletionRepo{db: q},
+		state:        &StateRepo{db: q},
+		superAdmin:   &SuperAdminRepo{db: q},
+		template:     &TemplateRepo{db: q},
+		templateTask: &TemplateTaskRepo{db: q},
+		category:     &TemplateCategoryRepo{db: q},
+		version:      &TemplateVersionRepo{db: q},
+		comment:      &CommentRepo{db: q},
+		kudos:        &KudosRepo{db: q},
+		nudge:        &NudgeRepo{db: q},
+		achievement:  &AchievementRepo{db: q},
+		audit:        &AuditRepo{db: q},
 	}
-	return nil
 }
 
 func (r *SQLiteRepository) Challenge() repository.ChallengeRepository {
@@ -123,6 +124,64 @@ func (r *SQLiteRepository) TemplateTask() repository.TemplateTaskRepository {
 	return r.templateTask
 }
 
+func (r *SQLiteRepository) TemplateCategory() repository.TemplateCategoryRepository {
+	return r.category
+}
+
+func (r *SQLiteRepository) TemplateVersion() repository.TemplateVersionRepository {
+	return r.version
+}
+
+func (r *SQLiteRepository) Comment() repository.CommentRepository {
+	return r.comment
+}
+
+func (r *SQLiteRepository) Kudos() repository.KudosRepository {
+	return r.kudos
+}
+
+func (r *SQLiteRepository) Nudge() repository.NudgeRepository {
+	return r.nudge
+}
+
+func (r *SQLiteRepository) Achievement() repository.AchievementRepository {
+	return r.achievement
+}
+
+func (r *SQLiteRepository) Audit() repository.AuditRepository {
+	return r.audit
+}
+
+// WithTx runs fn with a repository whose reads and writes share one transaction.
+// The transaction commits if fn returns nil and rolls back otherwise; calling
+// WithTx on a transactional repository joins the outer transaction
+func (r *SQLiteRepository) WithTx(fn func(repo repository.Repository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	txRepo := newRepository(r.db, tx)
+	txRepo.tx = tx
+	if err := fn(txRepo); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *SQLiteRepository) Ping() error {
+	return r.db.Ping()
+}
+
 func (r *SQLiteRepository) Close() error {
+	if r.tx != nil {
+		// The connection belongs to the repository that started the transaction
+		return nil
+	}
 	return r.db.Close()
 }
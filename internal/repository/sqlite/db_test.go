@@ -36,3 +36,15 @@ func TestNew(t *testing.T) {
 		t.Fatal("New() returned nil")
 	}
 }
+
+func TestPing(t *testing.T) {
+	repo := setupTestDB(t)
+	if err := repo.Ping(); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	repo.Close()
+	if err := repo.Ping(); err == nil {
+		t.Error("Ping() on a closed database should fail")
+	}
+}
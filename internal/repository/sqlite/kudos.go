@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// KudosRepo implements KudosRepository for SQLite
+type KudosRepo struct {
+	db dbtx
+}
+
+func (r *KudosRepo) Create(kudos *domain.Kudos) error {
+	kudos.CreatedAt = time.Now().UTC()
+
+	result, err := r.db.NamedExec(`
+		INSERT INTO kudos (task_id, from_participant_id, to_participant_id, emoji, created_at)
+		VALUES (:task_id, :from_participant_id, :to_participant_id, :emoji, :created_at)
+	`, kudos)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	kudos.ID = id
+	return nil
+}
+
+func (r *KudosRepo) Exists(taskID, fromParticipantID, toParticipantID int64) (bool, error) {
+	var count int
+	err := r.db.Get(&count, `
+		SELECT COUNT(*) FROM kudos
+		WHERE task_id = ? AND from_participant_id = ? AND to_participant_id = ?
+	`, taskID, fromParticipantID, toParticipantID)
+	return count > 0, err
+}
+
+func (r *KudosRepo) CountByReceiver(participantID int64) (int, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM kudos WHERE to_participant_id = ?", participantID)
+	return count, err
+}
+
+func (r *KudosRepo) CountByChallengeID(challengeID string) (map[int64]int, error) {
+	var rows []struct {
+		ParticipantID int64 `db:"participant_id"`
+		Count         int   `db:"count"`
+	}
+	err := r.db.Select(&rows, `
+		SELECT k.to_participant_id AS participant_id, COUNT(*) AS count
+		FROM kudos k
+		JOIN participants p ON p.id = k.to_participant_id
+		WHERE p.challenge_id = ?
+		GROUP BY k.to_participant_id
+	`, challengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		counts[row.ParticipantID] = row.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestKudosRepo_CreateAndCount(t *testing.T) {
+	repo := setupTestDB(t)
+
+	// Setup
+	challenge := &domain.Challenge{ID: "TEST1234", Name: "Test", CreatorID: 12345}
+	repo.Challenge().Create(challenge)
+
+	task := &domain.Task{ChallengeID: "TEST1234", OrderNum: 1, Title: "Task 1"}
+	repo.Task().Create(task)
+
+	p1 := &domain.Participant{ChallengeID: "TEST1234", TelegramID: 12345, DisplayName: "User1", Emoji: "💪"}
+	repo.Participant().Create(p1)
+	p2 := &domain.Participant{ChallengeID: "TEST1234", TelegramID: 67890, DisplayName: "User2", Emoji: "🔥"}
+	repo.Participant().Create(p2)
+
+	kudos := &domain.Kudos{TaskID: task.ID, FromParticipantID: p1.ID, ToParticipantID: p2.ID, Emoji: "👏"}
+	if err := repo.Kudos().Create(kudos); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if kudos.ID == 0 {
+		t.Error("Create() should set kudos ID")
+	}
+
+	// Duplicate is rejected by the unique constraint
+	dup := &domain.Kudos{TaskID: task.ID, FromParticipantID: p1.ID, ToParticipantID: p2.ID, Emoji: "🔥"}
+	if err := repo.Kudos().Create(dup); err == nil {
+		t.Error("Create() duplicate should fail")
+	}
+
+	exists, err := repo.Kudos().Exists(task.ID, p1.ID, p2.ID)
+	if err != nil {
+		t.Fatalf("Exists() error = %v", err)
+	}
+	if !exists {
+		t.Error("Exists() = false, want true")
+	}
+
+	count, _ := repo.Kudos().CountByReceiver(p2.ID)
+	if count != 1 {
+		t.Errorf("CountByReceiver() = %d, want 1", count)
+	}
+
+	counts, err := repo.Kudos().CountByChallengeID("TEST1234")
+	if err != nil {
+		t.Fatalf("CountByChallengeID() error = %v", err)
+	}
+	if counts[p2.ID] != 1 || counts[p1.ID] != 0 {
+		t.Errorf("CountByChallengeID() = %v, want {%d: 1}", counts, p2.ID)
+	}
+}
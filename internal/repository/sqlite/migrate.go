@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository/migration"
+)
+
+// legacyMigrationVersion is the last migration shipped before schema_migrations existed.
+// Older databases re-ran every file on start and ignored "duplicate column" errors, so
+// these files keep that tolerance: on such a database they run once more and get recorded.
+// Later migrations must not rely on it.
+const legacyMigrationVersion = 14
+
+func loadMigrations() ([]migration.Migration, error) {
+	return migration.Load(migrationsFS, "migrations")
+}
+
+// LatestMigrationVersion returns the version of the newest embedded migration
+func LatestMigrationVersion() (int, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	return migration.Latest(migrations), nil
+}
+
+func (r *SQLiteRepository) tableExists(name string) (bool, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name)
+	return count > 0, err
+}
+
+func (r *SQLiteRepository) appliedMigrations() (map[int]migration.Applied, error) {
+	applied := map[int]migration.Applied{}
+	exists, err := r.tableExists("schema_migrations")
+	if err != nil || !exists {
+		return applied, err
+	}
+
+	var rows []migration.Applied
+	if err := r.db.Select(&rows, "SELECT version, name, checksum, applied_at FROM schema_migrations"); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// SchemaVersion returns the highest applied migration version (0 for an empty database)
+func (r *SQLiteRepository) SchemaVersion() (int, error) {
+	exists, err := r.tableExists("schema_migrations")
+	if err != nil || !exists {
+		return 0, err
+	}
+	var version sql.NullInt64
+	if err := r.db.Get(&version, "SELECT MAX(version) FROM schema_migrations"); err != nil {
+		return 0, err
+	}
+	return int(version.Int64), nil
+}
+
+// MigrationStatus lists the embedded migrations and whether each has been applied
+func (r *SQLiteRepository) MigrationStatus() ([]migration.Status, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := r.appliedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	return migration.Statuses(migrations, applied), nil
+}
+
+// Migrate applies pending migrations up to target (0 = latest). Each migration runs once,
+// in its own transaction, and is recorded in schema_migrations with its checksum.
+func (r *SQLiteRepository) Migrate(target int) error {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return err
+	}
+
+	applied, err := r.appliedMigrations()
+	if err != nil {
+		return err
+	}
+	pending, err := migration.Pending(migrations, applied, target)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range pending {
+		if err := r.applyMigration(m, m.Version <= legacyMigrationVersion); err != nil {
+			return fmt.Errorf("migration %03d_%s: %w", m.Version, m.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyMigration runs a migration and records it in one transaction. In tolerant mode a
+// "duplicate column" error ends the file early instead of failing, like the old runner did.
+func (r *SQLiteRepository) applyMigration(m migration.Migration, tolerant bool) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(m.SQL); err != nil {
+		if !tolerant || !strings.Contains(err.Error(), "duplicate column") {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO schema_migrations (version, name, checksum) VALUES (?, ?, ?)",
+		m.Version, m.Name, m.Checksum,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
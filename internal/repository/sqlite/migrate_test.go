@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository/migration"
+)
+
+func openTestDB(t *testing.T) *SQLiteRepository {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	repo, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestMigrate_AppliesAllOnce(t *testing.T) {
+	repo := setupTestDB(t)
+
+	latest, err := LatestMigrationVersion()
+	if err != nil {
+		t.Fatalf("LatestMigrationVersion() error = %v", err)
+	}
+	if version, _ := repo.SchemaVersion(); version != latest {
+		t.Errorf("SchemaVersion() = %d, want %d", version, latest)
+	}
+
+	statuses, err := repo.MigrationStatus()
+	if err != nil {
+		t.Fatalf("MigrationStatus() error = %v", err)
+	}
+	for _, s := range statuses {
+		if !s.Applied || s.Modified {
+			t.Errorf("migration %d: applied = %v, modified = %v", s.Version, s.Applied, s.Modified)
+		}
+	}
+
+	// Running again is a no-op
+	if err := repo.Migrate(0); err != nil {
+		t.Fatalf("Migrate() again error = %v", err)
+	}
+	var count int
+	repo.db.Get(&count, "SELECT COUNT(*) FROM schema_migrations")
+	if count != len(statuses) {
+		t.Errorf("schema_migrations rows = %d, want %d", count, len(statuses))
+	}
+}
+
+func TestMigrate_Target(t *testing.T) {
+	repo := openTestDB(t)
+
+	if version, _ := repo.SchemaVersion(); version != 0 {
+		t.Fatalf("SchemaVersion() of empty database = %d, want 0", version)
+	}
+
+	if err := repo.Migrate(5); err != nil {
+		t.Fatalf("Migrate(5) error = %v", err)
+	}
+	if version, _ := repo.SchemaVersion(); version != 5 {
+		t.Errorf("SchemaVersion() = %d, want 5", version)
+	}
+	statuses, _ := repo.MigrationStatus()
+	for _, s := range statuses {
+		if s.Applied != (s.Version <= 5) {
+			t.Errorf("migration %d applied = %v", s.Version, s.Applied)
+		}
+	}
+
+	if err := repo.Migrate(3); !errors.Is(err, migration.ErrDowngrade) {
+		t.Errorf("Migrate(3) error = %v, want migration.ErrDowngrade", err)
+	}
+	if err := repo.Migrate(999); err == nil {
+		t.Error("Migrate(999) should fail for an unknown version")
+	}
+
+	if err := repo.Migrate(0); err != nil {
+		t.Fatalf("Migrate(0) error = %v", err)
+	}
+	latest, _ := LatestMigrationVersion()
+	if version, _ := repo.SchemaVersion(); version != latest {
+		t.Errorf("SchemaVersion() = %d, want %d", version, latest)
+	}
+}
+
+func TestMigrate_RejectsModifiedAndUnknown(t *testing.T) {
+	repo := setupTestDB(t)
+
+	repo.db.Exec("UPDATE schema_migrations SET checksum = 'x' WHERE version = 2")
+	if err := repo.Migrate(0); !errors.Is(err, migration.ErrModified) {
+		t.Errorf("Migrate() with changed checksum error = %v, want migration.ErrModified", err)
+	}
+	statuses, _ := repo.MigrationStatus()
+	if !statuses[1].Modified {
+		t.Error("MigrationStatus() should flag the modified migration")
+	}
+
+	repo.db.Exec("DELETE FROM schema_migrations WHERE version = 2")
+	repo.db.Exec("INSERT INTO schema_migrations (version, name, checksum) VALUES (999, 'from_the_future', 'x')")
+	if err := repo.Migrate(0); !errors.Is(err, migration.ErrUnknown) {
+		t.Errorf("Migrate() with unknown migration error = %v, want migration.ErrUnknown", err)
+	}
+}
+
+func TestMigrate_AdoptsLegacyDatabase(t *testing.T) {
+	repo := openTestDB(t)
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations() error = %v", err)
+	}
+
+	// Set up the database the way the old runner did: every file on every start,
+	// ignoring "duplicate column" errors
+	legacyStart := func() {
+		for _, m := range migrations {
+			if m.Version > legacyMigrationVersion {
+				break
+			}
+			if _, err := repo.db.Exec(m.SQL); err != nil && !strings.Contains(err.Error(), "duplicate column") {
+				t.Fatalf("legacy migration %d error = %v", m.Version, err)
+			}
+		}
+	}
+	legacyStart()
+	repo.db.Exec("INSERT INTO templates (name) VALUES ('Legacy')")
+	legacyStart()
+
+	if err := repo.Migrate(0); err != nil {
+		t.Fatalf("Migrate() on legacy database error = %v", err)
+	}
+	if version, _ := repo.SchemaVersion(); version < legacyMigrationVersion {
+		t.Errorf("SchemaVersion() = %d, want at least %d", version, legacyMigrationVersion)
+	}
+
+	// The one-time template version backfill did not run again
+	var versions int
+	repo.db.Get(&versions, "SELECT COUNT(*) FROM template_versions")
+	if versions != 0 {
+		t.Errorf("template_versions rows = %d, want 0", versions)
+	}
+}
@@ -0,0 +1,14 @@
+-- Kudos table
+-- Stores reactions sent by participants for teammates' task completions
+-- One kudos per sender per completion (task + receiver)
+CREATE TABLE IF NOT EXISTS kudos (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    from_participant_id INTEGER NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+    to_participant_id INTEGER NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+    emoji TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(task_id, from_participant_id, to_participant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_kudos_to_participant ON kudos(to_participant_id);
@@ -0,0 +1,10 @@
+-- Nudges table
+-- Stores reminders sent between participants (used for per-pair rate limiting)
+CREATE TABLE IF NOT EXISTS nudges (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    from_participant_id INTEGER NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+    to_participant_id INTEGER NOT NULL REFERENCES participants(id) ON DELETE CASCADE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_nudges_pair ON nudges(from_participant_id, to_participant_id);
@@ -0,0 +1,13 @@
+-- Achievements table
+-- Stores badges awarded to users per challenge
+-- No foreign keys on purpose: badges are kept even if the user leaves or the challenge is deleted
+CREATE TABLE IF NOT EXISTS achievements (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    telegram_id INTEGER NOT NULL,
+    challenge_id TEXT NOT NULL,
+    code TEXT NOT NULL,
+    awarded_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(telegram_id, challenge_id, code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievements_telegram ON achievements(telegram_id);
@@ -0,0 +1,6 @@
+-- Add owner_id column to templates if it doesn't exist
+-- 0 = global template curated by super admins, otherwise the owner's Telegram ID
+-- The error is ignored in db.go if column already exists
+ALTER TABLE templates ADD COLUMN owner_id INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_templates_owner ON templates(owner_id);
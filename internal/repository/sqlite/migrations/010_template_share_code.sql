@@ -0,0 +1,6 @@
+-- Add share_code column to templates if it doesn't exist
+-- Empty string = not shared; otherwise the code used in t.me/bot?start=tpl_CODE links
+-- The error is ignored in db.go if column already exists
+ALTER TABLE templates ADD COLUMN share_code TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_templates_share_code ON templates(share_code) WHERE share_code != '';
@@ -0,0 +1,14 @@
+-- Template catalog: categories, tags and usage counts
+-- Categories are managed by super admins; templates.category_id = 0 means uncategorized
+CREATE TABLE IF NOT EXISTS template_categories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The error is ignored in db.go if the columns already exist
+ALTER TABLE templates ADD COLUMN category_id INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE templates ADD COLUMN tags TEXT NOT NULL DEFAULT '';
+ALTER TABLE templates ADD COLUMN use_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_templates_category ON templates(category_id);
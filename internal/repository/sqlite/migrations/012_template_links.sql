@@ -0,0 +1,7 @@
+-- Link challenges and their tasks to the template they were created from
+-- 0 = not created from a template (or created before links were recorded)
+-- The error is ignored in db.go if the columns already exist
+ALTER TABLE challenges ADD COLUMN template_id INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN template_task_id INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_challenges_template ON challenges(template_id);
@@ -0,0 +1,43 @@
+-- Template version history: a snapshot of the template settings and tasks after every edit
+-- challenges.template_version records the version a challenge was created from (0 = unknown)
+-- The ALTER comes first on purpose: databases from before schema_migrations may have run
+-- this file already, and there the ignored "duplicate column" error skips the backfill below
+ALTER TABLE challenges ADD COLUMN template_version INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS template_versions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    template_id INTEGER NOT NULL REFERENCES templates(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    editor_id INTEGER NOT NULL DEFAULT 0,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    daily_task_limit INTEGER NOT NULL DEFAULT 0,
+    hide_future_tasks INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(template_id, version)
+);
+
+-- template_task_id keeps the task identity so a restore can bring tasks back in place
+CREATE TABLE IF NOT EXISTS template_version_tasks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    version_id INTEGER NOT NULL REFERENCES template_versions(id) ON DELETE CASCADE,
+    template_task_id INTEGER NOT NULL,
+    order_num INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    image_file_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_template_version_tasks_version ON template_version_tasks(version_id);
+
+-- Record the current state of existing templates as their first version
+INSERT INTO template_versions (template_id, version, editor_id, name, description, daily_task_limit, hide_future_tasks, created_at)
+SELECT id, 1, owner_id, name, COALESCE(description, ''), COALESCE(daily_task_limit, 0), COALESCE(hide_future_tasks, 0), CURRENT_TIMESTAMP
+FROM templates
+WHERE id NOT IN (SELECT template_id FROM template_versions);
+
+INSERT INTO template_version_tasks (version_id, template_task_id, order_num, title, description, image_file_id)
+SELECT v.id, tt.id, tt.order_num, tt.title, COALESCE(tt.description, ''), COALESCE(tt.image_file_id, '')
+FROM template_versions v
+JOIN template_tasks tt ON tt.template_id = v.template_id
+WHERE v.version = 1 AND v.id NOT IN (SELECT version_id FROM template_version_tasks);
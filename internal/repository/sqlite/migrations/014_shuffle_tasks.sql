@@ -0,0 +1,4 @@
+-- Add shuffle_tasks column to challenges if it doesn't exist
+-- 1 = every participant gets their own stable shuffled task order
+-- The error is ignored in db.go if column already exists
+ALTER TABLE challenges ADD COLUMN shuffle_tasks INTEGER NOT NULL DEFAULT 0;
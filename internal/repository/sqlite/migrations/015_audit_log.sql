@@ -0,0 +1,28 @@
+-- Append-only audit log of admin and super admin actions. Targets have no foreign keys
+-- so the history of a deleted challenge, task or template stays intact
+CREATE TABLE IF NOT EXISTS audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    actor_id INTEGER NOT NULL,
+    action TEXT NOT NULL,
+    challenge_id TEXT NOT NULL DEFAULT '',
+    task_id INTEGER NOT NULL DEFAULT 0,
+    template_id INTEGER NOT NULL DEFAULT 0,
+    target_user_id INTEGER NOT NULL DEFAULT 0,
+    target_name TEXT NOT NULL DEFAULT '',
+    before_value TEXT NOT NULL DEFAULT '',
+    after_value TEXT NOT NULL DEFAULT '',
+    observer INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_challenge ON audit_log(challenge_id, id);
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
@@ -0,0 +1,7 @@
+-- Soft delete: deleted tasks and challenges stay in the trash with their completions
+-- until the purge job removes them after the retention window
+ALTER TABLE tasks ADD COLUMN deleted_at DATETIME;
+ALTER TABLE challenges ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted ON tasks(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_challenges_deleted ON challenges(deleted_at);
@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// NudgeRepo implements NudgeRepository for SQLite
+type NudgeRepo struct {
+	db dbtx
+}
+
+func (r *NudgeRepo) Create(nudge *domain.Nudge) error {
+	nudge.CreatedAt = time.Now().UTC()
+
+	result, err := r.db.NamedExec(`
+		INSERT INTO nudges (from_participant_id, to_participant_id, created_at)
+		VALUES (:from_participant_id, :to_participant_id, :created_at)
+	`, nudge)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	nudge.ID = id
+	return nil
+}
+
+func (r *NudgeRepo) GetLast(fromParticipantID, toParticipantID int64) (*domain.Nudge, error) {
+	var nudge domain.Nudge
+	err := r.db.Get(&nudge, `
+		SELECT * FROM nudges
+		WHERE from_participant_id = ? AND to_participant_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`, fromParticipantID, toParticipantID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &nudge, err
+}
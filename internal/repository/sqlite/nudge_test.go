@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestNudgeRepo_CreateAndGetLast(t *testing.T) {
+	repo := setupTestDB(t)
+
+	// Setup
+	challenge := &domain.Challenge{ID: "TEST1234", Name: "Test", CreatorID: 12345}
+	repo.Challenge().Create(challenge)
+
+	p1 := &domain.Participant{ChallengeID: "TEST1234", TelegramID: 12345, DisplayName: "User1", Emoji: "💪"}
+	repo.Participant().Create(p1)
+	p2 := &domain.Participant{ChallengeID: "TEST1234", TelegramID: 67890, DisplayName: "User2", Emoji: "🔥"}
+	repo.Participant().Create(p2)
+
+	// No nudges yet
+	last, err := repo.Nudge().GetLast(p1.ID, p2.ID)
+	if err != nil {
+		t.Fatalf("GetLast() error = %v", err)
+	}
+	if last != nil {
+		t.Error("GetLast() should return nil before any nudge")
+	}
+
+	first := &domain.Nudge{FromParticipantID: p1.ID, ToParticipantID: p2.ID}
+	if err := repo.Nudge().Create(first); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	second := &domain.Nudge{FromParticipantID: p1.ID, ToParticipantID: p2.ID}
+	repo.Nudge().Create(second)
+
+	last, err = repo.Nudge().GetLast(p1.ID, p2.ID)
+	if err != nil {
+		t.Fatalf("GetLast() error = %v", err)
+	}
+	if last == nil || last.ID != second.ID {
+		t.Errorf("GetLast() should return the most recent nudge")
+	}
+
+	// Pairs are directional
+	last, _ = repo.Nudge().GetLast(p2.ID, p1.ID)
+	if last != nil {
+		t.Error("GetLast() for reversed pair should return nil")
+	}
+}
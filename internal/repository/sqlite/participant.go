@@ -4,13 +4,12 @@ import (
 	"database/sql"
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 )
 
 // ParticipantRepo implements ParticipantRepository for SQLite
 type ParticipantRepo struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func (r *ParticipantRepo) Create(participant *domain.Participant) error {
@@ -41,6 +40,11 @@ func (r *ParticipantRepo) GetByID(id int64) (*domain.Participant, error) {
 	return &participant, err
 }
 
+func (r *ParticipantRepo) GetByIDForUpdate(id int64) (*domain.Participant, error) {
+	// SQLite has no row locks; transactions start immediate and so already hold the write lock
+	return r.GetByID(id)
+}
+
 func (r *ParticipantRepo) GetByChallengeAndUser(challengeID string, telegramID int64) (*domain.Participant, error) {
 	var participant domain.Participant
 	err := r.db.Get(&participant, `
@@ -99,3 +103,13 @@ func (r *ParticipantRepo) GetUsedEmojis(challengeID string) ([]string, error) {
 	`, challengeID)
 	return emojis, err
 }
+
+func (r *ParticipantRepo) Count() (int, error) {
+	var count int
+	err := r.db.Get(&count, `
+		SELECT COUNT(*) FROM participants p
+		JOIN challenges c ON c.id = p.challenge_id
+		WHERE c.deleted_at IS NULL
+	`)
+	return count, err
+}
@@ -4,13 +4,12 @@ import (
 	"database/sql"
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 )
 
 // StateRepo implements StateRepository for SQLite
 type StateRepo struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func (r *StateRepo) Get(telegramID int64) (*domain.UserState, error) {
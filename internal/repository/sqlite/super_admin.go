@@ -3,13 +3,12 @@ package sqlite
 import (
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 )
 
 // SuperAdminRepo implements SuperAdminRepository for SQLite
 type SuperAdminRepo struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func (r *SuperAdminRepo) Create(telegramID int64) error {
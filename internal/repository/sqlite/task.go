@@ -4,21 +4,20 @@ import (
 	"database/sql"
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 )
 
 // TaskRepo implements TaskRepository for SQLite
 type TaskRepo struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func (r *TaskRepo) Create(task *domain.Task) error {
 	task.CreatedAt = time.Now()
 
 	result, err := r.db.NamedExec(`
-		INSERT INTO tasks (challenge_id, order_num, title, description, image_file_id, created_at)
-		VALUES (:challenge_id, :order_num, :title, :description, :image_file_id, :created_at)
+		INSERT INTO tasks (challenge_id, order_num, title, description, image_file_id, template_task_id, created_at)
+		VALUES (:challenge_id, :order_num, :title, :description, :image_file_id, :template_task_id, :created_at)
 	`, task)
 	if err != nil {
 		return err
@@ -32,9 +31,74 @@ func (r *TaskRepo) Create(task *domain.Task) error {
 	return nil
 }
 
+func (r *TaskRepo) CreateBatch(tasks []*domain.Task) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, task := range tasks {
+		task.CreatedAt = now
+		result, err := tx.NamedExec(`
+			INSERT INTO tasks (challenge_id, order_num, title, description, image_file_id, template_task_id, created_at)
+			VALUES (:challenge_id, :order_num, :title, :description, :image_file_id, :template_task_id, :created_at)
+		`, task)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		task.ID = id
+	}
+
+	return tx.Commit()
+}
+
+func (r *TaskRepo) ApplyBatch(updated []*domain.Task, created []*domain.Task) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, task := range updated {
+		_, err := tx.NamedExec(`
+			UPDATE tasks
+			SET title = :title, description = :description, image_file_id = :image_file_id, order_num = :order_num
+			WHERE id = :id
+		`, task)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	for _, task := range created {
+		task.CreatedAt = now
+		result, err := tx.NamedExec(`
+			INSERT INTO tasks (challenge_id, order_num, title, description, image_file_id, template_task_id, created_at)
+			VALUES (:challenge_id, :order_num, :title, :description, :image_file_id, :template_task_id, :created_at)
+		`, task)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		task.ID = id
+	}
+
+	return tx.Commit()
+}
+
 func (r *TaskRepo) GetByID(id int64) (*domain.Task, error) {
 	var task domain.Task
-	err := r.db.Get(&task, "SELECT * FROM tasks WHERE id = ?", id)
+	err := r.db.Get(&task, "SELECT * FROM tasks WHERE id = ? AND deleted_at IS NULL", id)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -45,7 +109,7 @@ func (r *TaskRepo) GetByChallengeID(challengeID string) ([]*domain.Task, error)
 	var tasks []*domain.Task
 	err := r.db.Select(&tasks, `
 		SELECT * FROM tasks
-		WHERE challenge_id = ?
+		WHERE challenge_id = ? AND deleted_at IS NULL
 		ORDER BY order_num ASC
 	`, challengeID)
 	return tasks, err
@@ -61,14 +125,19 @@ func (r *TaskRepo) Update(task *domain.Task) error {
 }
 
 func (r *TaskRepo) Delete(id int64) error {
-	_, err := r.db.Exec("DELETE FROM tasks WHERE id = ?", id)
+	// A trashed task gives up its position; -id can't clash with any other task's
+	_, err := r.db.Exec(`
+		UPDATE tasks
+		SET deleted_at = ?, order_num = -id
+		WHERE id = ? AND deleted_at IS NULL
+	`, time.Now().UTC(), id)
 	return err
 }
 
 func (r *TaskRepo) GetMaxOrderNum(challengeID string) (int, error) {
 	var maxOrder sql.NullInt64
 	err := r.db.Get(&maxOrder, `
-		SELECT MAX(order_num) FROM tasks WHERE challenge_id = ?
+		SELECT MAX(order_num) FROM tasks WHERE challenge_id = ? AND deleted_at IS NULL
 	`, challengeID)
 	if err != nil {
 		return 0, err
@@ -80,15 +149,16 @@ func (r *TaskRepo) GetMaxOrderNum(challengeID string) (int, error) {
 }
 
 func (r *TaskRepo) UpdateOrderNums(challengeID string, updates map[int64]int) error {
-	tx, err := r.db.Beginx()
+	tx, err := begin(r.db)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
-	// First, set all to negative values to avoid unique constraint violations
-	for taskID, newOrder := range updates {
-		_, err = tx.Exec("UPDATE tasks SET order_num = ? WHERE id = ?", -newOrder, taskID)
+	// First, move them out of the way to avoid unique constraint violations. -id can't
+	// clash with the positions of other tasks, trashed ones included
+	for taskID := range updates {
+		_, err = tx.Exec("UPDATE tasks SET order_num = -id WHERE id = ?", taskID)
 		if err != nil {
 			return err
 		}
@@ -107,6 +177,43 @@ func (r *TaskRepo) UpdateOrderNums(challengeID string, updates map[int64]int) er
 
 func (r *TaskRepo) CountByChallengeID(challengeID string) (int, error) {
 	var count int
-	err := r.db.Get(&count, "SELECT COUNT(*) FROM tasks WHERE challenge_id = ?", challengeID)
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM tasks WHERE challenge_id = ? AND deleted_at IS NULL", challengeID)
 	return count, err
 }
+
+func (r *TaskRepo) GetDeletedByChallengeID(challengeID string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	err := r.db.Select(&tasks, `
+		SELECT * FROM tasks
+		WHERE challenge_id = ? AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+	`, challengeID)
+	return tasks, err
+}
+
+func (r *TaskRepo) GetDeletedByID(id int64) (*domain.Task, error) {
+	var task domain.Task
+	err := r.db.Get(&task, "SELECT * FROM tasks WHERE id = ? AND deleted_at IS NOT NULL", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &task, err
+}
+
+func (r *TaskRepo) Restore(id int64, orderNum int) error {
+	_, err := r.db.Exec(`
+		UPDATE tasks
+		SET deleted_at = NULL, order_num = ?
+		WHERE id = ? AND deleted_at IS NOT NULL
+	`, orderNum, id)
+	return err
+}
+
+func (r *TaskRepo) PurgeDeleted(before time.Time) (int, error) {
+	result, err := r.db.Exec("DELETE FROM tasks WHERE deleted_at IS NOT NULL AND deleted_at < ?", before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	purged, err := result.RowsAffected()
+	return int(purged), err
+}
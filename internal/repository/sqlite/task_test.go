@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"testing"
+	"time"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 )
@@ -114,7 +115,7 @@ func TestTaskRepo_Delete(t *testing.T) {
 	}
 }
 
-func TestTaskRepo_CascadeDelete(t *testing.T) {
+func TestTaskRepo_CascadePurge(t *testing.T) {
 	repo := setupTestDB(t)
 
 	// Create challenge and task
@@ -124,12 +125,53 @@ func TestTaskRepo_CascadeDelete(t *testing.T) {
 	task := &domain.Task{ChallengeID: "TEST1234", OrderNum: 1, Title: "Task 1"}
 	repo.Task().Create(task)
 
-	// Delete challenge (should cascade)
+	// Trashing the challenge keeps its tasks for a restore
 	repo.Challenge().Delete("TEST1234")
 
-	// Verify tasks deleted
 	tasks, _ := repo.Task().GetByChallengeID("TEST1234")
+	if len(tasks) != 1 {
+		t.Error("Tasks should be kept while the challenge is in the trash")
+	}
+
+	// Purging the challenge cascades
+	repo.Challenge().PurgeDeleted(time.Now().Add(time.Minute))
+
+	tasks, _ = repo.Task().GetByChallengeID("TEST1234")
 	if len(tasks) != 0 {
-		t.Error("Tasks should be deleted when challenge is deleted")
+		t.Error("Tasks should be deleted when challenge is purged")
+	}
+}
+
+func TestTaskRepo_CreateBatch(t *testing.T) {
+	repo := setupTestDB(t)
+
+	challenge := &domain.Challenge{ID: "TEST1234", Name: "Test", CreatorID: 12345}
+	repo.Challenge().Create(challenge)
+
+	tasks := []*domain.Task{
+		{ChallengeID: "TEST1234", OrderNum: 1, Title: "Task 1"},
+		{ChallengeID: "TEST1234", OrderNum: 2, Title: "Task 2", Description: "Desc"},
+	}
+	if err := repo.Task().CreateBatch(tasks); err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+	for _, task := range tasks {
+		if task.ID == 0 {
+			t.Error("CreateBatch() should set task IDs")
+		}
+	}
+
+	// A failing insert rolls back the whole batch
+	bad := []*domain.Task{
+		{ChallengeID: "TEST1234", OrderNum: 3, Title: "Task 3"},
+		{ChallengeID: "TEST1234", OrderNum: 1, Title: "Duplicate order"},
+	}
+	if err := repo.Task().CreateBatch(bad); err == nil {
+		t.Fatal("CreateBatch() with duplicate order should fail")
+	}
+
+	count, _ := repo.Task().CountByChallengeID("TEST1234")
+	if count != 2 {
+		t.Errorf("Task count after failed batch = %d, want 2", count)
 	}
 }
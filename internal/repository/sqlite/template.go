@@ -2,23 +2,23 @@ package sqlite
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 )
 
 // TemplateRepo implements TemplateRepository for SQLite
 type TemplateRepo struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func (r *TemplateRepo) Create(template *domain.Template) error {
 	template.CreatedAt = time.Now()
 
 	result, err := r.db.NamedExec(`
-		INSERT INTO templates (name, description, daily_task_limit, hide_future_tasks, created_at)
-		VALUES (:name, :description, :daily_task_limit, :hide_future_tasks, :created_at)
+		INSERT INTO templates (name, description, daily_task_limit, hide_future_tasks, owner_id, created_at)
+		VALUES (:name, :description, :daily_task_limit, :hide_future_tasks, :owner_id, :created_at)
 	`, template)
 	if err != nil {
 		return err
@@ -41,9 +41,104 @@ func (r *TemplateRepo) GetByID(id int64) (*domain.Template, error) {
 	return &template, err
 }
 
-func (r *TemplateRepo) GetAll() ([]*domain.Template, error) {
+func (r *TemplateRepo) GetByShareCode(code string) (*domain.Template, error) {
+	var template domain.Template
+	err := r.db.Get(&template, "SELECT * FROM templates WHERE share_code = ? AND share_code != ''", code)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &template, err
+}
+
+func (r *TemplateRepo) ExistsByShareCode(code string) (bool, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM templates WHERE share_code = ?", code)
+	return count > 0, err
+}
+
+func (r *TemplateRepo) UpdateShareCode(id int64, code string) error {
+	_, err := r.db.Exec("UPDATE templates SET share_code = ? WHERE id = ?", code, id)
+	return err
+}
+
+// filterWhere builds the WHERE clause selecting global templates that match the filter
+func filterWhere(filter domain.TemplateFilter) (string, []any) {
+	where := "owner_id = 0"
+	var args []any
+	if filter.CategoryID > 0 {
+		where += " AND category_id = ?"
+		args = append(args, filter.CategoryID)
+	}
+	if filter.Query != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Query) + "%"
+		where += ` AND (name LIKE ? ESCAPE '\' OR description LIKE ? ESCAPE '\' OR tags LIKE ? ESCAPE '\')`
+		args = append(args, pattern, pattern, pattern)
+	}
+	return where, args
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *TemplateRepo) Find(filter domain.TemplateFilter, limit, offset int) ([]*domain.Template, error) {
+	where, args := filterWhere(filter)
+	order := "created_at DESC, id DESC"
+	if filter.Sort == domain.TemplateSortPopular {
+		order = "use_count DESC, " + order
+	}
+	args = append(args, limit, offset)
+
 	var templates []*domain.Template
-	err := r.db.Select(&templates, "SELECT * FROM templates ORDER BY created_at DESC")
+	err := r.db.Select(&templates, "SELECT * FROM templates WHERE "+where+" ORDER BY "+order+" LIMIT ? OFFSET ?", args...)
+	return templates, err
+}
+
+func (r *TemplateRepo) CountFiltered(filter domain.TemplateFilter) (int, error) {
+	where, args := filterWhere(filter)
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM templates WHERE "+where, args...)
+	return count, err
+}
+
+func (r *TemplateRepo) CountByCategory() (map[int64]int, error) {
+	var rows []struct {
+		CategoryID int64 `db:"category_id"`
+		Count      int   `db:"count"`
+	}
+	err := r.db.Select(&rows, "SELECT category_id, COUNT(*) AS count FROM templates WHERE owner_id = 0 GROUP BY category_id")
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *TemplateRepo) UpdateCategory(id int64, categoryID int64) error {
+	_, err := r.db.Exec("UPDATE templates SET category_id = ? WHERE id = ?", categoryID, id)
+	return err
+}
+
+func (r *TemplateRepo) UpdateTags(id int64, tags string) error {
+	_, err := r.db.Exec("UPDATE templates SET tags = ? WHERE id = ?", tags, id)
+	return err
+}
+
+func (r *TemplateRepo) IncrementUseCount(id int64) error {
+	_, err := r.db.Exec("UPDATE templates SET use_count = use_count + 1 WHERE id = ?", id)
+	return err
+}
+
+func (r *TemplateRepo) GetGlobal() ([]*domain.Template, error) {
+	var templates []*domain.Template
+	err := r.db.Select(&templates, "SELECT * FROM templates WHERE owner_id = 0 ORDER BY created_at DESC")
+	return templates, err
+}
+
+func (r *TemplateRepo) GetByOwner(ownerID int64) ([]*domain.Template, error) {
+	var templates []*domain.Template
+	err := r.db.Select(&templates, "SELECT * FROM templates WHERE owner_id = ? ORDER BY created_at DESC", ownerID)
 	return templates, err
 }
 
@@ -52,15 +147,15 @@ func (r *TemplateRepo) Delete(id int64) error {
 	return err
 }
 
-func (r *TemplateRepo) Count() (int, error) {
+func (r *TemplateRepo) CountByOwner(ownerID int64) (int, error) {
 	var count int
-	err := r.db.Get(&count, "SELECT COUNT(*) FROM templates")
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM templates WHERE owner_id = ?", ownerID)
 	return count, err
 }
 
-func (r *TemplateRepo) ExistsByName(name string) (bool, error) {
+func (r *TemplateRepo) ExistsByName(name string, ownerID int64) (bool, error) {
 	var count int
-	err := r.db.Get(&count, "SELECT COUNT(*) FROM templates WHERE name = ?", name)
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM templates WHERE name = ? AND owner_id = ?", name, ownerID)
 	return count > 0, err
 }
 
@@ -83,3 +178,66 @@ func (r *TemplateRepo) UpdateHideFutureTasks(id int64, hide bool) error {
 	_, err := r.db.Exec("UPDATE templates SET hide_future_tasks = ? WHERE id = ?", hide, id)
 	return err
 }
+
+func (r *TemplateRepo) Restore(template *domain.Template, tasks []*domain.TemplateTask) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.NamedExec(`
+		UPDATE templates
+		SET name = :name, description = :description, daily_task_limit = :daily_task_limit, hide_future_tasks = :hide_future_tasks
+		WHERE id = :id
+	`, template)
+	if err != nil {
+		return err
+	}
+
+	var existingIDs []int64
+	if err := tx.Select(&existingIDs, "SELECT id FROM template_tasks WHERE template_id = ?", template.ID); err != nil {
+		return err
+	}
+	keep := make(map[int64]bool, len(tasks))
+	for _, task := range tasks {
+		keep[task.ID] = true
+	}
+	existing := make(map[int64]bool, len(existingIDs))
+	for _, id := range existingIDs {
+		if !keep[id] {
+			if _, err := tx.Exec("DELETE FROM template_tasks WHERE id = ?", id); err != nil {
+				return err
+			}
+			continue
+		}
+		existing[id] = true
+	}
+
+	// Move remaining tasks out of the way to avoid unique constraint violations
+	if _, err := tx.Exec("UPDATE template_tasks SET order_num = -id WHERE template_id = ?", template.ID); err != nil {
+		return err
+	}
+
+	// Tasks deleted since the version are re-created with their original ID,
+	// so challenges linked to them keep matching
+	for _, task := range tasks {
+		task.TemplateID = template.ID
+		query := `
+			INSERT INTO template_tasks (id, template_id, order_num, title, description, image_file_id)
+			VALUES (:id, :template_id, :order_num, :title, :description, :image_file_id)
+		`
+		if existing[task.ID] {
+			query = `
+				UPDATE template_tasks
+				SET order_num = :order_num, title = :title, description = :description, image_file_id = :image_file_id
+				WHERE id = :id
+			`
+		}
+		if _, err := tx.NamedExec(query, task); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// TemplateCategoryRepo implements TemplateCategoryRepository for SQLite
+type TemplateCategoryRepo struct {
+	db dbtx
+}
+
+func (r *TemplateCategoryRepo) Create(category *domain.TemplateCategory) error {
+	category.CreatedAt = time.Now()
+	result, err := r.db.NamedExec(`
+		INSERT INTO template_categories (name, created_at)
+		VALUES (:name, :created_at)
+	`, category)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	category.ID = id
+	return nil
+}
+
+func (r *TemplateCategoryRepo) GetByID(id int64) (*domain.TemplateCategory, error) {
+	var category domain.TemplateCategory
+	err := r.db.Get(&category, "SELECT * FROM template_categories WHERE id = ?", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &category, err
+}
+
+func (r *TemplateCategoryRepo) GetAll() ([]*domain.TemplateCategory, error) {
+	var categories []*domain.TemplateCategory
+	err := r.db.Select(&categories, "SELECT * FROM template_categories ORDER BY name")
+	return categories, err
+}
+
+func (r *TemplateCategoryRepo) ExistsByName(name string) (bool, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM template_categories WHERE name = ?", name)
+	return count > 0, err
+}
+
+func (r *TemplateCategoryRepo) Delete(id int64) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Templates in the category become uncategorized
+	if _, err := tx.Exec("UPDATE templates SET category_id = 0 WHERE category_id = ?", id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM template_categories WHERE id = ?", id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
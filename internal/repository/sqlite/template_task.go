@@ -1,13 +1,10 @@
 package sqlite
 
-import (
-	"github.com/jmoiron/sqlx"
-	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
-)
+import "github.com/rgeraskin/squad-challenge-bot/internal/domain"
 
 // TemplateTaskRepo implements TemplateTaskRepository for SQLite
 type TemplateTaskRepo struct {
-	db *sqlx.DB
+	db dbtx
 }
 
 func (r *TemplateTaskRepo) Create(task *domain.TemplateTask) error {
@@ -27,6 +24,31 @@ func (r *TemplateTaskRepo) Create(task *domain.TemplateTask) error {
 	return nil
 }
 
+func (r *TemplateTaskRepo) CreateBatch(tasks []*domain.TemplateTask) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, task := range tasks {
+		result, err := tx.NamedExec(`
+			INSERT INTO template_tasks (template_id, order_num, title, description, image_file_id)
+			VALUES (:template_id, :order_num, :title, :description, :image_file_id)
+		`, task)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		task.ID = id
+	}
+
+	return tx.Commit()
+}
+
 func (r *TemplateTaskRepo) GetByID(id int64) (*domain.TemplateTask, error) {
 	var task domain.TemplateTask
 	err := r.db.Get(&task, "SELECT * FROM template_tasks WHERE id = ?", id)
@@ -92,7 +114,7 @@ func (r *TemplateTaskRepo) UpdateOrderNum(id int64, orderNum int) error {
 }
 
 func (r *TemplateTaskRepo) UpdateOrderNums(templateID int64, updates map[int64]int) error {
-	tx, err := r.db.Beginx()
+	tx, err := begin(r.db)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestTemplateRepo_Find(t *testing.T) {
+	repo := setupTestDB(t)
+
+	yoga := &domain.Template{Name: "Morning Yoga"}
+	reading := &domain.Template{Name: "Reading", Description: "A book a week"}
+	percent := &domain.Template{Name: "100% Focus"}
+	personal := &domain.Template{Name: "Morning Pages", OwnerID: 12345}
+	for _, tpl := range []*domain.Template{yoga, reading, percent, personal} {
+		if err := repo.Template().Create(tpl); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	category := &domain.TemplateCategory{Name: "Health"}
+	repo.TemplateCategory().Create(category)
+	repo.Template().UpdateCategory(yoga.ID, category.ID)
+	repo.Template().UpdateTags(yoga.ID, "fitness,morning")
+	repo.Template().IncrementUseCount(reading.ID)
+	repo.Template().IncrementUseCount(reading.ID)
+
+	tests := []struct {
+		name   string
+		filter domain.TemplateFilter
+		want   []string
+	}{
+		{"all global, newest first", domain.TemplateFilter{}, []string{"100% Focus", "Reading", "Morning Yoga"}},
+		{"popular first", domain.TemplateFilter{Sort: domain.TemplateSortPopular}, []string{"Reading", "100% Focus", "Morning Yoga"}},
+		{"category", domain.TemplateFilter{CategoryID: category.ID}, []string{"Morning Yoga"}},
+		{"search name skips personal", domain.TemplateFilter{Query: "morning"}, []string{"Morning Yoga"}},
+		{"search description", domain.TemplateFilter{Query: "book"}, []string{"Reading"}},
+		{"search tags", domain.TemplateFilter{Query: "fitness"}, []string{"Morning Yoga"}},
+		{"wildcards are literal", domain.TemplateFilter{Query: "%"}, []string{"100% Focus"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates, err := repo.Template().Find(tt.filter, 10, 0)
+			if err != nil {
+				t.Fatalf("Find() error = %v", err)
+			}
+			var names []string
+			for _, tpl := range templates {
+				names = append(names, tpl.Name)
+			}
+			if len(names) != len(tt.want) {
+				t.Fatalf("Find() = %v, want %v", names, tt.want)
+			}
+			for i := range names {
+				if names[i] != tt.want[i] {
+					t.Errorf("Find()[%d] = %q, want %q", i, names[i], tt.want[i])
+				}
+			}
+
+			count, err := repo.Template().CountFiltered(tt.filter)
+			if err != nil {
+				t.Fatalf("CountFiltered() error = %v", err)
+			}
+			if count != len(tt.want) {
+				t.Errorf("CountFiltered() = %d, want %d", count, len(tt.want))
+			}
+		})
+	}
+
+	// Pagination
+	page, _ := repo.Template().Find(domain.TemplateFilter{}, 2, 2)
+	if len(page) != 1 || page[0].Name != "Morning Yoga" {
+		t.Errorf("Find() second page = %v, want [Morning Yoga]", page)
+	}
+}
+
+func TestTemplateCategoryRepo_DeleteUncategorizesTemplates(t *testing.T) {
+	repo := setupTestDB(t)
+
+	category := &domain.TemplateCategory{Name: "Health"}
+	if err := repo.TemplateCategory().Create(category); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	template := &domain.Template{Name: "Yoga"}
+	repo.Template().Create(template)
+	repo.Template().UpdateCategory(template.ID, category.ID)
+
+	counts, _ := repo.Template().CountByCategory()
+	if counts[category.ID] != 1 {
+		t.Errorf("CountByCategory()[%d] = %d, want 1", category.ID, counts[category.ID])
+	}
+
+	if err := repo.TemplateCategory().Delete(category.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	got, _ := repo.TemplateCategory().GetByID(category.ID)
+	if got != nil {
+		t.Error("GetByID() should return nil after delete")
+	}
+	updated, _ := repo.Template().GetByID(template.ID)
+	if updated.CategoryID != 0 {
+		t.Errorf("CategoryID = %d, want 0 after category delete", updated.CategoryID)
+	}
+}
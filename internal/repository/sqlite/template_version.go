@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// TemplateVersionRepo implements TemplateVersionRepository for SQLite
+type TemplateVersionRepo struct {
+	db dbtx
+}
+
+func (r *TemplateVersionRepo) Create(version *domain.TemplateVersion, tasks []*domain.TemplateVersionTask) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var latest sql.NullInt64
+	if err := tx.Get(&latest, "SELECT MAX(version) FROM template_versions WHERE template_id = ?", version.TemplateID); err != nil {
+		return err
+	}
+	version.Version = int(latest.Int64) + 1
+	version.CreatedAt = time.Now()
+
+	result, err := tx.NamedExec(`
+		INSERT INTO template_versions (template_id, version, editor_id, name, description, daily_task_limit, hide_future_tasks, created_at)
+		VALUES (:template_id, :version, :editor_id, :name, :description, :daily_task_limit, :hide_future_tasks, :created_at)
+	`, version)
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	version.ID = id
+
+	for _, task := range tasks {
+		task.VersionID = id
+		result, err := tx.NamedExec(`
+			INSERT INTO template_version_tasks (version_id, template_task_id, order_num, title, description, image_file_id)
+			VALUES (:version_id, :template_task_id, :order_num, :title, :description, :image_file_id)
+		`, task)
+		if err != nil {
+			return err
+		}
+		taskID, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		task.ID = taskID
+	}
+
+	return tx.Commit()
+}
+
+func (r *TemplateVersionRepo) GetByID(id int64) (*domain.TemplateVersion, error) {
+	var version domain.TemplateVersion
+	err := r.db.Get(&version, "SELECT * FROM template_versions WHERE id = ?", id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &version, err
+}
+
+func (r *TemplateVersionRepo) GetLatest(templateID int64) (*domain.TemplateVersion, error) {
+	var version domain.TemplateVersion
+	err := r.db.Get(&version, `
+		SELECT * FROM template_versions
+		WHERE template_id = ?
+		ORDER BY version DESC
+		LIMIT 1
+	`, templateID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &version, err
+}
+
+func (r *TemplateVersionRepo) GetByVersion(templateID int64, version int) (*domain.TemplateVersion, error) {
+	var v domain.TemplateVersion
+	err := r.db.Get(&v, `
+		SELECT * FROM template_versions
+		WHERE template_id = ? AND version = ?
+	`, templateID, version)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return &v, err
+}
+
+func (r *TemplateVersionRepo) GetByTemplateID(templateID int64, limit, offset int) ([]*domain.TemplateVersion, error) {
+	var versions []*domain.TemplateVersion
+	err := r.db.Select(&versions, `
+		SELECT * FROM template_versions
+		WHERE template_id = ?
+		ORDER BY version DESC
+		LIMIT ? OFFSET ?
+	`, templateID, limit, offset)
+	return versions, err
+}
+
+func (r *TemplateVersionRepo) CountByTemplateID(templateID int64) (int, error) {
+	var count int
+	err := r.db.Get(&count, "SELECT COUNT(*) FROM template_versions WHERE template_id = ?", templateID)
+	return count, err
+}
+
+func (r *TemplateVersionRepo) GetTasks(versionID int64) ([]*domain.TemplateVersionTask, error) {
+	var tasks []*domain.TemplateVersionTask
+	err := r.db.Select(&tasks, `
+		SELECT * FROM template_version_tasks
+		WHERE version_id = ?
+		ORDER BY order_num ASC
+	`, versionID)
+	return tasks, err
+}
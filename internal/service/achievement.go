@@ -0,0 +1,276 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+// nightOwlEndHour is the local hour before which a completion counts as a night owl one
+const nightOwlEndHour = 5
+
+// AchievementService evaluates and awards badges
+type AchievementService struct {
+	repo repository.Repository
+}
+
+// NewAchievementService creates a new AchievementService
+func NewAchievementService(repo repository.Repository) *AchievementService {
+	return &AchievementService{repo: repo}
+}
+
+// achievementContext holds everything the rules need to evaluate a participant
+type achievementContext struct {
+	repo        repository.Repository
+	participant *domain.Participant
+	challenge   *domain.Challenge
+	totalTasks  int
+	times       []time.Time // completion times, ascending
+}
+
+// allCompleted reports whether the participant has completed every task
+func (ctx *achievementContext) allCompleted() bool {
+	return ctx.totalTasks > 0 && len(ctx.times) >= ctx.totalTasks
+}
+
+// achievementRule awards a badge when check returns true
+type achievementRule struct {
+	code  string
+	check func(ctx *achievementContext) (bool, error)
+}
+
+// achievementRules is the list of rules evaluated after each completion
+var achievementRules = []achievementRule{
+	{domain.AchievementFirstTask, func(ctx *achievementContext) (bool, error) {
+		return len(ctx.times) > 0, nil
+	}},
+	{domain.AchievementStreak7, func(ctx *achievementContext) (bool, error) {
+		return longestStreakDays(ctx.times, ctx.participant.TimeOffsetMinutes) >= 7, nil
+	}},
+	{domain.AchievementFirstFinisher, func(ctx *achievementContext) (bool, error) {
+		if !ctx.allCompleted() {
+			return false, nil
+		}
+		return isFirstFinisher(ctx)
+	}},
+	{domain.AchievementDailyDiscipline, func(ctx *achievementContext) (bool, error) {
+		if ctx.challenge.DailyTaskLimit <= 0 || !ctx.allCompleted() {
+			return false, nil
+		}
+		return hitDailyLimitEveryDay(ctx.times, ctx.participant.TimeOffsetMinutes, ctx.challenge.DailyTaskLimit), nil
+	}},
+	{domain.AchievementNightOwl, func(ctx *achievementContext) (bool, error) {
+		return hasNightCompletion(ctx.times, ctx.participant.TimeOffsetMinutes), nil
+	}},
+}
+
+// Evaluate runs all rules for a participant and awards any new badges
+// Returns the badges awarded by this call
+func (s *AchievementService) Evaluate(participantID int64) ([]domain.AchievementInfo, error) {
+	participant, err := s.repo.Participant().GetByID(participantID)
+	if err != nil {
+		return nil, err
+	}
+	if participant == nil {
+		return nil, ErrParticipantNotFound
+	}
+
+	challenge, err := s.repo.Challenge().GetByID(participant.ChallengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil {
+		return nil, ErrChallengeNotFound
+	}
+
+	totalTasks, err := s.repo.Task().CountByChallengeID(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	completions, err := s.repo.Completion().GetByParticipantID(participant.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	held, err := s.repo.Achievement().GetCodesByChallengeAndUser(challenge.ID, participant.TelegramID)
+	if err != nil {
+		return nil, err
+	}
+	heldSet := make(map[string]bool, len(held))
+	for _, code := range held {
+		heldSet[code] = true
+	}
+
+	ctx := &achievementContext{
+		repo:        s.repo,
+		participant: participant,
+		challenge:   challenge,
+		totalTasks:  totalTasks,
+		times:       completionTimes(completions),
+	}
+
+	var awarded []domain.AchievementInfo
+	for _, rule := range achievementRules {
+		if heldSet[rule.code] {
+			continue
+		}
+		ok, err := rule.check(ctx)
+		if err != nil {
+			return awarded, err
+		}
+		if !ok {
+			continue
+		}
+
+		created, err := s.repo.Achievement().Create(&domain.Achievement{
+			TelegramID:  participant.TelegramID,
+			ChallengeID: challenge.ID,
+			Code:        rule.code,
+		})
+		if err != nil {
+			return awarded, err
+		}
+		if created {
+			info, _ := domain.GetAchievementInfo(rule.code)
+			awarded = append(awarded, info)
+		}
+	}
+
+	return awarded, nil
+}
+
+// GetForChallenge returns the badges a user earned in a challenge, in display order
+func (s *AchievementService) GetForChallenge(challengeID string, telegramID int64) ([]domain.AchievementInfo, error) {
+	codes, err := s.repo.Achievement().GetCodesByChallengeAndUser(challengeID, telegramID)
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		held[code] = true
+	}
+
+	var infos []domain.AchievementInfo
+	for _, a := range domain.Achievements {
+		if held[a.Code] {
+			infos = append(infos, a)
+		}
+	}
+	return infos, nil
+}
+
+// GetAllTimeCounts returns how many times a user earned each badge across all challenges
+func (s *AchievementService) GetAllTimeCounts(telegramID int64) (map[string]int, error) {
+	achievements, err := s.repo.Achievement().GetByUser(telegramID)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, a := range achievements {
+		counts[a.Code]++
+	}
+	return counts, nil
+}
+
+// isFirstFinisher checks that no other participant finished all tasks before this one
+func isFirstFinisher(ctx *achievementContext) (bool, error) {
+	myFinish := ctx.times[len(ctx.times)-1]
+
+	participants, err := ctx.repo.Participant().GetByChallengeID(ctx.challenge.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, p := range participants {
+		if p.ID == ctx.participant.ID {
+			continue
+		}
+		completions, err := ctx.repo.Completion().GetByParticipantID(p.ID)
+		if err != nil {
+			return false, err
+		}
+		if len(completions) < ctx.totalTasks {
+			continue
+		}
+		times := completionTimes(completions)
+		if times[len(times)-1].Before(myFinish) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// completionTimes extracts completion times sorted ascending
+func completionTimes(completions []*domain.TaskCompletion) []time.Time {
+	times := make([]time.Time, len(completions))
+	for i, c := range completions {
+		times[i] = c.CompletedAt.UTC()
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
+}
+
+// localDay returns the user's local calendar day (as a UTC midnight) for a time
+func localDay(t time.Time, offsetMinutes int) time.Time {
+	local := t.UTC().Add(time.Duration(offsetMinutes) * time.Minute)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// longestStreakDays returns the longest run of consecutive local days with at least one completion
+func longestStreakDays(times []time.Time, offsetMinutes int) int {
+	longest, current := 0, 0
+	var prev time.Time
+	for _, t := range times {
+		day := localDay(t, offsetMinutes)
+		switch {
+		case current == 0:
+			current = 1
+		case day.Equal(prev):
+			continue
+		case day.Equal(prev.AddDate(0, 0, 1)):
+			current++
+		default:
+			current = 1
+		}
+		prev = day
+		if current > longest {
+			longest = current
+		}
+	}
+	return longest
+}
+
+// hitDailyLimitEveryDay checks that every local day from the first to the last completion
+// had exactly `limit` completions, except the last day which may have fewer (the remainder)
+func hitDailyLimitEveryDay(times []time.Time, offsetMinutes int, limit int) bool {
+	if len(times) == 0 || limit <= 0 {
+		return false
+	}
+
+	perDay := make(map[time.Time]int)
+	for _, t := range times {
+		perDay[localDay(t, offsetMinutes)]++
+	}
+
+	first := localDay(times[0], offsetMinutes)
+	last := localDay(times[len(times)-1], offsetMinutes)
+	for day := first; day.Before(last); day = day.AddDate(0, 0, 1) {
+		if perDay[day] != limit {
+			return false
+		}
+	}
+	return perDay[last] > 0 && perDay[last] <= limit
+}
+
+// hasNightCompletion checks for any completion between local midnight and nightOwlEndHour
+func hasNightCompletion(times []time.Time, offsetMinutes int) bool {
+	for _, t := range times {
+		local := t.UTC().Add(time.Duration(offsetMinutes) * time.Minute)
+		if local.Hour() < nightOwlEndHour {
+			return true
+		}
+	}
+	return false
+}
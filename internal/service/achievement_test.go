@@ -0,0 +1,144 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestAchievementService_Evaluate(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+	achievementSvc := NewAchievementService(repo)
+
+	// Setup
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	p1, _ := participantSvc.Join(challenge.ID, 12345, "User1", "💪", 0)
+	p2, _ := participantSvc.Join(challenge.ID, 67890, "User2", "🔥", 0)
+
+	// No completions - no badges
+	awarded, err := achievementSvc.Evaluate(p1.ID)
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if len(awarded) != 0 {
+		t.Errorf("Evaluate() without completions awarded %d badges, want 0", len(awarded))
+	}
+
+	// First task
+	completionSvc.Complete(task1.ID, p1.ID)
+	awarded, _ = achievementSvc.Evaluate(p1.ID)
+	if !hasBadge(awarded, domain.AchievementFirstTask) {
+		t.Error("Evaluate() should award first task badge")
+	}
+
+	// Badges are awarded only once
+	awarded, _ = achievementSvc.Evaluate(p1.ID)
+	if hasBadge(awarded, domain.AchievementFirstTask) {
+		t.Error("Evaluate() should not award first task badge twice")
+	}
+
+	// First finisher
+	completionSvc.Complete(task2.ID, p1.ID)
+	awarded, _ = achievementSvc.Evaluate(p1.ID)
+	if !hasBadge(awarded, domain.AchievementFirstFinisher) {
+		t.Error("Evaluate() should award first finisher badge")
+	}
+
+	// Second finisher doesn't get it
+	completionSvc.Complete(task1.ID, p2.ID)
+	completionSvc.Complete(task2.ID, p2.ID)
+	awarded, _ = achievementSvc.Evaluate(p2.ID)
+	if hasBadge(awarded, domain.AchievementFirstFinisher) {
+		t.Error("Evaluate() should not award first finisher badge to second finisher")
+	}
+
+	// Stored per challenge and globally
+	earned, _ := achievementSvc.GetForChallenge(challenge.ID, 12345)
+	if !hasBadge(earned, domain.AchievementFirstTask) || !hasBadge(earned, domain.AchievementFirstFinisher) {
+		t.Errorf("GetForChallenge() = %v, want first task and first finisher", earned)
+	}
+	counts, _ := achievementSvc.GetAllTimeCounts(12345)
+	if counts[domain.AchievementFirstTask] != 1 {
+		t.Errorf("GetAllTimeCounts() first task = %d, want 1", counts[domain.AchievementFirstTask])
+	}
+}
+
+func TestLongestStreakDays(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2025, 1, d, h, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		times  []time.Time
+		offset int
+		want   int
+	}{
+		{"empty", nil, 0, 0},
+		{"single", []time.Time{day(1, 10)}, 0, 1},
+		{"same day twice", []time.Time{day(1, 10), day(1, 12)}, 0, 1},
+		{"three in a row", []time.Time{day(1, 10), day(2, 10), day(3, 10)}, 0, 3},
+		{"gap resets", []time.Time{day(1, 10), day(2, 10), day(4, 10)}, 0, 2},
+		// 23:00 UTC on Jan 1 is Jan 2 at UTC+2, which joins with Jan 2-3 completions
+		{"offset shifts day", []time.Time{day(1, 23), day(3, 10)}, 120, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := longestStreakDays(tt.times, tt.offset); got != tt.want {
+				t.Errorf("longestStreakDays() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHitDailyLimitEveryDay(t *testing.T) {
+	day := func(d, h int) time.Time { return time.Date(2025, 1, d, h, 0, 0, 0, time.UTC) }
+
+	// Limit 2: two per day, then one on the last day
+	full := []time.Time{day(1, 9), day(1, 10), day(2, 9), day(2, 10), day(3, 9)}
+	if !hitDailyLimitEveryDay(full, 0, 2) {
+		t.Error("hitDailyLimitEveryDay() = false for a perfect run, want true")
+	}
+
+	// Skipped a day
+	skipped := []time.Time{day(1, 9), day(1, 10), day(3, 9), day(3, 10)}
+	if hitDailyLimitEveryDay(skipped, 0, 2) {
+		t.Error("hitDailyLimitEveryDay() = true with a skipped day, want false")
+	}
+
+	// Under the limit on a middle day
+	under := []time.Time{day(1, 9), day(2, 9), day(2, 10), day(3, 9)}
+	if hitDailyLimitEveryDay(under, 0, 2) {
+		t.Error("hitDailyLimitEveryDay() = true with a short day, want false")
+	}
+}
+
+func TestHasNightCompletion(t *testing.T) {
+	at := func(h int) time.Time { return time.Date(2025, 1, 1, h, 0, 0, 0, time.UTC) }
+
+	if !hasNightCompletion([]time.Time{at(12), at(3)}, 0) {
+		t.Error("hasNightCompletion() = false for 3 AM, want true")
+	}
+	if hasNightCompletion([]time.Time{at(12)}, 0) {
+		t.Error("hasNightCompletion() = true for noon, want false")
+	}
+	// 22:00 UTC is 01:00 at UTC+3
+	if !hasNightCompletion([]time.Time{at(22)}, 180) {
+		t.Error("hasNightCompletion() should use the local time offset")
+	}
+}
+
+func hasBadge(badges []domain.AchievementInfo, code string) bool {
+	for _, b := range badges {
+		if b.Code == code {
+			return true
+		}
+	}
+	return false
+}
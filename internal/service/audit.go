@@ -0,0 +1,146 @@
+package service
+
+import (
+	"strconv"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+// withAudit runs change and records the audit entries it returns in one transaction, so an
+// admin action is never saved without its record. Returning no entries (e.g. for a no-op) records nothing
+func withAudit(repo repository.Repository, change func(repo repository.Repository) ([]*domain.AuditEntry, error)) error {
+	return repo.WithTx(func(repo repository.Repository) error {
+		entries, err := change(repo)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := repo.Audit().Create(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// newChallengeEntry starts an audit entry about a challenge. Anyone acting on a challenge
+// they didn't create is a super admin in observer mode
+func newChallengeEntry(challenge *domain.Challenge, actorID int64, action string) *domain.AuditEntry {
+	return &domain.AuditEntry{
+		ActorID:     actorID,
+		Action:      action,
+		ChallengeID: challenge.ID,
+		TargetName:  challenge.Name,
+		Observer:    challenge.CreatorID != actorID,
+	}
+}
+
+// challengeEntry loads a challenge and starts an audit entry about it
+func challengeEntry(repo repository.Repository, challengeID string, actorID int64, action string) (*domain.AuditEntry, error) {
+	challenge, err := repo.Challenge().GetByID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil {
+		return nil, ErrChallengeNotFound
+	}
+	return newChallengeEntry(challenge, actorID, action), nil
+}
+
+// newTaskEntry starts an audit entry about a task of a challenge
+func newTaskEntry(repo repository.Repository, task *domain.Task, actorID int64, action string) (*domain.AuditEntry, error) {
+	entry, err := challengeEntry(repo, task.ChallengeID, actorID, action)
+	if err != nil {
+		return nil, err
+	}
+	entry.TaskID = task.ID
+	entry.TargetName = task.Title
+	return entry, nil
+}
+
+// newTemplateEntry starts an audit entry about a template
+func newTemplateEntry(template *domain.Template, actorID int64, action string) *domain.AuditEntry {
+	return &domain.AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		TemplateID: template.ID,
+		TargetName: template.Name,
+	}
+}
+
+// newTemplateTaskEntry starts an audit entry about a task of a template
+func newTemplateTaskEntry(task *domain.TemplateTask, actorID int64, action string) *domain.AuditEntry {
+	return &domain.AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		TemplateID: task.TemplateID,
+		TaskID:     task.ID,
+		TargetName: task.Title,
+	}
+}
+
+// AuditService reads the audit log and records repeat rate limit offenders in it
+type AuditService struct {
+	repo repository.Repository
+}
+
+// NewAuditService creates a new AuditService
+func NewAuditService(repo repository.Repository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// RecordRateLimited records that userID had throttled updates dropped by the rate limiter
+func (s *AuditService) RecordRateLimited(userID int64, throttled int) error {
+	return s.repo.Audit().Create(&domain.AuditEntry{
+		ActorID:      userID,
+		Action:       domain.AuditUserRateLimited,
+		TargetUserID: userID,
+		After:        strconv.Itoa(throttled),
+	})
+}
+
+// GetChallengePage returns one page of a challenge's audit log (newest first) and the total number of pages
+func (s *AuditService) GetChallengePage(challengeID string, page int) ([]*domain.AuditEntry, int, error) {
+	count, err := s.repo.Audit().CountByChallengeID(challengeID)
+	if err != nil {
+		return nil, 0, err
+	}
+	page, totalPages := auditPage(count, page)
+
+	entries, err := s.repo.Audit().GetByChallengeID(challengeID, domain.AuditLogPageSize, page*domain.AuditLogPageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, totalPages, nil
+}
+
+// GetGlobalPage returns one page of the whole audit log (newest first) and the total number of pages
+func (s *AuditService) GetGlobalPage(page int) ([]*domain.AuditEntry, int, error) {
+	count, err := s.repo.Audit().Count()
+	if err != nil {
+		return nil, 0, err
+	}
+	page, totalPages := auditPage(count, page)
+
+	entries, err := s.repo.Audit().GetAll(domain.AuditLogPageSize, page*domain.AuditLogPageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, totalPages, nil
+}
+
+// auditPage clamps page to the pages available for count entries
+func auditPage(count, page int) (int, int) {
+	totalPages := (count + domain.AuditLogPageSize - 1) / domain.AuditLogPageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+	return page, totalPages
+}
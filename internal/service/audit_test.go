@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestAuditService_RecordsChallengeChanges(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	auditSvc := NewAuditService(repo)
+
+	adminID := int64(12345)
+	superAdminID := int64(999)
+
+	challenge, _ := challengeSvc.Create("Old Name", "", adminID, 0, false)
+	task, _ := taskSvc.Create(challenge.ID, "Push-ups", "", "", adminID)
+
+	if err := challengeSvc.UpdateName(challenge.ID, "New Name", superAdminID, true); err != nil {
+		t.Fatalf("UpdateName() error = %v", err)
+	}
+	if err := taskSvc.Delete(task.ID, challenge.ID, adminID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	entries, totalPages, err := auditSvc.GetChallengePage(challenge.ID, 0)
+	if err != nil {
+		t.Fatalf("GetChallengePage() error = %v", err)
+	}
+	if totalPages != 1 || len(entries) != 3 {
+		t.Fatalf("GetChallengePage() = %d entries, %d pages, want 3, 1", len(entries), totalPages)
+	}
+
+	// Newest first
+	deleted := entries[0]
+	if deleted.Action != domain.AuditTaskDelete || deleted.TaskID != task.ID || deleted.TargetName != "Push-ups" {
+		t.Errorf("entries[0] = %+v, want deletion of Push-ups", deleted)
+	}
+	if deleted.Observer {
+		t.Error("Deletion by the creator should not be marked as observer")
+	}
+
+	renamed := entries[1]
+	if renamed.Action != domain.AuditChallengeRename || renamed.Before != "Old Name" || renamed.After != "New Name" {
+		t.Errorf("entries[1] = %+v, want rename Old Name → New Name", renamed)
+	}
+	if renamed.ActorID != superAdminID || !renamed.Observer {
+		t.Errorf("entries[1] actor = %d, observer = %v, want %d in observer mode", renamed.ActorID, renamed.Observer, superAdminID)
+	}
+}
+
+func TestAuditService_FailedChangeNotRecorded(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	auditSvc := NewAuditService(repo)
+
+	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
+
+	if err := challengeSvc.UpdateName(challenge.ID, "Hijacked", 777, false); err != ErrNotAdmin {
+		t.Fatalf("UpdateName() error = %v, want ErrNotAdmin", err)
+	}
+
+	entries, _, _ := auditSvc.GetChallengePage(challenge.ID, 0)
+	if len(entries) != 0 {
+		t.Errorf("Rejected change recorded %d entries, want 0", len(entries))
+	}
+}
+
+func TestAuditService_GlobalPagination(t *testing.T) {
+	repo := setupTestRepo(t)
+	superAdminSvc := NewSuperAdminService(repo)
+	auditSvc := NewAuditService(repo)
+
+	superAdminSvc.SeedFromEnv(1)
+	for i := 0; i < domain.AuditLogPageSize+2; i++ {
+		if err := superAdminSvc.Grant(1, int64(100+i)); err != nil {
+			t.Fatalf("Grant() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		page        int
+		wantEntries int
+	}{
+		{0, domain.AuditLogPageSize},
+		{1, 2},
+		{5, 2}, // clamped to the last page
+		{-1, domain.AuditLogPageSize},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("page %d", tt.page), func(t *testing.T) {
+			entries, totalPages, err := auditSvc.GetGlobalPage(tt.page)
+			if err != nil {
+				t.Fatalf("GetGlobalPage() error = %v", err)
+			}
+			if totalPages != 2 {
+				t.Errorf("totalPages = %d, want 2", totalPages)
+			}
+			if len(entries) != tt.wantEntries {
+				t.Errorf("len(entries) = %d, want %d", len(entries), tt.wantEntries)
+			}
+		})
+	}
+
+	entries, _, _ := auditSvc.GetGlobalPage(0)
+	newest := entries[0]
+	if newest.Action != domain.AuditSuperAdminGrant || newest.ActorID != 1 || newest.TargetUserID != int64(100+domain.AuditLogPageSize+1) {
+		t.Errorf("Newest entry = %+v, want the last grant", newest)
+	}
+}
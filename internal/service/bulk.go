@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// BulkTask is one task parsed from a pasted list
+type BulkTask struct {
+	Line        int // 1-based line number in the pasted text
+	Title       string
+	Description string
+}
+
+// BulkParseResult holds parsed tasks and per-line validation errors
+type BulkParseResult struct {
+	Tasks  []BulkTask
+	Errors []string
+}
+
+// listMarker matches Markdown list markers: "-", "*", "+", "1.", "1)" with an optional "[ ]"/"[x]" checkbox
+var listMarker = regexp.MustCompile(`^(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s+)?`)
+
+// ParseBulkTasks parses a pasted multi-line message into tasks
+//
+// Each non-empty line is a task; "Title | description" sets a description.
+// Markdown list markers are stripped, and in a Markdown list indented
+// lines without a marker continue the previous task's description.
+func ParseBulkTasks(text string) *BulkParseResult {
+	result := &BulkParseResult{}
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	isList := false
+	for _, line := range lines {
+		if listMarker.MatchString(strings.TrimSpace(line)) {
+			isList = true
+			break
+		}
+	}
+
+	for i, raw := range lines {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+
+		hasMarker := listMarker.MatchString(line)
+		indented := raw != strings.TrimLeft(raw, " \t")
+		if isList && !hasMarker && indented && len(result.Tasks) > 0 {
+			last := &result.Tasks[len(result.Tasks)-1]
+			last.Description = strings.TrimSpace(last.Description + "\n" + line)
+			continue
+		}
+
+		line = listMarker.ReplaceAllString(line, "")
+		title, description, _ := strings.Cut(line, "|")
+		result.Tasks = append(result.Tasks, BulkTask{
+			Line:        i + 1,
+			Title:       strings.TrimSpace(title),
+			Description: strings.TrimSpace(description),
+		})
+	}
+
+	for _, t := range result.Tasks {
+		switch {
+		case t.Title == "":
+			result.Errors = append(result.Errors, fmt.Sprintf("Line %d: title is empty", t.Line))
+		case utf8.RuneCountInString(t.Title) > domain.MaxTaskTitleLength:
+			result.Errors = append(result.Errors, fmt.Sprintf("Line %d: title is longer than %d characters", t.Line, domain.MaxTaskTitleLength))
+		}
+		if utf8.RuneCountInString(t.Description) > domain.MaxTaskDescriptionLength {
+			result.Errors = append(result.Errors, fmt.Sprintf("Line %d: description is longer than %d characters", t.Line, domain.MaxTaskDescriptionLength))
+		}
+	}
+
+	return result
+}
+
+// CheckLimit adds an error if the tasks don't fit next to existingCount tasks
+func (r *BulkParseResult) CheckLimit(existingCount int) {
+	if existingCount+len(r.Tasks) > domain.MaxTasksPerChallenge {
+		r.Errors = append(r.Errors, fmt.Sprintf(
+			"Too many tasks: %d existing + %d new is over the limit of %d",
+			existingCount, len(r.Tasks), domain.MaxTasksPerChallenge,
+		))
+	}
+}
+
+// Valid reports whether there is at least one task and no errors
+func (r *BulkParseResult) Valid() bool {
+	return len(r.Tasks) > 0 && len(r.Errors) == 0
+}
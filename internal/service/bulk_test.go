@@ -0,0 +1,144 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestParseBulkTasks(t *testing.T) {
+	text := "Morning run | 5 km easy\n\n  Drink water  \nRead | \n"
+
+	result := ParseBulkTasks(text)
+	if len(result.Errors) != 0 {
+		t.Fatalf("ParseBulkTasks() errors = %v", result.Errors)
+	}
+	if len(result.Tasks) != 3 {
+		t.Fatalf("ParseBulkTasks() returned %d tasks, want 3", len(result.Tasks))
+	}
+	if result.Tasks[0].Title != "Morning run" || result.Tasks[0].Description != "5 km easy" {
+		t.Errorf("Task 1 = %+v", result.Tasks[0])
+	}
+	if result.Tasks[1].Title != "Drink water" || result.Tasks[1].Line != 3 {
+		t.Errorf("Task 2 = %+v", result.Tasks[1])
+	}
+	if result.Tasks[2].Description != "" {
+		t.Errorf("Task 3 description = %q, want empty", result.Tasks[2].Description)
+	}
+}
+
+func TestParseBulkTasks_MarkdownList(t *testing.T) {
+	text := `- Run
+  5 km easy
+  keep heart rate low
+* [ ] Swim | 1 km
+1. Stretch
+2) Sleep early`
+
+	result := ParseBulkTasks(text)
+	if len(result.Tasks) != 4 {
+		t.Fatalf("ParseBulkTasks() returned %d tasks, want 4: %+v", len(result.Tasks), result.Tasks)
+	}
+
+	want := []struct{ title, desc string }{
+		{"Run", "5 km easy\nkeep heart rate low"},
+		{"Swim", "1 km"},
+		{"Stretch", ""},
+		{"Sleep early", ""},
+	}
+	for i, w := range want {
+		if result.Tasks[i].Title != w.title || result.Tasks[i].Description != w.desc {
+			t.Errorf("Task %d = %+v, want %q / %q", i+1, result.Tasks[i], w.title, w.desc)
+		}
+	}
+}
+
+func TestParseBulkTasks_Validation(t *testing.T) {
+	text := " | only description\n" + strings.Repeat("a", domain.MaxTaskTitleLength+1) + "\nOK"
+
+	result := ParseBulkTasks(text)
+	if len(result.Errors) != 2 {
+		t.Fatalf("ParseBulkTasks() errors = %v, want 2", result.Errors)
+	}
+	if !strings.Contains(result.Errors[0], "Line 1") || !strings.Contains(result.Errors[1], "Line 2") {
+		t.Errorf("Errors should reference line numbers: %v", result.Errors)
+	}
+	if result.Valid() {
+		t.Error("Valid() should be false with errors")
+	}
+
+	// Titles are limited by characters, not bytes
+	if r := ParseBulkTasks(strings.Repeat("я", domain.MaxTaskTitleLength)); !r.Valid() {
+		t.Errorf("Title of exactly %d characters should be valid: %v", domain.MaxTaskTitleLength, r.Errors)
+	}
+}
+
+func TestBulkParseResult_CheckLimit(t *testing.T) {
+	result := ParseBulkTasks("A\nB\nC")
+
+	result.CheckLimit(domain.MaxTasksPerChallenge - 3)
+	if !result.Valid() {
+		t.Errorf("Exactly reaching the limit should be valid: %v", result.Errors)
+	}
+
+	result.CheckLimit(domain.MaxTasksPerChallenge - 2)
+	if result.Valid() {
+		t.Error("Exceeding the limit should be invalid")
+	}
+
+	if ParseBulkTasks("\n  \n").Valid() {
+		t.Error("Empty input should be invalid")
+	}
+}
+
+func TestTaskService_CreateBulk(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	taskSvc.Create(challenge.ID, "Existing", "", "", challenge.CreatorID)
+
+	tasks, err := taskSvc.CreateBulk(challenge.ID, ParseBulkTasks("A | first\nB").Tasks, challenge.CreatorID)
+	if err != nil {
+		t.Fatalf("CreateBulk() error = %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].OrderNum != 2 || tasks[1].OrderNum != 3 {
+		t.Errorf("CreateBulk() order = %d, %d, want 2, 3", tasks[0].OrderNum, tasks[1].OrderNum)
+	}
+
+	var lines []string
+	for i := 0; i < domain.MaxTasksPerChallenge; i++ {
+		lines = append(lines, fmt.Sprintf("Task %d", i))
+	}
+	if _, err := taskSvc.CreateBulk(challenge.ID, ParseBulkTasks(strings.Join(lines, "\n")).Tasks, challenge.CreatorID); err != ErrMaxTasksReached {
+		t.Errorf("CreateBulk() over limit error = %v, want ErrMaxTasksReached", err)
+	}
+	if count, _ := taskSvc.CountByChallengeID(challenge.ID); count != 3 {
+		t.Errorf("Task count = %d, want 3", count)
+	}
+}
+
+func TestTemplateService_CreateTasksBulk(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	taskSvc.Create(challenge.ID, "Existing", "", "", challenge.CreatorID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
+
+	tasks, err := templateSvc.CreateTasksBulk(template.ID, ParseBulkTasks("- A\n- B | b").Tasks, 0)
+	if err != nil {
+		t.Fatalf("CreateTasksBulk() error = %v", err)
+	}
+	if len(tasks) != 2 || tasks[1].OrderNum != 3 || tasks[1].Description != "b" {
+		t.Errorf("CreateTasksBulk() = %+v", tasks[1])
+	}
+	if count, _ := templateSvc.GetTaskCount(template.ID); count != 3 {
+		t.Errorf("Template task count = %d, want 3", count)
+	}
+}
@@ -2,8 +2,10 @@ package service
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
 	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
 	"github.com/rgeraskin/squad-challenge-bot/internal/util"
 )
@@ -110,8 +112,12 @@ func (s *ChallengeService) UpdateName(
 		return ErrNotAdmin
 	}
 
+	entry := newChallengeEntry(challenge, userID, domain.AuditChallengeRename)
+	entry.Before, entry.After = challenge.Name, name
 	challenge.Name = name
-	return s.repo.Challenge().Update(challenge)
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		return []*domain.AuditEntry{entry}, repo.Challenge().Update(challenge)
+	})
 }
 
 // UpdateDescription updates a challenge's description (admin only)
@@ -130,8 +136,12 @@ func (s *ChallengeService) UpdateDescription(
 		return ErrNotAdmin
 	}
 
+	entry := newChallengeEntry(challenge, userID, domain.AuditChallengeDescription)
+	entry.Before, entry.After = challenge.Description, description
 	challenge.Description = description
-	return s.repo.Challenge().Update(challenge)
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		return []*domain.AuditEntry{entry}, repo.Challenge().Update(challenge)
+	})
 }
 
 // UpdateDailyLimit updates a challenge's daily task limit (admin only)
@@ -150,7 +160,11 @@ func (s *ChallengeService) UpdateDailyLimit(
 		return ErrNotAdmin
 	}
 
-	return s.repo.Challenge().UpdateDailyLimit(id, limit)
+	entry := newChallengeEntry(challenge, userID, domain.AuditChallengeDailyLimit)
+	entry.Before, entry.After = strconv.Itoa(challenge.DailyTaskLimit), strconv.Itoa(limit)
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		return []*domain.AuditEntry{entry}, repo.Challenge().UpdateDailyLimit(id, limit)
+	})
 }
 
 // UpdateHideFutureTasks updates the hide future tasks setting (admin only)
@@ -169,7 +183,11 @@ func (s *ChallengeService) UpdateHideFutureTasks(
 		return ErrNotAdmin
 	}
 
-	return s.repo.Challenge().UpdateHideFutureTasks(id, hide)
+	entry := newChallengeEntry(challenge, userID, domain.AuditChallengeSequential)
+	entry.Before, entry.After = strconv.FormatBool(challenge.HideFutureTasks), strconv.FormatBool(hide)
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		return []*domain.AuditEntry{entry}, repo.Challenge().UpdateHideFutureTasks(id, hide)
+	})
 }
 
 // ToggleHideFutureTasks toggles the hide future tasks setting and returns new value (admin only)
@@ -188,7 +206,35 @@ func (s *ChallengeService) ToggleHideFutureTasks(
 	}
 
 	newValue := !challenge.HideFutureTasks
-	err = s.repo.Challenge().UpdateHideFutureTasks(id, newValue)
+	entry := newChallengeEntry(challenge, userID, domain.AuditChallengeSequential)
+	entry.Before, entry.After = strconv.FormatBool(challenge.HideFutureTasks), strconv.FormatBool(newValue)
+	err = withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		return []*domain.AuditEntry{entry}, repo.Challenge().UpdateHideFutureTasks(id, newValue)
+	})
+	return newValue, err
+}
+
+// ToggleShuffleTasks toggles per-participant task order and returns new value (admin only)
+func (s *ChallengeService) ToggleShuffleTasks(
+	id string,
+	userID int64,
+	isSuperAdmin bool,
+) (bool, error) {
+	challenge, err := s.GetByID(id)
+	if err != nil {
+		return false, err
+	}
+
+	if challenge.CreatorID != userID && !isSuperAdmin {
+		return false, ErrNotAdmin
+	}
+
+	newValue := !challenge.ShuffleTasks
+	entry := newChallengeEntry(challenge, userID, domain.AuditChallengeShuffle)
+	entry.Before, entry.After = strconv.FormatBool(challenge.ShuffleTasks), strconv.FormatBool(newValue)
+	err = withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		return []*domain.AuditEntry{entry}, repo.Challenge().UpdateShuffleTasks(id, newValue)
+	})
 	return newValue, err
 }
 
@@ -203,7 +249,14 @@ func (s *ChallengeService) Delete(id string, userID int64, isSuperAdmin bool) er
 		return ErrNotAdmin
 	}
 
-	return s.repo.Challenge().Delete(id)
+	// Users still viewing the challenge go back to the start menu together with the delete
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		if err := repo.Challenge().Delete(id); err != nil {
+			return nil, err
+		}
+		entry := newChallengeEntry(challenge, userID, domain.AuditChallengeDelete)
+		return []*domain.AuditEntry{entry}, repo.State().ResetByChallenge(id)
+	})
 }
 
 // IsAdmin checks if a user is the admin of a challenge
@@ -292,26 +345,46 @@ func (s *ChallengeService) CreateFromTemplate(
 		CreatorID:       creatorID,
 		DailyTaskLimit:  template.DailyTaskLimit,
 		HideFutureTasks: template.HideFutureTasks,
+		TemplateID:      template.ID,
 	}
 
-	if err := s.repo.Challenge().Create(challenge); err != nil {
-		return nil, err
-	}
+	// Create the challenge and copy its tasks in one transaction so a failure
+	// never leaves a half-built challenge behind
+	err = s.repo.WithTx(func(repo repository.Repository) error {
+		// Record which template version the challenge was created from
+		latest, err := repo.TemplateVersion().GetLatest(template.ID)
+		if err != nil {
+			return err
+		}
+		if latest != nil {
+			challenge.TemplateVersion = latest.Version
+		}
 
-	// Copy tasks from template
-	for _, tt := range templateTasks {
-		task := &domain.Task{
-			ChallengeID: challenge.ID,
-			OrderNum:    tt.OrderNum,
-			Title:       tt.Title,
-			Description: tt.Description,
-			ImageFileID: tt.ImageFileID,
+		if err := repo.Challenge().Create(challenge); err != nil {
+			return err
 		}
-		if err := s.repo.Task().Create(task); err != nil {
-			// Rollback: delete challenge (tasks cascade)
-			s.repo.Challenge().Delete(challenge.ID)
-			return nil, err
+
+		tasks := make([]*domain.Task, len(templateTasks))
+		for i, tt := range templateTasks {
+			tasks[i] = &domain.Task{
+				ChallengeID:    challenge.ID,
+				OrderNum:       tt.OrderNum,
+				Title:          tt.Title,
+				Description:    tt.Description,
+				ImageFileID:    tt.ImageFileID,
+				TemplateTaskID: tt.ID,
+			}
 		}
+		return repo.Task().CreateBatch(tasks)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Usage count drives the "popular" sort in the template catalog; a failure here
+	// shouldn't fail the challenge creation
+	if err := s.repo.Template().IncrementUseCount(template.ID); err != nil {
+		logger.Warn("Failed to increment template use count", "template_id", template.ID, "error", err)
 	}
 
 	return challenge, nil
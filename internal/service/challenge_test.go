@@ -201,6 +201,48 @@ func TestChallengeService_Delete(t *testing.T) {
 	}
 }
 
+func TestChallengeService_Delete_ResetsViewers(t *testing.T) {
+	repo := setupTestRepo(t)
+	svc := NewChallengeService(repo)
+	stateSvc := NewStateService(repo)
+
+	challenge, _ := svc.Create("Test Challenge", "", 12345, 0, false)
+	stateSvc.SetCurrentChallenge(67890, challenge.ID)
+
+	if err := svc.Delete(challenge.ID, 12345, false); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	state, _ := stateSvc.Get(67890)
+	if state.CurrentChallenge != "" {
+		t.Errorf("CurrentChallenge after delete = %q, want empty", state.CurrentChallenge)
+	}
+}
+
+func TestChallengeService_CreateFromTemplate_RollsBack(t *testing.T) {
+	repo := setupTestRepo(t)
+	svc := NewChallengeService(repo)
+
+	template := &domain.Template{Name: "Broken"}
+	if err := repo.Template().Create(template); err != nil {
+		t.Fatalf("Template().Create() error = %v", err)
+	}
+	// Duplicate order numbers make the second task insert fail
+	tasks := []*domain.TemplateTask{
+		{TemplateID: template.ID, OrderNum: 1, Title: "A"},
+		{TemplateID: template.ID, OrderNum: 1, Title: "B"},
+	}
+
+	if _, err := svc.CreateFromTemplate(template, tasks, "Cohort", 67890); err == nil {
+		t.Fatal("CreateFromTemplate() should fail")
+	}
+
+	challenges, _ := svc.GetByUserID(67890)
+	if len(challenges) != 0 {
+		t.Errorf("GetByUserID() = %d challenges, want none left behind", len(challenges))
+	}
+}
+
 func TestChallengeService_Delete_NotAdmin(t *testing.T) {
 	repo := setupTestRepo(t)
 	svc := NewChallengeService(repo)
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrEmptyComment    = errors.New("comment cannot be empty")
+	ErrCommentTooLong  = errors.New("comment is too long")
+)
+
+// CommentService handles task comment business logic
+type CommentService struct {
+	repo repository.Repository
+}
+
+// NewCommentService creates a new CommentService
+func NewCommentService(repo repository.Repository) *CommentService {
+	return &CommentService{repo: repo}
+}
+
+// Add posts a new comment on a task
+func (s *CommentService) Add(taskID, participantID int64, text string) (*domain.TaskComment, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyComment
+	}
+	if utf8.RuneCountInString(text) > domain.MaxCommentLength {
+		return nil, ErrCommentTooLong
+	}
+
+	comment := &domain.TaskComment{
+		TaskID:        taskID,
+		ParticipantID: participantID,
+		Text:          text,
+	}
+	if err := s.repo.Comment().Create(comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// GetLatest returns the most recent comments on a task, newest first
+func (s *CommentService) GetLatest(taskID int64, limit int) ([]*domain.TaskComment, error) {
+	return s.repo.Comment().GetByTaskID(taskID, limit, 0)
+}
+
+// GetPage returns one page of comments (0-based, newest first) and the total page count
+func (s *CommentService) GetPage(taskID int64, page int) ([]*domain.TaskComment, int, error) {
+	count, err := s.repo.Comment().CountByTaskID(taskID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalPages := (count + domain.CommentsPageSize - 1) / domain.CommentsPageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+
+	comments, err := s.repo.Comment().GetByTaskID(taskID, domain.CommentsPageSize, page*domain.CommentsPageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, totalPages, nil
+}
+
+// Count returns the number of comments on a task
+func (s *CommentService) Count(taskID int64) (int, error) {
+	return s.repo.Comment().CountByTaskID(taskID)
+}
+
+// Delete removes a comment (challenge admin or super admin only)
+func (s *CommentService) Delete(commentID int64, userID int64, isSuperAdmin bool) error {
+	comment, err := s.repo.Comment().GetByID(commentID)
+	if err != nil {
+		return err
+	}
+	if comment == nil {
+		return ErrCommentNotFound
+	}
+
+	task, err := s.repo.Task().GetByID(comment.TaskID)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return ErrTaskNotFound
+	}
+
+	challenge, err := s.repo.Challenge().GetByID(task.ChallengeID)
+	if err != nil {
+		return err
+	}
+	if challenge == nil {
+		return ErrChallengeNotFound
+	}
+
+	if challenge.CreatorID != userID && !isSuperAdmin {
+		return ErrNotAdmin
+	}
+
+	return s.repo.Comment().Delete(commentID)
+}
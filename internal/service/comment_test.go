@@ -0,0 +1,118 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestCommentService_Add(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	commentSvc := NewCommentService(repo)
+
+	// Setup
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	participant, _ := participantSvc.Join(challenge.ID, 12345, "User", "💪", 0)
+
+	comment, err := commentSvc.Add(task.ID, participant.ID, "  Great task!  ")
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if comment.Text != "Great task!" {
+		t.Errorf("Add() Text = %q, want trimmed text", comment.Text)
+	}
+
+	// Empty comment
+	if _, err := commentSvc.Add(task.ID, participant.ID, "   "); err != ErrEmptyComment {
+		t.Errorf("Add() empty error = %v, want ErrEmptyComment", err)
+	}
+
+	// Too long comment
+	long := strings.Repeat("a", domain.MaxCommentLength+1)
+	if _, err := commentSvc.Add(task.ID, participant.ID, long); err != ErrCommentTooLong {
+		t.Errorf("Add() long error = %v, want ErrCommentTooLong", err)
+	}
+
+	count, _ := commentSvc.Count(task.ID)
+	if count != 1 {
+		t.Errorf("Count() = %d, want 1", count)
+	}
+}
+
+func TestCommentService_GetPage(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	commentSvc := NewCommentService(repo)
+
+	// Setup
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	participant, _ := participantSvc.Join(challenge.ID, 12345, "User", "💪", 0)
+
+	// No comments - still one (empty) page
+	comments, totalPages, err := commentSvc.GetPage(task.ID, 0)
+	if err != nil {
+		t.Fatalf("GetPage() error = %v", err)
+	}
+	if len(comments) != 0 || totalPages != 1 {
+		t.Errorf("GetPage() empty = (%d, %d), want (0, 1)", len(comments), totalPages)
+	}
+
+	for i := 0; i < domain.CommentsPageSize+2; i++ {
+		commentSvc.Add(task.ID, participant.ID, "hi")
+	}
+
+	comments, totalPages, _ = commentSvc.GetPage(task.ID, 0)
+	if len(comments) != domain.CommentsPageSize || totalPages != 2 {
+		t.Errorf("GetPage(0) = (%d, %d), want (%d, 2)", len(comments), totalPages, domain.CommentsPageSize)
+	}
+
+	// Out of range page is clamped to the last page
+	comments, _, _ = commentSvc.GetPage(task.ID, 5)
+	if len(comments) != 2 {
+		t.Errorf("GetPage(5) count = %d, want 2", len(comments))
+	}
+}
+
+func TestCommentService_Delete(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	commentSvc := NewCommentService(repo)
+
+	// Setup
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	participantSvc.Join(challenge.ID, 12345, "Admin", "💪", 0)
+	member, _ := participantSvc.Join(challenge.ID, 67890, "Member", "🔥", 0)
+	comment, _ := commentSvc.Add(task.ID, member.ID, "hello")
+
+	// Non-admin cannot delete
+	if err := commentSvc.Delete(comment.ID, 67890, false); err != ErrNotAdmin {
+		t.Errorf("Delete() by non-admin error = %v, want ErrNotAdmin", err)
+	}
+
+	// Super admin can delete
+	if err := commentSvc.Delete(comment.ID, 99999, true); err != nil {
+		t.Fatalf("Delete() by super admin error = %v", err)
+	}
+
+	// Already deleted
+	if err := commentSvc.Delete(comment.ID, 12345, false); err != ErrCommentNotFound {
+		t.Errorf("Delete() missing error = %v, want ErrCommentNotFound", err)
+	}
+
+	// Admin can delete
+	comment, _ = commentSvc.Add(task.ID, member.ID, "again")
+	if err := commentSvc.Delete(comment.ID, 12345, false); err != nil {
+		t.Errorf("Delete() by admin error = %v", err)
+	}
+}
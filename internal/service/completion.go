@@ -1,13 +1,17 @@
 package service
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
-	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
 	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
 )
 
+// ErrDailyLimitReached matches the *DailyLimitError returned by Complete
+var ErrDailyLimitReached = errors.New("daily task limit reached")
+
 // CompletionService handles task completion business logic
 type CompletionService struct {
 	repo repository.Repository
@@ -18,23 +22,54 @@ func NewCompletionService(repo repository.Repository) *CompletionService {
 	return &CompletionService{repo: repo}
 }
 
-// Complete marks a task as completed by a participant
+// Complete marks a task as completed by a participant.
+// The challenge's daily limit is checked and the completion recorded in one transaction,
+// so concurrent taps can't overshoot it; a *DailyLimitError is returned when the limit is reached
 func (s *CompletionService) Complete(taskID, participantID int64) (*domain.TaskCompletion, error) {
-	// Check if already completed
-	existing, err := s.repo.Completion().GetByTaskAndParticipant(taskID, participantID)
-	if err != nil {
-		return nil, err
-	}
-	if existing != nil {
-		return existing, nil // Already completed
-	}
+	var completion *domain.TaskCompletion
+	err := s.repo.WithTx(func(repo repository.Repository) error {
+		// Lock the participant so their completions are counted one at a time
+		participant, err := repo.Participant().GetByIDForUpdate(participantID)
+		if err != nil {
+			return err
+		}
+		if participant == nil {
+			return ErrParticipantNotFound
+		}
 
-	completion := &domain.TaskCompletion{
-		TaskID:        taskID,
-		ParticipantID: participantID,
-	}
+		// Check if already completed
+		existing, err := repo.Completion().GetByTaskAndParticipant(taskID, participantID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			completion = existing // Already completed
+			return nil
+		}
+
+		challenge, err := repo.Challenge().GetByID(participant.ChallengeID)
+		if err != nil {
+			return err
+		}
+		if challenge == nil {
+			return ErrChallengeNotFound
+		}
+
+		info, err := dailyLimitInfo(repo, participant, challenge.DailyTaskLimit)
+		if err != nil {
+			return err
+		}
+		if !info.Allowed {
+			return &DailyLimitError{Info: info}
+		}
 
-	if err := s.repo.Completion().Create(completion); err != nil {
+		completion = &domain.TaskCompletion{
+			TaskID:        taskID,
+			ParticipantID: participantID,
+		}
+		return repo.Completion().Create(completion)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -65,6 +100,11 @@ func (s *CompletionService) GetCompletionsByTaskID(taskID int64) ([]*domain.Task
 	return s.repo.Completion().GetByTaskID(taskID)
 }
 
+// GetByParticipantID returns a participant's completions of live tasks, oldest first
+func (s *CompletionService) GetByParticipantID(participantID int64) ([]*domain.TaskCompletion, error) {
+	return s.repo.Completion().GetByParticipantID(participantID)
+}
+
 // CountByParticipantID returns the number of completed tasks for a participant
 func (s *CompletionService) CountByParticipantID(participantID int64) (int, error) {
 	return s.repo.Completion().CountByParticipantID(participantID)
@@ -132,6 +172,20 @@ type DailyLimitInfo struct {
 	UserLocalTime time.Time
 }
 
+// DailyLimitError is returned by Complete when the participant has reached the daily limit
+type DailyLimitError struct {
+	Info *DailyLimitInfo
+}
+
+func (e *DailyLimitError) Error() string {
+	return fmt.Sprintf("daily limit reached: %d/%d", e.Info.Completed, e.Info.Limit)
+}
+
+// Is makes errors.Is(err, ErrDailyLimitReached) match a *DailyLimitError
+func (e *DailyLimitError) Is(target error) bool {
+	return target == ErrDailyLimitReached
+}
+
 // GetUserDayBoundaries calculates the start and end of user's current day
 func GetUserDayBoundaries(offsetMinutes int) (start, end time.Time) {
 	now := time.Now().UTC()
@@ -160,22 +214,13 @@ func TimeUntilUserMidnight(offsetMinutes int) time.Duration {
 	return time.Until(dayEnd)
 }
 
-// GetCompletionsToday returns the number of completions for today (user's day)
-func (s *CompletionService) GetCompletionsToday(participantID int64, offsetMinutes int) (int, error) {
-	dayStart, dayEnd := GetUserDayBoundaries(offsetMinutes)
-	logger.Debug("GetCompletionsToday",
-		"participant_id", participantID,
-		"offset_minutes", offsetMinutes,
-		"day_start", dayStart,
-		"day_end", dayEnd,
-	)
-	count, err := s.repo.Completion().CountCompletionsInRange(participantID, dayStart, dayEnd)
-	logger.Debug("GetCompletionsToday result", "count", count, "error", err)
-	return count, err
-}
-
 // CheckDailyLimit checks if a participant can complete more tasks today
 func (s *CompletionService) CheckDailyLimit(participant *domain.Participant, dailyLimit int) (*DailyLimitInfo, error) {
+	return dailyLimitInfo(s.repo, participant, dailyLimit)
+}
+
+// dailyLimitInfo counts a participant's completions for their current day against dailyLimit
+func dailyLimitInfo(repo repository.Repository, participant *domain.Participant, dailyLimit int) (*DailyLimitInfo, error) {
 	info := &DailyLimitInfo{
 		Limit:         dailyLimit,
 		UserLocalTime: GetUserLocalTime(participant.TimeOffsetMinutes),
@@ -187,7 +232,8 @@ func (s *CompletionService) CheckDailyLimit(participant *domain.Participant, dai
 		return info, nil
 	}
 
-	completed, err := s.GetCompletionsToday(participant.ID, participant.TimeOffsetMinutes)
+	dayStart, dayEnd := GetUserDayBoundaries(participant.TimeOffsetMinutes)
+	completed, err := repo.Completion().CountCompletionsInRange(participant.ID, dayStart, dayEnd)
 	if err != nil {
 		return nil, err
 	}
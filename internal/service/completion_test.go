@@ -1,6 +1,9 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
@@ -15,7 +18,7 @@ func TestCompletionService_Complete(t *testing.T) {
 
 	// Setup
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
-	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "")
+	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
 	participant, _ := participantSvc.Join(challenge.ID, 12345, "User", "💪", 0)
 
 	// Complete task
@@ -46,7 +49,7 @@ func TestCompletionService_Uncomplete(t *testing.T) {
 
 	// Setup
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
-	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "")
+	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
 	participant, _ := participantSvc.Join(challenge.ID, 12345, "User", "💪", 0)
 
 	// Complete then uncomplete
@@ -72,9 +75,9 @@ func TestCompletionService_GetCurrentTaskNum(t *testing.T) {
 
 	// Setup
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
-	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "")
-	taskSvc.Create(challenge.ID, "Task 2", "", "")
-	task3, _ := taskSvc.Create(challenge.ID, "Task 3", "", "")
+	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	task3, _ := taskSvc.Create(challenge.ID, "Task 3", "", "", challenge.CreatorID)
 	participant, _ := participantSvc.Join(challenge.ID, 12345, "User", "💪", 0)
 
 	tasks, _ := taskSvc.GetByChallengeID(challenge.ID)
@@ -109,8 +112,8 @@ func TestCompletionService_IsAllCompleted(t *testing.T) {
 
 	// Setup
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
-	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "")
-	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "")
+	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
 	participant, _ := participantSvc.Join(challenge.ID, 12345, "User", "💪", 0)
 
 	// Not all completed
@@ -155,7 +158,7 @@ func TestCompletionService_GetCompletionsByTaskID(t *testing.T) {
 
 	// Setup
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
-	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "")
+	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
 	p1, _ := participantSvc.Join(challenge.ID, 12345, "User1", "💪", 0)
 	p2, _ := participantSvc.Join(challenge.ID, 67890, "User2", "🔥", 0)
 
@@ -181,9 +184,9 @@ func TestCompletionService_GetCompletedTaskIDs(t *testing.T) {
 
 	// Setup
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
-	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "")
-	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "")
-	taskSvc.Create(challenge.ID, "Task 3", "", "")
+	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 3", "", "", challenge.CreatorID)
 	participant, _ := participantSvc.Join(challenge.ID, 12345, "User", "💪", 0)
 
 	// Complete tasks 1 and 2
@@ -198,3 +201,123 @@ func TestCompletionService_GetCompletedTaskIDs(t *testing.T) {
 		t.Errorf("GetCompletedTaskIDs() count = %d, want 2", len(taskIDs))
 	}
 }
+
+func TestCompletionService_Complete_DailyLimit(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 1, false)
+	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	participant, _ := participantSvc.Join(challenge.ID, 12345, "User", "💪", 0)
+
+	if _, err := completionSvc.Complete(task1.ID, participant.ID); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	_, err := completionSvc.Complete(task2.ID, participant.ID)
+	var limitErr *DailyLimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("Complete() over the limit: error = %v, want *DailyLimitError", err)
+	}
+	if !errors.Is(err, ErrDailyLimitReached) {
+		t.Error("errors.Is(err, ErrDailyLimitReached) = false, want true")
+	}
+	if limitErr.Info.Completed != 1 || limitErr.Info.Limit != 1 || limitErr.Info.Allowed {
+		t.Errorf("DailyLimitError.Info = %+v", limitErr.Info)
+	}
+	if done, _ := completionSvc.IsCompleted(task2.ID, participant.ID); done {
+		t.Error("task over the limit should not be completed")
+	}
+
+	// Re-completing an already completed task is not blocked by the limit
+	if _, err := completionSvc.Complete(task1.ID, participant.ID); err != nil {
+		t.Errorf("Complete() of a completed task: error = %v, want nil", err)
+	}
+}
+
+func TestCompletionService_Complete_DailyLimitConcurrent(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+
+	const limit = 3
+	const workers = 20
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, limit, false)
+	var tasks []*domain.Task
+	for i := 0; i < workers; i++ {
+		task, _ := taskSvc.Create(challenge.ID, fmt.Sprintf("Task %d", i+1), "", "", challenge.CreatorID)
+		tasks = append(tasks, task)
+	}
+	participant, _ := participantSvc.Join(challenge.ID, 12345, "User", "💪", 0)
+
+	// Every goroutine taps a different task at the same time
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	completed, limited := 0, 0
+	start := make(chan struct{})
+	for _, task := range tasks {
+		wg.Add(1)
+		go func(taskID int64) {
+			defer wg.Done()
+			<-start
+			_, err := completionSvc.Complete(taskID, participant.ID)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				completed++
+			case errors.Is(err, ErrDailyLimitReached):
+				limited++
+			default:
+				t.Errorf("Complete() error = %v", err)
+			}
+		}(task.ID)
+	}
+	close(start)
+	wg.Wait()
+
+	if completed != limit || limited != workers-limit {
+		t.Errorf("completed = %d, limited = %d, want %d and %d", completed, limited, limit, workers-limit)
+	}
+	if count, _ := completionSvc.CountByParticipantID(participant.ID); count != limit {
+		t.Errorf("CountByParticipantID() = %d, want %d", count, limit)
+	}
+}
+
+func TestCompletionService_Complete_SameTaskConcurrent(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 1, false)
+	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	participant, _ := participantSvc.Join(challenge.ID, 12345, "User", "💪", 0)
+
+	// Double (and triple...) taps on the same task all succeed with a single completion
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if _, err := completionSvc.Complete(task.ID, participant.ID); err != nil {
+				t.Errorf("Complete() error = %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if count, _ := completionSvc.CountByParticipantID(participant.ID); count != 1 {
+		t.Errorf("CountByParticipantID() = %d, want 1", count)
+	}
+}
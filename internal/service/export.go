@@ -0,0 +1,117 @@
+package service
+
+import (
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+// ExportRow is one participant × task line of a challenge export
+type ExportRow struct {
+	ParticipantName string
+	Emoji           string
+	TelegramID      int64
+	TaskNum         int
+	TaskTitle       string
+	CompletedAt     *time.Time // participant's local time, nil if not completed
+}
+
+// ExportSummary is one participant's totals in a challenge export
+type ExportSummary struct {
+	ParticipantName string
+	Emoji           string
+	TelegramID      int64
+	JoinedAt        time.Time // participant's local time
+	CompletedTasks  int
+	TotalTasks      int
+	LastCompletedAt *time.Time // participant's local time, nil if nothing completed
+}
+
+// ChallengeExport holds all data of a challenge export
+type ChallengeExport struct {
+	Challenge *domain.Challenge
+	Rows      []ExportRow
+	Summary   []ExportSummary
+}
+
+// ExportService builds completion data exports
+type ExportService struct {
+	repo repository.Repository
+}
+
+// NewExportService creates a new ExportService
+func NewExportService(repo repository.Repository) *ExportService {
+	return &ExportService{repo: repo}
+}
+
+// Build collects completion data for a challenge (admin or super admin only)
+// Timestamps are converted to each participant's local time
+func (s *ExportService) Build(challengeID string, userID int64, isSuperAdmin bool) (*ChallengeExport, error) {
+	challenge, err := s.repo.Challenge().GetByID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil {
+		return nil, ErrChallengeNotFound
+	}
+	if challenge.CreatorID != userID && !isSuperAdmin {
+		return nil, ErrNotAdmin
+	}
+
+	tasks, err := s.repo.Task().GetByChallengeID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.repo.Participant().GetByChallengeID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &ChallengeExport{Challenge: challenge}
+	for _, p := range participants {
+		completions, err := s.repo.Completion().GetByParticipantID(p.ID)
+		if err != nil {
+			return nil, err
+		}
+		completedAt := make(map[int64]time.Time, len(completions))
+		for _, c := range completions {
+			completedAt[c.TaskID] = toLocal(c.CompletedAt, p.TimeOffsetMinutes)
+		}
+
+		summary := ExportSummary{
+			ParticipantName: p.DisplayName,
+			Emoji:           p.Emoji,
+			TelegramID:      p.TelegramID,
+			JoinedAt:        toLocal(p.JoinedAt, p.TimeOffsetMinutes),
+			TotalTasks:      len(tasks),
+		}
+
+		for _, t := range tasks {
+			row := ExportRow{
+				ParticipantName: p.DisplayName,
+				Emoji:           p.Emoji,
+				TelegramID:      p.TelegramID,
+				TaskNum:         t.OrderNum,
+				TaskTitle:       t.Title,
+			}
+			if at, ok := completedAt[t.ID]; ok {
+				row.CompletedAt = &at
+				summary.CompletedTasks++
+				if summary.LastCompletedAt == nil || at.After(*summary.LastCompletedAt) {
+					summary.LastCompletedAt = &at
+				}
+			}
+			export.Rows = append(export.Rows, row)
+		}
+
+		export.Summary = append(export.Summary, summary)
+	}
+
+	return export, nil
+}
+
+// toLocal shifts a timestamp into a participant's local wall-clock time
+func toLocal(t time.Time, offsetMinutes int) time.Time {
+	return t.UTC().Add(time.Duration(offsetMinutes) * time.Minute)
+}
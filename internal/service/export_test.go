@@ -0,0 +1,82 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestExportService_Build(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+	exportSvc := NewExportService(repo)
+
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	p1, _ := participantSvc.Join(challenge.ID, 12345, "User1", "💪", 120)
+	participantSvc.Join(challenge.ID, 67890, "User2", "🔥", 0)
+
+	completionSvc.Complete(task1.ID, p1.ID)
+
+	data, err := exportSvc.Build(challenge.ID, 12345, false)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if len(data.Rows) != 4 {
+		t.Errorf("Rows count = %d, want 4 (2 participants × 2 tasks)", len(data.Rows))
+	}
+	if len(data.Summary) != 2 {
+		t.Fatalf("Summary count = %d, want 2", len(data.Summary))
+	}
+
+	completedRows := 0
+	for _, r := range data.Rows {
+		if r.CompletedAt != nil {
+			completedRows++
+			if r.TelegramID != 12345 || r.TaskNum != 1 {
+				t.Errorf("Unexpected completed row: %+v", r)
+			}
+		}
+	}
+	if completedRows != 1 {
+		t.Errorf("Completed rows = %d, want 1", completedRows)
+	}
+
+	// Completion time is shifted to the participant's local time
+	completion, _ := repo.Completion().GetByTaskAndParticipant(task1.ID, p1.ID)
+	for _, s := range data.Summary {
+		if s.TelegramID != 12345 {
+			continue
+		}
+		if s.CompletedTasks != 1 || s.TotalTasks != 2 {
+			t.Errorf("Summary = %d/%d, want 1/2", s.CompletedTasks, s.TotalTasks)
+		}
+		if s.LastCompletedAt == nil {
+			t.Fatal("LastCompletedAt should be set")
+		}
+		if diff := s.LastCompletedAt.Sub(completion.CompletedAt.UTC()); diff.Minutes() != 120 {
+			t.Errorf("LastCompletedAt offset = %v, want 2h", diff)
+		}
+	}
+}
+
+func TestExportService_Build_Permissions(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	exportSvc := NewExportService(repo)
+
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+
+	if _, err := exportSvc.Build(challenge.ID, 67890, false); err != ErrNotAdmin {
+		t.Errorf("Build() by non-admin error = %v, want ErrNotAdmin", err)
+	}
+	if _, err := exportSvc.Build(challenge.ID, 67890, true); err != nil {
+		t.Errorf("Build() by super admin error = %v", err)
+	}
+	if _, err := exportSvc.Build("missing", 12345, true); err != ErrChallengeNotFound {
+		t.Errorf("Build() for missing challenge error = %v, want ErrChallengeNotFound", err)
+	}
+}
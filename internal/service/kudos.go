@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+var (
+	ErrInvalidKudos     = errors.New("invalid kudos reaction")
+	ErrSelfKudos        = errors.New("cannot send kudos to yourself")
+	ErrKudosAlreadySent = errors.New("kudos already sent for this completion")
+	ErrTaskNotCompleted = errors.New("task is not completed")
+)
+
+// KudosService handles kudos business logic
+type KudosService struct {
+	repo repository.Repository
+}
+
+// NewKudosService creates a new KudosService
+func NewKudosService(repo repository.Repository) *KudosService {
+	return &KudosService{repo: repo}
+}
+
+// Send records kudos from one participant for another's task completion
+// Both participants must be in the same challenge and the task must be completed by the receiver
+func (s *KudosService) Send(taskID, fromParticipantID, toParticipantID int64, emoji string) (*domain.Kudos, error) {
+	if !domain.IsKudosEmoji(emoji) {
+		return nil, ErrInvalidKudos
+	}
+	if fromParticipantID == toParticipantID {
+		return nil, ErrSelfKudos
+	}
+
+	from, err := s.repo.Participant().GetByID(fromParticipantID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.repo.Participant().GetByID(toParticipantID)
+	if err != nil {
+		return nil, err
+	}
+	if from == nil || to == nil || from.ChallengeID != to.ChallengeID {
+		return nil, ErrParticipantNotFound
+	}
+
+	completion, err := s.repo.Completion().GetByTaskAndParticipant(taskID, toParticipantID)
+	if err != nil {
+		return nil, err
+	}
+	if completion == nil {
+		return nil, ErrTaskNotCompleted
+	}
+
+	exists, err := s.repo.Kudos().Exists(taskID, fromParticipantID, toParticipantID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrKudosAlreadySent
+	}
+
+	kudos := &domain.Kudos{
+		TaskID:            taskID,
+		FromParticipantID: fromParticipantID,
+		ToParticipantID:   toParticipantID,
+		Emoji:             emoji,
+	}
+	if err := s.repo.Kudos().Create(kudos); err != nil {
+		return nil, err
+	}
+	return kudos, nil
+}
+
+// CountReceived returns the number of kudos a participant has received
+func (s *KudosService) CountReceived(participantID int64) (int, error) {
+	return s.repo.Kudos().CountByReceiver(participantID)
+}
+
+// CountReceivedByChallenge returns kudos received per participant ID in a challenge
+func (s *KudosService) CountReceivedByChallenge(challengeID string) (map[int64]int, error) {
+	return s.repo.Kudos().CountByChallengeID(challengeID)
+}
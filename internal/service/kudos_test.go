@@ -0,0 +1,71 @@
+package service
+
+import "testing"
+
+func TestKudosService_Send(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+	kudosSvc := NewKudosService(repo)
+
+	// Setup
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	p1, _ := participantSvc.Join(challenge.ID, 12345, "User1", "💪", 0)
+	p2, _ := participantSvc.Join(challenge.ID, 67890, "User2", "🔥", 0)
+
+	// Task not completed yet
+	if _, err := kudosSvc.Send(task.ID, p1.ID, p2.ID, "👏"); err != ErrTaskNotCompleted {
+		t.Errorf("Send() before completion error = %v, want ErrTaskNotCompleted", err)
+	}
+
+	completionSvc.Complete(task.ID, p2.ID)
+
+	// Invalid emoji
+	if _, err := kudosSvc.Send(task.ID, p1.ID, p2.ID, "🍕"); err != ErrInvalidKudos {
+		t.Errorf("Send() invalid emoji error = %v, want ErrInvalidKudos", err)
+	}
+
+	// Self kudos
+	if _, err := kudosSvc.Send(task.ID, p2.ID, p2.ID, "👏"); err != ErrSelfKudos {
+		t.Errorf("Send() to self error = %v, want ErrSelfKudos", err)
+	}
+
+	// Valid kudos
+	if _, err := kudosSvc.Send(task.ID, p1.ID, p2.ID, "👏"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	// Only once per completion
+	if _, err := kudosSvc.Send(task.ID, p1.ID, p2.ID, "🔥"); err != ErrKudosAlreadySent {
+		t.Errorf("Send() twice error = %v, want ErrKudosAlreadySent", err)
+	}
+
+	count, _ := kudosSvc.CountReceived(p2.ID)
+	if count != 1 {
+		t.Errorf("CountReceived() = %d, want 1", count)
+	}
+}
+
+func TestKudosService_Send_DifferentChallenges(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+	kudosSvc := NewKudosService(repo)
+
+	// Setup: two separate challenges
+	c1, _ := challengeSvc.Create("Challenge 1", "", 12345, 0, false)
+	c2, _ := challengeSvc.Create("Challenge 2", "", 67890, 0, false)
+	task, _ := taskSvc.Create(c1.ID, "Task 1", "", "", c1.CreatorID)
+	p1, _ := participantSvc.Join(c1.ID, 12345, "User1", "💪", 0)
+	outsider, _ := participantSvc.Join(c2.ID, 67890, "User2", "🔥", 0)
+	completionSvc.Complete(task.ID, p1.ID)
+
+	if _, err := kudosSvc.Send(task.ID, outsider.ID, p1.ID, "👏"); err != ErrParticipantNotFound {
+		t.Errorf("Send() across challenges error = %v, want ErrParticipantNotFound", err)
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"fmt"
 
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 	"github.com/rgeraskin/squad-challenge-bot/internal/logger"
 	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
 	tele "gopkg.in/telebot.v3"
@@ -57,7 +58,8 @@ func (s *NotificationService) NotifyJoin(challengeID string, joinerEmoji, joiner
 }
 
 // NotifyTaskCompleted notifies all participants that someone completed a task
-func (s *NotificationService) NotifyTaskCompleted(challengeID string, completerEmoji, completerName, taskTitle string, excludeUserID int64) {
+// kudosMarkup (optional) is attached to each notification so recipients can react
+func (s *NotificationService) NotifyTaskCompleted(challengeID string, completerEmoji, completerName, taskTitle string, excludeUserID int64, kudosMarkup *tele.ReplyMarkup) {
 	participants, err := s.repo.Participant().GetByChallengeID(challengeID)
 	if err != nil {
 		logger.Error("NotifyTaskCompleted: failed to get participants", "challenge_id", challengeID, "error", err)
@@ -70,12 +72,102 @@ func (s *NotificationService) NotifyTaskCompleted(challengeID string, completerE
 		if p.TelegramID == excludeUserID || !p.NotifyEnabled {
 			continue
 		}
-		if _, err := s.bot.Send(TelegramUser{ID: p.TelegramID}, message); err != nil {
+		var opts []interface{}
+		if kudosMarkup != nil {
+			opts = append(opts, kudosMarkup)
+		}
+		if _, err := s.bot.Send(TelegramUser{ID: p.TelegramID}, message, opts...); err != nil {
 			logger.Warn("NotifyTaskCompleted: failed to send", "telegram_id", p.TelegramID, "error", err)
 		}
 	}
 }
 
+// NotifyTaskComment notifies participants who completed a task that someone commented on it
+func (s *NotificationService) NotifyTaskComment(taskID int64, commenterEmoji, commenterName, taskTitle, text string, excludeUserID int64) {
+	completions, err := s.repo.Completion().GetByTaskID(taskID)
+	if err != nil {
+		logger.Error("NotifyTaskComment: failed to get completions", "task_id", taskID, "error", err)
+		return
+	}
+
+	message := fmt.Sprintf("💬 %s %s commented on \"%s\":\n\n%s", commenterEmoji, commenterName, taskTitle, text)
+
+	for _, comp := range completions {
+		p, err := s.repo.Participant().GetByID(comp.ParticipantID)
+		if err != nil || p == nil {
+			continue
+		}
+		if p.TelegramID == excludeUserID || !p.NotifyEnabled {
+			continue
+		}
+		if _, err := s.bot.Send(TelegramUser{ID: p.TelegramID}, message); err != nil {
+			logger.Warn("NotifyTaskComment: failed to send", "telegram_id", p.TelegramID, "error", err)
+		}
+	}
+}
+
+// NotifyKudos relays a kudos reaction to the participant who completed the task
+func (s *NotificationService) NotifyKudos(toParticipantID int64, senderEmoji, senderName, kudosEmoji, taskTitle string) {
+	p, err := s.repo.Participant().GetByID(toParticipantID)
+	if err != nil || p == nil {
+		logger.Error("NotifyKudos: failed to get participant", "participant_id", toParticipantID, "error", err)
+		return
+	}
+	if !p.NotifyEnabled {
+		return
+	}
+
+	message := fmt.Sprintf("%s %s %s sent you kudos for \"%s\"!", kudosEmoji, senderEmoji, senderName, taskTitle)
+	if _, err := s.bot.Send(TelegramUser{ID: p.TelegramID}, message); err != nil {
+		logger.Warn("NotifyKudos: failed to send", "telegram_id", p.TelegramID, "error", err)
+	}
+}
+
+// NotifyNudge sends a nudge from a teammate, with an optional button to open the recipient's current task
+func (s *NotificationService) NotifyNudge(toUserID int64, senderEmoji, senderName, challengeName string, markup *tele.ReplyMarkup) {
+	message := fmt.Sprintf("👋 %s %s nudged you in \"%s\"!\n\nYour squad is waiting — time to knock out your next task 💪", senderEmoji, senderName, challengeName)
+
+	var opts []interface{}
+	if markup != nil {
+		opts = append(opts, markup)
+	}
+	if _, err := s.bot.Send(TelegramUser{ID: toUserID}, message, opts...); err != nil {
+		logger.Warn("NotifyNudge: failed to send", "telegram_id", toUserID, "error", err)
+	}
+}
+
+// NotifyTemplateUpdate offers a challenge admin a pending update from the challenge's source template
+func (s *NotificationService) NotifyTemplateUpdate(toUserID int64, preview string, markup *tele.ReplyMarkup) {
+	if _, err := s.bot.Send(TelegramUser{ID: toUserID}, preview, markup, tele.ModeHTML); err != nil {
+		logger.Warn("NotifyTemplateUpdate: failed to send", "telegram_id", toUserID, "error", err)
+	}
+}
+
+// NotifyAchievement announces a new badge to the earner and to the rest of the squad
+func (s *NotificationService) NotifyAchievement(challengeID string, earnerID int64, earnerEmoji, earnerName string, badge domain.AchievementInfo) {
+	personal := fmt.Sprintf("🏅 New badge unlocked: %s <b>%s</b>\n<i>%s</i>", badge.Emoji, badge.Title, badge.Description)
+	if _, err := s.bot.Send(TelegramUser{ID: earnerID}, personal, tele.ModeHTML); err != nil {
+		logger.Warn("NotifyAchievement: failed to send", "telegram_id", earnerID, "error", err)
+	}
+
+	participants, err := s.repo.Participant().GetByChallengeID(challengeID)
+	if err != nil {
+		logger.Error("NotifyAchievement: failed to get participants", "challenge_id", challengeID, "error", err)
+		return
+	}
+
+	message := fmt.Sprintf("🏅 %s %s earned the %s %s badge!", earnerEmoji, earnerName, badge.Emoji, badge.Title)
+
+	for _, p := range participants {
+		if p.TelegramID == earnerID || !p.NotifyEnabled {
+			continue
+		}
+		if _, err := s.bot.Send(TelegramUser{ID: p.TelegramID}, message); err != nil {
+			logger.Warn("NotifyAchievement: failed to send", "telegram_id", p.TelegramID, "error", err)
+		}
+	}
+}
+
 // NotifyChallengeCompleted notifies all participants that someone finished the challenge
 func (s *NotificationService) NotifyChallengeCompleted(challengeID string, completerEmoji, completerName string, excludeUserID int64) {
 	participants, err := s.repo.Participant().GetByChallengeID(challengeID)
@@ -140,13 +232,9 @@ func (s *NotificationService) GetParticipantsForDeletion(challengeID string) []i
 }
 
 // NotifyChallengeDeletedAsync sends deletion notifications to participants
-// This should be called AFTER the challenge is deleted, with participant IDs fetched beforehand
+// This should be called AFTER the challenge is deleted, with participant IDs fetched beforehand.
+// ChallengeService.Delete has already moved users viewing the challenge back to the start menu
 func (s *NotificationService) NotifyChallengeDeletedAsync(challengeID, challengeName string, participantIDs []int64, excludeUserID int64) {
-	// Reset state for all users viewing this challenge (moves them to /start menu)
-	if err := s.repo.State().ResetByChallenge(challengeID); err != nil {
-		logger.Error("NotifyChallengeDeletedAsync: failed to reset user states", "challenge_id", challengeID, "error", err)
-	}
-
 	message := fmt.Sprintf("❌ Challenge \"%s\" has been deleted by admin.\n\nUse /start to return to main menu.", challengeName)
 
 	for _, telegramID := range participantIDs {
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+var (
+	ErrSelfNudge      = errors.New("cannot nudge yourself")
+	ErrNudgeTooSoon   = errors.New("nudged this teammate too recently")
+	ErrNudgesMuted    = errors.New("teammate has notifications turned off")
+	ErrNothingToNudge = errors.New("teammate has already completed all tasks")
+)
+
+// NudgeService handles nudges between teammates
+type NudgeService struct {
+	repo repository.Repository
+}
+
+// NewNudgeService creates a new NudgeService
+func NewNudgeService(repo repository.Repository) *NudgeService {
+	return &NudgeService{repo: repo}
+}
+
+// Send records a nudge from one participant to another in the same challenge
+// Nudges are rate-limited per sender/recipient pair and respect the recipient's notification setting
+func (s *NudgeService) Send(fromParticipantID, toParticipantID int64) (*domain.Nudge, error) {
+	if fromParticipantID == toParticipantID {
+		return nil, ErrSelfNudge
+	}
+
+	from, err := s.repo.Participant().GetByID(fromParticipantID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.repo.Participant().GetByID(toParticipantID)
+	if err != nil {
+		return nil, err
+	}
+	if from == nil || to == nil || from.ChallengeID != to.ChallengeID {
+		return nil, ErrParticipantNotFound
+	}
+
+	if !to.NotifyEnabled {
+		return nil, ErrNudgesMuted
+	}
+
+	// Nothing to nudge about if the teammate is already done
+	completed, err := s.repo.Completion().CountByParticipantID(to.ID)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repo.Task().CountByChallengeID(to.ChallengeID)
+	if err != nil {
+		return nil, err
+	}
+	if total > 0 && completed >= total {
+		return nil, ErrNothingToNudge
+	}
+
+	wait, err := s.CooldownRemaining(fromParticipantID, toParticipantID)
+	if err != nil {
+		return nil, err
+	}
+	if wait > 0 {
+		return nil, ErrNudgeTooSoon
+	}
+
+	nudge := &domain.Nudge{
+		FromParticipantID: fromParticipantID,
+		ToParticipantID:   toParticipantID,
+	}
+	if err := s.repo.Nudge().Create(nudge); err != nil {
+		return nil, err
+	}
+	return nudge, nil
+}
+
+// CooldownRemaining returns how long the sender must wait before nudging the recipient again
+func (s *NudgeService) CooldownRemaining(fromParticipantID, toParticipantID int64) (time.Duration, error) {
+	last, err := s.repo.Nudge().GetLast(fromParticipantID, toParticipantID)
+	if err != nil {
+		return 0, err
+	}
+	if last == nil {
+		return 0, nil
+	}
+
+	remaining := domain.NudgeCooldown - time.Since(last.CreatedAt)
+	if remaining < 0 {
+		return 0, nil
+	}
+	return remaining, nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import "testing"
+
+func TestNudgeService_Send(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	nudgeSvc := NewNudgeService(repo)
+
+	// Setup
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	p1, _ := participantSvc.Join(challenge.ID, 12345, "User1", "💪", 0)
+	p2, _ := participantSvc.Join(challenge.ID, 67890, "User2", "🔥", 0)
+
+	// Self nudge
+	if _, err := nudgeSvc.Send(p1.ID, p1.ID); err != ErrSelfNudge {
+		t.Errorf("Send() to self error = %v, want ErrSelfNudge", err)
+	}
+
+	// First nudge goes through
+	if _, err := nudgeSvc.Send(p1.ID, p2.ID); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	// Second nudge to the same teammate is rate-limited
+	if _, err := nudgeSvc.Send(p1.ID, p2.ID); err != ErrNudgeTooSoon {
+		t.Errorf("Send() again error = %v, want ErrNudgeTooSoon", err)
+	}
+	wait, _ := nudgeSvc.CooldownRemaining(p1.ID, p2.ID)
+	if wait <= 0 {
+		t.Error("CooldownRemaining() should be positive right after a nudge")
+	}
+
+	// Rate limit is per pair
+	if _, err := nudgeSvc.Send(p2.ID, p1.ID); err != nil {
+		t.Errorf("Send() reversed pair error = %v", err)
+	}
+}
+
+func TestNudgeService_Send_RespectsPreferences(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+	nudgeSvc := NewNudgeService(repo)
+
+	// Setup
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	task, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	p1, _ := participantSvc.Join(challenge.ID, 12345, "User1", "💪", 0)
+	p2, _ := participantSvc.Join(challenge.ID, 67890, "User2", "🔥", 0)
+	p3, _ := participantSvc.Join(challenge.ID, 11111, "User3", "⭐", 0)
+
+	// Muted recipient
+	participantSvc.ToggleNotifications(p2.ID)
+	if _, err := nudgeSvc.Send(p1.ID, p2.ID); err != ErrNudgesMuted {
+		t.Errorf("Send() to muted error = %v, want ErrNudgesMuted", err)
+	}
+
+	// Finished recipient
+	completionSvc.Complete(task.ID, p3.ID)
+	if _, err := nudgeSvc.Send(p1.ID, p3.ID); err != ErrNothingToNudge {
+		t.Errorf("Send() to finished error = %v, want ErrNothingToNudge", err)
+	}
+}
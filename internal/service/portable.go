@@ -0,0 +1,318 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// PortableFormatName identifies portable challenge/template documents
+	PortableFormatName = "squad-challenge-bot"
+
+	// PortableFormatVersion is the current portable document version
+	PortableFormatVersion = 1
+
+	// MaxPortableFileSize is the largest document accepted for import
+	MaxPortableFileSize = 512 * 1024
+
+	PortableKindChallenge = "challenge"
+	PortableKindTemplate  = "template"
+
+	PortableFormatJSON = "json"
+	PortableFormatYAML = "yaml"
+)
+
+var (
+	ErrInvalidDocument            = errors.New("invalid document")
+	ErrUnsupportedDocumentVersion = errors.New("unsupported document version")
+	ErrUnsupportedDocumentFormat  = errors.New("unsupported document format")
+)
+
+// PortableDocument is a versioned, bot-independent description of a challenge or template
+// Task images are not included: Telegram file IDs are only valid within one bot
+type PortableDocument struct {
+	Format         string         `json:"format" yaml:"format"`
+	Version        int            `json:"version" yaml:"version"`
+	Kind           string         `json:"kind" yaml:"kind"`
+	Name           string         `json:"name" yaml:"name"`
+	Description    string         `json:"description,omitempty" yaml:"description,omitempty"`
+	DailyTaskLimit int            `json:"daily_task_limit" yaml:"daily_task_limit"` // 0 = unlimited
+	Sequential     bool           `json:"sequential" yaml:"sequential"`             // hide future tasks
+	Tasks          []PortableTask `json:"tasks" yaml:"tasks"`
+}
+
+// PortableTask is a task within a portable document
+type PortableTask struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// PortableService handles portable document export and import
+type PortableService struct {
+	repo repository.Repository
+}
+
+// NewPortableService creates a new PortableService
+func NewPortableService(repo repository.Repository) *PortableService {
+	return &PortableService{repo: repo}
+}
+
+// ExportChallenge builds a portable document from a challenge (admin or super admin only)
+func (s *PortableService) ExportChallenge(challengeID string, userID int64, isSuperAdmin bool) (*PortableDocument, error) {
+	challenge, err := s.repo.Challenge().GetByID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil {
+		return nil, ErrChallengeNotFound
+	}
+	if challenge.CreatorID != userID && !isSuperAdmin {
+		return nil, ErrNotAdmin
+	}
+
+	tasks, err := s.repo.Task().GetByChallengeID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := newPortableDocument(PortableKindChallenge, challenge.Name, challenge.Description, challenge.DailyTaskLimit, challenge.HideFutureTasks)
+	for _, t := range tasks {
+		doc.Tasks = append(doc.Tasks, PortableTask{Title: t.Title, Description: t.Description})
+	}
+	return doc, nil
+}
+
+// ExportTemplate builds a portable document from a template
+func (s *PortableService) ExportTemplate(templateID int64) (*PortableDocument, error) {
+	template, err := s.repo.Template().GetByID(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, ErrTemplateNotFound
+	}
+
+	tasks, err := s.repo.TemplateTask().GetByTemplateID(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := newPortableDocument(PortableKindTemplate, template.Name, template.Description, template.DailyTaskLimit, template.HideFutureTasks)
+	for _, t := range tasks {
+		doc.Tasks = append(doc.Tasks, PortableTask{Title: t.Title, Description: t.Description})
+	}
+	return doc, nil
+}
+
+// ImportTemplate creates a new template from a portable document of any kind.
+// userID is recorded as the editor of the template's first version.
+func (s *PortableService) ImportTemplate(doc *PortableDocument, userID int64) (*domain.Template, error) {
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.Template().ExistsByName(doc.Name, 0)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrTemplateNameExists
+	}
+
+	template := &domain.Template{
+		Name:            doc.Name,
+		Description:     doc.Description,
+		DailyTaskLimit:  doc.DailyTaskLimit,
+		HideFutureTasks: doc.Sequential,
+	}
+	err = s.repo.WithTx(func(repo repository.Repository) error {
+		if err := repo.Template().Create(template); err != nil {
+			return err
+		}
+
+		tasks := make([]*domain.TemplateTask, len(doc.Tasks))
+		for i, t := range doc.Tasks {
+			tasks[i] = &domain.TemplateTask{
+				TemplateID:  template.ID,
+				OrderNum:    i + 1,
+				Title:       t.Title,
+				Description: t.Description,
+			}
+		}
+		if err := repo.TemplateTask().CreateBatch(tasks); err != nil {
+			return err
+		}
+		_, err := recordTemplateVersion(repo, template.ID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// ImportTasks appends a portable document's tasks to an existing challenge (admin or super admin only)
+// Challenge settings are kept as they are
+func (s *PortableService) ImportTasks(challengeID string, doc *PortableDocument, userID int64, isSuperAdmin bool) (int, error) {
+	if err := doc.Validate(); err != nil {
+		return 0, err
+	}
+
+	challenge, err := s.repo.Challenge().GetByID(challengeID)
+	if err != nil {
+		return 0, err
+	}
+	if challenge == nil {
+		return 0, ErrChallengeNotFound
+	}
+	if challenge.CreatorID != userID && !isSuperAdmin {
+		return 0, ErrNotAdmin
+	}
+
+	count, err := s.repo.Task().CountByChallengeID(challengeID)
+	if err != nil {
+		return 0, err
+	}
+	if count+len(doc.Tasks) > domain.MaxTasksPerChallenge {
+		return 0, ErrMaxTasksReached
+	}
+
+	maxOrder, err := s.repo.Task().GetMaxOrderNum(challengeID)
+	if err != nil {
+		return 0, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(doc.Tasks))
+	for i, t := range doc.Tasks {
+		tasks = append(tasks, &domain.Task{
+			ChallengeID: challengeID,
+			OrderNum:    maxOrder + i + 1,
+			Title:       t.Title,
+			Description: t.Description,
+		})
+	}
+
+	err = withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		entry := newChallengeEntry(challenge, userID, domain.AuditTaskImport)
+		entry.After = strconv.Itoa(len(tasks))
+		return []*domain.AuditEntry{entry}, repo.Task().CreateBatch(tasks)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(tasks), nil
+}
+
+// Validate checks a portable document against format and business limits
+func (d *PortableDocument) Validate() error {
+	if d.Format != PortableFormatName {
+		return fmt.Errorf("%w: not a %s document", ErrInvalidDocument, PortableFormatName)
+	}
+	if d.Version < 1 || d.Version > PortableFormatVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedDocumentVersion, d.Version)
+	}
+	if d.Kind != PortableKindChallenge && d.Kind != PortableKindTemplate {
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidDocument, d.Kind)
+	}
+	if strings.TrimSpace(d.Name) == "" {
+		return fmt.Errorf("%w: name is empty", ErrInvalidDocument)
+	}
+	if len(d.Name) > domain.MaxChallengeNameLength {
+		return fmt.Errorf("%w: name is longer than %d bytes", ErrInvalidDocument, domain.MaxChallengeNameLength)
+	}
+	if len(d.Description) > domain.MaxChallengeDescriptionLength {
+		return fmt.Errorf("%w: description is longer than %d bytes", ErrInvalidDocument, domain.MaxChallengeDescriptionLength)
+	}
+	if d.DailyTaskLimit < 0 || d.DailyTaskLimit > domain.MaxTasksPerChallenge {
+		return fmt.Errorf("%w: daily task limit must be between 0 and %d", ErrInvalidDocument, domain.MaxTasksPerChallenge)
+	}
+	if len(d.Tasks) == 0 {
+		return fmt.Errorf("%w: no tasks", ErrInvalidDocument)
+	}
+	if len(d.Tasks) > domain.MaxTasksPerChallenge {
+		return fmt.Errorf("%w: more than %d tasks", ErrInvalidDocument, domain.MaxTasksPerChallenge)
+	}
+	for i, t := range d.Tasks {
+		if strings.TrimSpace(t.Title) == "" {
+			return fmt.Errorf("%w: task %d has no title", ErrInvalidDocument, i+1)
+		}
+		if utf8.RuneCountInString(t.Title) > domain.MaxTaskTitleLength {
+			return fmt.Errorf("%w: task %d title is longer than %d characters", ErrInvalidDocument, i+1, domain.MaxTaskTitleLength)
+		}
+		if utf8.RuneCountInString(t.Description) > domain.MaxTaskDescriptionLength {
+			return fmt.Errorf("%w: task %d description is longer than %d characters", ErrInvalidDocument, i+1, domain.MaxTaskDescriptionLength)
+		}
+	}
+	return nil
+}
+
+// EncodePortable serializes a portable document as JSON or YAML
+func EncodePortable(doc *PortableDocument, format string) ([]byte, error) {
+	switch format {
+	case PortableFormatJSON:
+		return json.MarshalIndent(doc, "", "  ")
+	case PortableFormatYAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, ErrUnsupportedDocumentFormat
+}
+
+// DecodePortable parses and validates a portable document; the format is picked by file extension
+func DecodePortable(data []byte, fileName string) (*PortableDocument, error) {
+	var doc PortableDocument
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+		}
+	default:
+		return nil, ErrUnsupportedDocumentFormat
+	}
+
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// newPortableDocument creates a document header with current format metadata
+func newPortableDocument(kind, name, description string, dailyLimit int, sequential bool) *PortableDocument {
+	return &PortableDocument{
+		Format:         PortableFormatName,
+		Version:        PortableFormatVersion,
+		Kind:           kind,
+		Name:           name,
+		Description:    description,
+		DailyTaskLimit: dailyLimit,
+		Sequential:     sequential,
+		Tasks:          []PortableTask{},
+	}
+}
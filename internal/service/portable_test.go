@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestPortableService_ExportImportRoundTrip(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	templateSvc := NewTemplateService(repo)
+	portableSvc := NewPortableService(repo)
+
+	challenge, _ := challengeSvc.Create("Test Challenge", "Desc", 12345, 3, true)
+	taskSvc.Create(challenge.ID, "Task 1", "First", "img-file-id", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+
+	doc, err := portableSvc.ExportChallenge(challenge.ID, 12345, false)
+	if err != nil {
+		t.Fatalf("ExportChallenge() error = %v", err)
+	}
+	if doc.Kind != PortableKindChallenge || doc.Version != PortableFormatVersion {
+		t.Errorf("Header = %s v%d, want challenge v%d", doc.Kind, doc.Version, PortableFormatVersion)
+	}
+
+	for _, format := range []string{PortableFormatJSON, PortableFormatYAML} {
+		b, err := EncodePortable(doc, format)
+		if err != nil {
+			t.Fatalf("EncodePortable(%s) error = %v", format, err)
+		}
+		if strings.Contains(string(b), "img-file-id") {
+			t.Errorf("EncodePortable(%s) should not include image file IDs", format)
+		}
+
+		decoded, err := DecodePortable(b, "export."+format)
+		if err != nil {
+			t.Fatalf("DecodePortable(%s) error = %v", format, err)
+		}
+		if decoded.Name != "Test Challenge" || decoded.DailyTaskLimit != 3 || !decoded.Sequential {
+			t.Errorf("DecodePortable(%s) settings = %+v", format, decoded)
+		}
+		if len(decoded.Tasks) != 2 || decoded.Tasks[0].Description != "First" {
+			t.Errorf("DecodePortable(%s) tasks = %+v", format, decoded.Tasks)
+		}
+	}
+
+	template, err := portableSvc.ImportTemplate(doc, 0)
+	if err != nil {
+		t.Fatalf("ImportTemplate() error = %v", err)
+	}
+	if !template.HideFutureTasks || template.DailyTaskLimit != 3 {
+		t.Errorf("ImportTemplate() settings = %+v", template)
+	}
+	count, _ := templateSvc.GetTaskCount(template.ID)
+	if count != 2 {
+		t.Errorf("Template task count = %d, want 2", count)
+	}
+
+	// Same name again
+	if _, err := portableSvc.ImportTemplate(doc, 0); err != ErrTemplateNameExists {
+		t.Errorf("ImportTemplate() duplicate error = %v, want ErrTemplateNameExists", err)
+	}
+
+	// Template export carries the template kind
+	tplDoc, err := portableSvc.ExportTemplate(template.ID)
+	if err != nil {
+		t.Fatalf("ExportTemplate() error = %v", err)
+	}
+	if tplDoc.Kind != PortableKindTemplate || len(tplDoc.Tasks) != 2 {
+		t.Errorf("ExportTemplate() = %s with %d tasks", tplDoc.Kind, len(tplDoc.Tasks))
+	}
+}
+
+func TestPortableService_ExportChallenge_NotAdmin(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	portableSvc := NewPortableService(repo)
+
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+
+	if _, err := portableSvc.ExportChallenge(challenge.ID, 67890, false); err != ErrNotAdmin {
+		t.Errorf("ExportChallenge() by non-admin error = %v, want ErrNotAdmin", err)
+	}
+	if _, err := portableSvc.ExportChallenge(challenge.ID, 67890, true); err != nil {
+		t.Errorf("ExportChallenge() by super admin error = %v", err)
+	}
+}
+
+func TestPortableService_ImportTasks(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	portableSvc := NewPortableService(repo)
+
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	taskSvc.Create(challenge.ID, "Existing", "", "", challenge.CreatorID)
+
+	doc := newPortableDocument(PortableKindTemplate, "Imported", "", 0, false)
+	doc.Tasks = []PortableTask{{Title: "New 1"}, {Title: "New 2"}}
+
+	if _, err := portableSvc.ImportTasks(challenge.ID, doc, 67890, false); err != ErrNotAdmin {
+		t.Errorf("ImportTasks() by non-admin error = %v, want ErrNotAdmin", err)
+	}
+
+	count, err := portableSvc.ImportTasks(challenge.ID, doc, 12345, false)
+	if err != nil {
+		t.Fatalf("ImportTasks() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("ImportTasks() = %d, want 2", count)
+	}
+
+	tasks, _ := taskSvc.GetByChallengeID(challenge.ID)
+	if len(tasks) != 3 || tasks[2].Title != "New 2" || tasks[2].OrderNum != 3 {
+		t.Errorf("Tasks after import = %d, last = %+v", len(tasks), tasks[len(tasks)-1])
+	}
+
+	// Exceeding the task limit imports nothing
+	big := newPortableDocument(PortableKindTemplate, "Big", "", 0, false)
+	for i := 0; i < domain.MaxTasksPerChallenge; i++ {
+		big.Tasks = append(big.Tasks, PortableTask{Title: fmt.Sprintf("Task %d", i)})
+	}
+	if _, err := portableSvc.ImportTasks(challenge.ID, big, 12345, false); err != ErrMaxTasksReached {
+		t.Errorf("ImportTasks() over limit error = %v, want ErrMaxTasksReached", err)
+	}
+	if n, _ := taskSvc.CountByChallengeID(challenge.ID); n != 3 {
+		t.Errorf("Task count after rejected import = %d, want 3", n)
+	}
+}
+
+func TestDecodePortable_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		data     string
+		wantErr  error
+	}{
+		{"unknown extension", "tasks.txt", `{}`, ErrUnsupportedDocumentFormat},
+		{"malformed json", "a.json", `{`, ErrInvalidDocument},
+		{"unknown field", "a.json", `{"format":"squad-challenge-bot","version":1,"kind":"template","name":"X","tasks":[{"title":"T"}],"extra":1}`, ErrInvalidDocument},
+		{"foreign format", "a.json", `{"format":"other","version":1,"kind":"template","name":"X","tasks":[{"title":"T"}]}`, ErrInvalidDocument},
+		{"newer version", "a.yaml", "format: squad-challenge-bot\nversion: 99\nkind: template\nname: X\ntasks:\n  - title: T\n", ErrUnsupportedDocumentVersion},
+		{"no tasks", "a.yml", "format: squad-challenge-bot\nversion: 1\nkind: template\nname: X\ntasks: []\n", ErrInvalidDocument},
+		{"empty title", "a.yml", "format: squad-challenge-bot\nversion: 1\nkind: template\nname: X\ntasks:\n  - title: \"\"\n", ErrInvalidDocument},
+		{"long name", "a.json", `{"format":"squad-challenge-bot","version":1,"kind":"template","name":"` + strings.Repeat("a", domain.MaxChallengeNameLength+1) + `","tasks":[{"title":"T"}]}`, ErrInvalidDocument},
+		{"long description", "a.json", `{"format":"squad-challenge-bot","version":1,"kind":"challenge","name":"X","description":"` + strings.Repeat("a", domain.MaxChallengeDescriptionLength+1) + `","tasks":[{"title":"T"}]}`, ErrInvalidDocument},
+		{"bad daily limit", "a.json", `{"format":"squad-challenge-bot","version":1,"kind":"template","name":"X","daily_task_limit":-1,"tasks":[{"title":"T"}]}`, ErrInvalidDocument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodePortable([]byte(tt.data), tt.fileName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DecodePortable() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPortableDocument_Validate_TitleLength(t *testing.T) {
+	doc := newPortableDocument(PortableKindTemplate, "X", "", 0, false)
+	doc.Tasks = []PortableTask{{Title: strings.Repeat("a", domain.MaxTaskTitleLength+1)}}
+
+	if err := doc.Validate(); !errors.Is(err, ErrInvalidDocument) {
+		t.Errorf("Validate() error = %v, want ErrInvalidDocument", err)
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+// MaxStatsDays caps how many days of history charts cover
+const MaxStatsDays = 365
+
+// BurnUpSeries holds one participant's cumulative completions per day
+type BurnUpSeries struct {
+	Participant *domain.Participant
+	Cumulative  []int // index 0 is the start day
+}
+
+// BurnUp holds burn-up chart data for a challenge
+type BurnUp struct {
+	Start      time.Time // UTC midnight of the first day
+	Days       int
+	TotalTasks int
+	Series     []BurnUpSeries
+}
+
+// StatsService computes pacing statistics from completion history
+type StatsService struct {
+	repo repository.Repository
+}
+
+// NewStatsService creates a new StatsService
+func NewStatsService(repo repository.Repository) *StatsService {
+	return &StatsService{repo: repo}
+}
+
+// BurnUp returns cumulative completions per participant per day since challenge creation
+func (s *StatsService) BurnUp(challengeID string, now time.Time) (*BurnUp, error) {
+	challenge, err := s.repo.Challenge().GetByID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil {
+		return nil, ErrChallengeNotFound
+	}
+
+	totalTasks, err := s.repo.Task().CountByChallengeID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := s.repo.Participant().GetByChallengeID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	start := localDay(challenge.CreatedAt, 0)
+	days := int(localDay(now, 0).Sub(start).Hours()/24) + 1
+	if days > MaxStatsDays {
+		start = start.AddDate(0, 0, days-MaxStatsDays)
+		days = MaxStatsDays
+	}
+
+	result := &BurnUp{Start: start, Days: days, TotalTasks: totalTasks}
+	for _, p := range participants {
+		completions, err := s.repo.Completion().GetByParticipantID(p.ID)
+		if err != nil {
+			return nil, err
+		}
+		result.Series = append(result.Series, BurnUpSeries{
+			Participant: p,
+			Cumulative:  cumulativeByDay(completionTimes(completions), start, days),
+		})
+	}
+
+	return result, nil
+}
+
+// DailyCompletions returns a participant's completion counts keyed by local day (UTC midnight)
+func (s *StatsService) DailyCompletions(participantID int64) (map[time.Time]int, error) {
+	participant, err := s.repo.Participant().GetByID(participantID)
+	if err != nil {
+		return nil, err
+	}
+	if participant == nil {
+		return nil, ErrParticipantNotFound
+	}
+
+	completions, err := s.repo.Completion().GetByParticipantID(participantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return countByLocalDay(completionTimes(completions), participant.TimeOffsetMinutes), nil
+}
+
+// cumulativeByDay returns running completion totals for each of days starting at start
+// Completions before start are counted in the first day
+func cumulativeByDay(times []time.Time, start time.Time, days int) []int {
+	result := make([]int, days)
+	for _, t := range times {
+		idx := int(localDay(t, 0).Sub(start).Hours() / 24)
+		if idx < 0 {
+			idx = 0
+		}
+		if idx >= days {
+			continue
+		}
+		result[idx]++
+	}
+	for i := 1; i < days; i++ {
+		result[i] += result[i-1]
+	}
+	return result
+}
+
+// countByLocalDay counts completions per local calendar day
+func countByLocalDay(times []time.Time, offsetMinutes int) map[time.Time]int {
+	counts := make(map[time.Time]int)
+	for _, t := range times {
+		counts[localDay(t, offsetMinutes)]++
+	}
+	return counts
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatsService_BurnUp(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+	statsSvc := NewStatsService(repo)
+
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	p1, _ := participantSvc.Join(challenge.ID, 12345, "User1", "💪", 0)
+	participantSvc.Join(challenge.ID, 67890, "User2", "🔥", 0)
+
+	completionSvc.Complete(task1.ID, p1.ID)
+	completionSvc.Complete(task2.ID, p1.ID)
+
+	burnUp, err := statsSvc.BurnUp(challenge.ID, time.Now().Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("BurnUp() error = %v", err)
+	}
+	if burnUp.TotalTasks != 2 {
+		t.Errorf("TotalTasks = %d, want 2", burnUp.TotalTasks)
+	}
+	if burnUp.Days != 3 {
+		t.Errorf("Days = %d, want 3", burnUp.Days)
+	}
+	if len(burnUp.Series) != 2 {
+		t.Fatalf("Series count = %d, want 2", len(burnUp.Series))
+	}
+	for _, s := range burnUp.Series {
+		want := 0
+		if s.Participant.ID == p1.ID {
+			want = 2
+		}
+		if got := s.Cumulative[len(s.Cumulative)-1]; got != want {
+			t.Errorf("%s final cumulative = %d, want %d", s.Participant.DisplayName, got, want)
+		}
+	}
+
+	if _, err := statsSvc.BurnUp("missing", time.Now()); err != ErrChallengeNotFound {
+		t.Errorf("BurnUp() for missing challenge error = %v, want ErrChallengeNotFound", err)
+	}
+}
+
+func TestStatsService_DailyCompletions(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+	statsSvc := NewStatsService(repo)
+
+	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
+	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	p, _ := participantSvc.Join(challenge.ID, 12345, "User", "💪", 0)
+
+	completionSvc.Complete(task1.ID, p.ID)
+	completionSvc.Complete(task2.ID, p.ID)
+
+	counts, err := statsSvc.DailyCompletions(p.ID)
+	if err != nil {
+		t.Fatalf("DailyCompletions() error = %v", err)
+	}
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	if total != 2 {
+		t.Errorf("DailyCompletions() total = %d, want 2", total)
+	}
+}
+
+func TestCumulativeByDay(t *testing.T) {
+	start := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	times := []time.Time{
+		start.Add(-time.Hour),                 // before start, counted on day 0
+		start.Add(2 * time.Hour),              // day 0
+		start.Add(26 * time.Hour),             // day 1
+		start.Add(3*24*time.Hour + time.Hour), // day 3
+		start.Add(10 * 24 * time.Hour),        // after range, ignored
+	}
+
+	got := cumulativeByDay(times, start, 5)
+	want := []int{2, 3, 3, 4, 4}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("cumulativeByDay() = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestCountByLocalDay(t *testing.T) {
+	// 23:30 UTC is already the next day at UTC+1
+	ts := time.Date(2025, 3, 10, 23, 30, 0, 0, time.UTC)
+
+	counts := countByLocalDay([]time.Time{ts}, 60)
+	if counts[time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC)] != 1 {
+		t.Errorf("countByLocalDay() = %v, want completion on Mar 11", counts)
+	}
+}
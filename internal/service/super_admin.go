@@ -49,7 +49,10 @@ func (s *SuperAdminService) Grant(grantedByID, targetID int64) error {
 		return ErrAlreadySuperAdmin
 	}
 
-	return s.repo.SuperAdmin().Create(targetID)
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		entry := &domain.AuditEntry{ActorID: grantedByID, Action: domain.AuditSuperAdminGrant, TargetUserID: targetID}
+		return []*domain.AuditEntry{entry}, repo.SuperAdmin().Create(targetID)
+	})
 }
 
 // Revoke removes super admin privileges from a user
@@ -77,7 +80,10 @@ func (s *SuperAdminService) Revoke(revokedByID, targetID int64) error {
 		return ErrSuperAdminNotFound
 	}
 
-	return s.repo.SuperAdmin().Delete(targetID)
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		entry := &domain.AuditEntry{ActorID: revokedByID, Action: domain.AuditSuperAdminRevoke, TargetUserID: targetID}
+		return []*domain.AuditEntry{entry}, repo.SuperAdmin().Delete(targetID)
+	})
 }
 
 // GetAll returns all super admins
@@ -3,6 +3,7 @@ package service
 import (
 	"errors"
 	"math/rand"
+	"strconv"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
@@ -24,8 +25,8 @@ func NewTaskService(repo repository.Repository) *TaskService {
 	return &TaskService{repo: repo}
 }
 
-// Create creates a new task
-func (s *TaskService) Create(challengeID, title, description, imageFileID string) (*domain.Task, error) {
+// Create creates a new task on behalf of userID
+func (s *TaskService) Create(challengeID, title, description, imageFileID string, userID int64) (*domain.Task, error) {
 	if title == "" {
 		return nil, ErrEmptyTaskTitle
 	}
@@ -53,13 +54,65 @@ func (s *TaskService) Create(challengeID, title, description, imageFileID string
 		ImageFileID: imageFileID,
 	}
 
-	if err := s.repo.Task().Create(task); err != nil {
+	err = withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		if err := repo.Task().Create(task); err != nil {
+			return nil, err
+		}
+		entry, err := newTaskEntry(repo, task, userID, domain.AuditTaskCreate)
+		return []*domain.AuditEntry{entry}, err
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return task, nil
 }
 
+// CreateBulk appends tasks to a challenge in a single transaction on behalf of userID
+func (s *TaskService) CreateBulk(challengeID string, bulk []BulkTask, userID int64) ([]*domain.Task, error) {
+	count, err := s.repo.Task().CountByChallengeID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if count+len(bulk) > domain.MaxTasksPerChallenge {
+		return nil, ErrMaxTasksReached
+	}
+
+	maxOrder, err := s.repo.Task().GetMaxOrderNum(challengeID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(bulk))
+	for i, b := range bulk {
+		if b.Title == "" {
+			return nil, ErrEmptyTaskTitle
+		}
+		tasks = append(tasks, &domain.Task{
+			ChallengeID: challengeID,
+			OrderNum:    maxOrder + i + 1,
+			Title:       b.Title,
+			Description: b.Description,
+		})
+	}
+
+	err = withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		if err := repo.Task().CreateBatch(tasks); err != nil {
+			return nil, err
+		}
+		entry, err := challengeEntry(repo, challengeID, userID, domain.AuditTaskBulkCreate)
+		if err != nil {
+			return nil, err
+		}
+		entry.After = strconv.Itoa(len(tasks))
+		return []*domain.AuditEntry{entry}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
 // GetByID retrieves a task by ID
 func (s *TaskService) GetByID(id int64) (*domain.Task, error) {
 	task, err := s.repo.Task().GetByID(id)
@@ -77,92 +130,147 @@ func (s *TaskService) GetByChallengeID(challengeID string) ([]*domain.Task, erro
 	return s.repo.Task().GetByChallengeID(challengeID)
 }
 
-// Update updates a task
-func (s *TaskService) Update(task *domain.Task) error {
+// Update updates a task on behalf of userID, recording each changed field in the audit log
+func (s *TaskService) Update(task *domain.Task, userID int64) error {
 	if task.Title == "" {
 		return ErrEmptyTaskTitle
 	}
-	return s.repo.Task().Update(task)
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		old, err := repo.Task().GetByID(task.ID)
+		if err != nil {
+			return nil, err
+		}
+		if old == nil {
+			return nil, ErrTaskNotFound
+		}
+		if err := repo.Task().Update(task); err != nil {
+			return nil, err
+		}
+
+		base, err := newTaskEntry(repo, task, userID, "")
+		if err != nil {
+			return nil, err
+		}
+		var entries []*domain.AuditEntry
+		record := func(action, before, after string) {
+			entry := *base
+			entry.Action, entry.Before, entry.After = action, before, after
+			entries = append(entries, &entry)
+		}
+		if old.Title != task.Title {
+			record(domain.AuditTaskTitle, old.Title, task.Title)
+		}
+		if old.Description != task.Description {
+			record(domain.AuditTaskDescription, old.Description, task.Description)
+		}
+		// File IDs mean nothing to a reader, so only the change itself is recorded
+		if old.ImageFileID != task.ImageFileID {
+			record(domain.AuditTaskImage, "", "")
+		}
+		return entries, nil
+	})
 }
 
-// Delete deletes a task and renumbers remaining tasks
-func (s *TaskService) Delete(taskID int64, challengeID string) error {
-	if err := s.repo.Task().Delete(taskID); err != nil {
-		return err
-	}
+// Delete deletes a task on behalf of userID and renumbers remaining tasks
+func (s *TaskService) Delete(taskID int64, challengeID string, userID int64) error {
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		task, err := repo.Task().GetByID(taskID)
+		if err != nil {
+			return nil, err
+		}
+		if task == nil {
+			return nil, ErrTaskNotFound
+		}
+		entry, err := newTaskEntry(repo, task, userID, domain.AuditTaskDelete)
+		if err != nil {
+			return nil, err
+		}
+		entries := []*domain.AuditEntry{entry}
 
-	// Renumber remaining tasks
-	tasks, err := s.repo.Task().GetByChallengeID(challengeID)
-	if err != nil {
-		return err
-	}
+		if err := repo.Task().Delete(taskID); err != nil {
+			return nil, err
+		}
 
-	updates := make(map[int64]int)
-	for i, t := range tasks {
-		if t.OrderNum != i+1 {
-			updates[t.ID] = i + 1
+		// Renumber remaining tasks
+		tasks, err := repo.Task().GetByChallengeID(challengeID)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	if len(updates) > 0 {
-		return s.repo.Task().UpdateOrderNums(challengeID, updates)
-	}
+		updates := make(map[int64]int)
+		for i, t := range tasks {
+			if t.OrderNum != i+1 {
+				updates[t.ID] = i + 1
+			}
+		}
+
+		if len(updates) > 0 {
+			return entries, repo.Task().UpdateOrderNums(challengeID, updates)
+		}
 
-	return nil
+		return entries, nil
+	})
 }
 
-// MoveTask moves a task to a new position
-func (s *TaskService) MoveTask(taskID int64, challengeID string, newPosition int) error {
-	tasks, err := s.repo.Task().GetByChallengeID(challengeID)
-	if err != nil {
-		return err
-	}
+// MoveTask moves a task to a new position on behalf of userID
+func (s *TaskService) MoveTask(taskID int64, challengeID string, newPosition int, userID int64) error {
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		tasks, err := repo.Task().GetByChallengeID(challengeID)
+		if err != nil {
+			return nil, err
+		}
 
-	if newPosition < 1 || newPosition > len(tasks) {
-		return errors.New("invalid position")
-	}
+		if newPosition < 1 || newPosition > len(tasks) {
+			return nil, errors.New("invalid position")
+		}
 
-	// Find the task to move
-	var movingTask *domain.Task
-	var oldPosition int
-	for i, t := range tasks {
-		if t.ID == taskID {
-			movingTask = t
-			oldPosition = i + 1
-			break
+		// Find the task to move
+		var movingTask *domain.Task
+		var oldPosition int
+		for i, t := range tasks {
+			if t.ID == taskID {
+				movingTask = t
+				oldPosition = i + 1
+				break
+			}
 		}
-	}
 
-	if movingTask == nil {
-		return ErrTaskNotFound
-	}
+		if movingTask == nil {
+			return nil, ErrTaskNotFound
+		}
 
-	if oldPosition == newPosition {
-		return nil // No change needed
-	}
+		if oldPosition == newPosition {
+			return nil, nil // No change needed
+		}
 
-	// Calculate new order numbers
-	updates := make(map[int64]int)
+		// Calculate new order numbers
+		updates := make(map[int64]int)
 
-	if newPosition < oldPosition {
-		// Moving up: shift tasks between newPosition and oldPosition down
-		for _, t := range tasks {
-			if t.OrderNum >= newPosition && t.OrderNum < oldPosition {
-				updates[t.ID] = t.OrderNum + 1
+		if newPosition < oldPosition {
+			// Moving up: shift tasks between newPosition and oldPosition down
+			for _, t := range tasks {
+				if t.OrderNum >= newPosition && t.OrderNum < oldPosition {
+					updates[t.ID] = t.OrderNum + 1
+				}
 			}
-		}
-	} else {
-		// Moving down: shift tasks between oldPosition and newPosition up
-		for _, t := range tasks {
-			if t.OrderNum > oldPosition && t.OrderNum <= newPosition {
-				updates[t.ID] = t.OrderNum - 1
+		} else {
+			// Moving down: shift tasks between oldPosition and newPosition up
+			for _, t := range tasks {
+				if t.OrderNum > oldPosition && t.OrderNum <= newPosition {
+					updates[t.ID] = t.OrderNum - 1
+				}
 			}
 		}
-	}
 
-	updates[taskID] = newPosition
+		updates[taskID] = newPosition
 
-	return s.repo.Task().UpdateOrderNums(challengeID, updates)
+		entry, err := newTaskEntry(repo, movingTask, userID, domain.AuditTaskMove)
+		if err != nil {
+			return nil, err
+		}
+		entry.Before, entry.After = strconv.Itoa(oldPosition), strconv.Itoa(newPosition)
+		return []*domain.AuditEntry{entry}, repo.Task().UpdateOrderNums(challengeID, updates)
+	})
 }
 
 // CountByChallengeID returns the number of tasks in a challenge
@@ -170,34 +278,40 @@ func (s *TaskService) CountByChallengeID(challengeID string) (int, error) {
 	return s.repo.Task().CountByChallengeID(challengeID)
 }
 
-// RandomizeOrder randomizes the order of tasks in a challenge
-func (s *TaskService) RandomizeOrder(challengeID string) error {
-	tasks, err := s.repo.Task().GetByChallengeID(challengeID)
-	if err != nil {
-		return err
-	}
+// RandomizeOrder randomizes the order of tasks in a challenge on behalf of userID
+func (s *TaskService) RandomizeOrder(challengeID string, userID int64) error {
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		tasks, err := repo.Task().GetByChallengeID(challengeID)
+		if err != nil {
+			return nil, err
+		}
 
-	if len(tasks) < 2 {
-		return nil // Nothing to randomize
-	}
+		if len(tasks) < 2 {
+			return nil, nil // Nothing to randomize
+		}
 
-	// Create shuffled positions
-	positions := make([]int, len(tasks))
-	for i := range positions {
-		positions[i] = i + 1
-	}
+		// Create shuffled positions
+		positions := make([]int, len(tasks))
+		for i := range positions {
+			positions[i] = i + 1
+		}
 
-	// Fisher-Yates shuffle
-	for i := len(positions) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
-		positions[i], positions[j] = positions[j], positions[i]
-	}
+		// Fisher-Yates shuffle
+		for i := len(positions) - 1; i > 0; i-- {
+			j := rand.Intn(i + 1)
+			positions[i], positions[j] = positions[j], positions[i]
+		}
 
-	// Build updates map
-	updates := make(map[int64]int)
-	for i, t := range tasks {
-		updates[t.ID] = positions[i]
-	}
+		// Build updates map
+		updates := make(map[int64]int)
+		for i, t := range tasks {
+			updates[t.ID] = positions[i]
+		}
 
-	return s.repo.Task().UpdateOrderNums(challengeID, updates)
+		entry, err := challengeEntry(repo, challengeID, userID, domain.AuditTaskShuffle)
+		if err != nil {
+			return nil, err
+		}
+		return []*domain.AuditEntry{entry}, repo.Task().UpdateOrderNums(challengeID, updates)
+	})
 }
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// ParticipantTaskOrder returns tasks in the order a participant works through them.
+//
+// Unless the challenge shuffles tasks per participant (or there is no participant,
+// e.g. an observer), tasks are returned as they are. Otherwise each task gets a sort key
+// seeded by the participant and task IDs, and the tasks are returned as copies renumbered
+// from 1 in that order. The order is stable across calls, differs between participants,
+// and a task added later lands at a random spot without moving the others.
+//
+// Sequential mode and GetCurrentTaskNum work on OrderNum, so passing them the result
+// makes each participant unlock tasks in their own order.
+func ParticipantTaskOrder(tasks []*domain.Task, challenge *domain.Challenge, participantID int64) []*domain.Task {
+	if !challenge.ShuffleTasks || participantID == 0 || len(tasks) < 2 {
+		return tasks
+	}
+
+	keys := make(map[int64]uint64, len(tasks))
+	for _, t := range tasks {
+		keys[t.ID] = taskOrderKey(participantID, t.ID)
+	}
+
+	ordered := make([]*domain.Task, len(tasks))
+	for i, t := range tasks {
+		task := *t
+		ordered[i] = &task
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		ki, kj := keys[ordered[i].ID], keys[ordered[j].ID]
+		if ki != kj {
+			return ki < kj
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+	for i, t := range ordered {
+		t.OrderNum = i + 1
+	}
+	return ordered
+}
+
+// taskOrderKey mixes a participant and task ID into a per-participant sort key (splitmix64 finalizer)
+func taskOrderKey(participantID, taskID int64) uint64 {
+	x := uint64(participantID)*0x9E3779B97F4A7C15 ^ uint64(taskID)
+	x ^= x >> 30
+	x *= 0xBF58476D1CE4E5B9
+	x ^= x >> 27
+	x *= 0x94D049BB133111EB
+	x ^= x >> 31
+	return x
+}
+
+// GetForParticipant returns the challenge tasks in the order the participant sees them
+func (s *TaskService) GetForParticipant(challenge *domain.Challenge, participantID int64) ([]*domain.Task, error) {
+	tasks, err := s.repo.Task().GetByChallengeID(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	return ParticipantTaskOrder(tasks, challenge, participantID), nil
+}
+
+// FindTask returns the task with the given ID from a task list, or nil
+func FindTask(tasks []*domain.Task, taskID int64) *domain.Task {
+	for _, t := range tasks {
+		if t.ID == taskID {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func orderedIDs(tasks []*domain.Task) []int64 {
+	ids := make([]int64, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+func makeTasks(n int) []*domain.Task {
+	tasks := make([]*domain.Task, n)
+	for i := range tasks {
+		tasks[i] = &domain.Task{ID: int64(i + 1), OrderNum: i + 1, Title: fmt.Sprintf("Task %d", i+1)}
+	}
+	return tasks
+}
+
+func TestParticipantTaskOrder_Disabled(t *testing.T) {
+	tasks := makeTasks(5)
+
+	got := ParticipantTaskOrder(tasks, &domain.Challenge{ShuffleTasks: false}, 7)
+	if fmt.Sprint(orderedIDs(got)) != fmt.Sprint(orderedIDs(tasks)) {
+		t.Errorf("order without shuffling = %v, want %v", orderedIDs(got), orderedIDs(tasks))
+	}
+
+	// Observers (no participant) see the shared order
+	got = ParticipantTaskOrder(tasks, &domain.Challenge{ShuffleTasks: true}, 0)
+	if fmt.Sprint(orderedIDs(got)) != fmt.Sprint(orderedIDs(tasks)) {
+		t.Errorf("order for observer = %v, want %v", orderedIDs(got), orderedIDs(tasks))
+	}
+}
+
+func TestParticipantTaskOrder_StablePerParticipant(t *testing.T) {
+	challenge := &domain.Challenge{ShuffleTasks: true}
+	tasks := makeTasks(10)
+
+	first := ParticipantTaskOrder(tasks, challenge, 7)
+	again := ParticipantTaskOrder(tasks, challenge, 7)
+	if fmt.Sprint(orderedIDs(first)) != fmt.Sprint(orderedIDs(again)) {
+		t.Errorf("order is not stable: %v then %v", orderedIDs(first), orderedIDs(again))
+	}
+
+	// Renumbered from 1 in the participant's order, originals untouched
+	for i, task := range first {
+		if task.OrderNum != i+1 {
+			t.Errorf("ordered[%d].OrderNum = %d, want %d", i, task.OrderNum, i+1)
+		}
+	}
+	for i, task := range tasks {
+		if task.OrderNum != i+1 {
+			t.Errorf("original tasks[%d].OrderNum changed to %d", i, task.OrderNum)
+		}
+	}
+
+	// Different participants get different orders
+	distinct := map[string]bool{}
+	for pid := int64(1); pid <= 5; pid++ {
+		distinct[fmt.Sprint(orderedIDs(ParticipantTaskOrder(tasks, challenge, pid)))] = true
+	}
+	if len(distinct) < 2 {
+		t.Error("expected participants to get different task orders")
+	}
+}
+
+func TestParticipantTaskOrder_NewTaskKeepsOthersInPlace(t *testing.T) {
+	challenge := &domain.Challenge{ShuffleTasks: true}
+	tasks := makeTasks(8)
+	before := orderedIDs(ParticipantTaskOrder(tasks, challenge, 7))
+
+	tasks = append(tasks, &domain.Task{ID: 9, OrderNum: 9, Title: "Task 9"})
+	var after []int64
+	for _, id := range orderedIDs(ParticipantTaskOrder(tasks, challenge, 7)) {
+		if id != 9 {
+			after = append(after, id)
+		}
+	}
+
+	if fmt.Sprint(before) != fmt.Sprint(after) {
+		t.Errorf("relative order changed after adding a task: %v -> %v", before, after)
+	}
+}
+
+func TestParticipantTaskOrder_SequentialProgress(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+
+	challenge, _ := challengeSvc.Create("Hunt", "", 12345, 0, true)
+	for i := 1; i <= 6; i++ {
+		taskSvc.Create(challenge.ID, fmt.Sprintf("Clue %d", i), "", "", challenge.CreatorID)
+	}
+	if _, err := challengeSvc.ToggleShuffleTasks(challenge.ID, 12345, false); err != nil {
+		t.Fatalf("ToggleShuffleTasks() error = %v", err)
+	}
+	challenge, _ = challengeSvc.GetByID(challenge.ID)
+	if !challenge.ShuffleTasks {
+		t.Fatal("ShuffleTasks should be on after toggling")
+	}
+
+	p, _ := participantSvc.Join(challenge.ID, 67890, "Alice", "🔥", 0)
+
+	tasks, err := taskSvc.GetForParticipant(challenge, p.ID)
+	if err != nil {
+		t.Fatalf("GetForParticipant() error = %v", err)
+	}
+	if got := completionSvc.GetCurrentTaskNum(p.ID, tasks); got != 1 {
+		t.Fatalf("GetCurrentTaskNum() = %d, want 1", got)
+	}
+
+	// Completing the participant's own first task unlocks their second one
+	completionSvc.Complete(tasks[0].ID, p.ID)
+	if got := completionSvc.GetCurrentTaskNum(p.ID, tasks); got != 2 {
+		t.Errorf("GetCurrentTaskNum() after first = %d, want 2", got)
+	}
+	completionSvc.Complete(tasks[1].ID, p.ID)
+	if got := completionSvc.GetCurrentTaskNum(p.ID, tasks); got != 3 {
+		t.Errorf("GetCurrentTaskNum() after second = %d, want 3", got)
+	}
+
+	if FindTask(tasks, tasks[2].ID) != tasks[2] {
+		t.Error("FindTask() should return the task from the participant's list")
+	}
+	if FindTask(tasks, 99999) != nil {
+		t.Error("FindTask() for an unknown ID should return nil")
+	}
+}
+
+func TestChallengeService_ToggleShuffleTasks_NotAdmin(t *testing.T) {
+	repo := setupTestRepo(t)
+	svc := NewChallengeService(repo)
+
+	challenge, _ := svc.Create("Test", "", 12345, 0, false)
+
+	if _, err := svc.ToggleShuffleTasks(challenge.ID, 99999, false); err != ErrNotAdmin {
+		t.Errorf("ToggleShuffleTasks() by non-admin error = %v, want ErrNotAdmin", err)
+	}
+}
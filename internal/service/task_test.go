@@ -15,7 +15,7 @@ func TestTaskService_Create(t *testing.T) {
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
 
 	// Create task
-	task, err := taskSvc.Create(challenge.ID, "Task 1", "Description", "")
+	task, err := taskSvc.Create(challenge.ID, "Task 1", "Description", "", challenge.CreatorID)
 	if err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
@@ -27,7 +27,7 @@ func TestTaskService_Create(t *testing.T) {
 	}
 
 	// Create second task
-	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "")
+	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
 	if task2.OrderNum != 2 {
 		t.Errorf("Second task OrderNum = %d, want 2", task2.OrderNum)
 	}
@@ -40,7 +40,7 @@ func TestTaskService_Create_EmptyTitle(t *testing.T) {
 
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
 
-	_, err := taskSvc.Create(challenge.ID, "", "Description", "")
+	_, err := taskSvc.Create(challenge.ID, "", "Description", "", challenge.CreatorID)
 	if err != ErrEmptyTaskTitle {
 		t.Errorf("Create() error = %v, want ErrEmptyTaskTitle", err)
 	}
@@ -54,12 +54,12 @@ func TestTaskService_Delete_Renumber(t *testing.T) {
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
 
 	// Create 3 tasks
-	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "")
-	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "")
-	taskSvc.Create(challenge.ID, "Task 3", "", "")
+	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	task2, _ := taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 3", "", "", challenge.CreatorID)
 
 	// Delete task 2
-	err := taskSvc.Delete(task2.ID, challenge.ID)
+	err := taskSvc.Delete(task2.ID, challenge.ID, challenge.CreatorID)
 	if err != nil {
 		t.Fatalf("Delete() error = %v", err)
 	}
@@ -87,12 +87,12 @@ func TestTaskService_MoveTask(t *testing.T) {
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
 
 	// Create 3 tasks: 1, 2, 3
-	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "")
-	taskSvc.Create(challenge.ID, "Task 2", "", "")
-	task3, _ := taskSvc.Create(challenge.ID, "Task 3", "", "")
+	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	task3, _ := taskSvc.Create(challenge.ID, "Task 3", "", "", challenge.CreatorID)
 
 	// Move task 3 to position 1
-	err := taskSvc.MoveTask(task3.ID, challenge.ID, 1)
+	err := taskSvc.MoveTask(task3.ID, challenge.ID, 1, challenge.CreatorID)
 	if err != nil {
 		t.Fatalf("MoveTask() error = %v", err)
 	}
@@ -118,14 +118,14 @@ func TestTaskService_MaxTasks(t *testing.T) {
 
 	// Create 50 tasks (max)
 	for i := 0; i < domain.MaxTasksPerChallenge; i++ {
-		_, err := taskSvc.Create(challenge.ID, "Task", "", "")
+		_, err := taskSvc.Create(challenge.ID, "Task", "", "", challenge.CreatorID)
 		if err != nil {
 			t.Fatalf("Create() %d error = %v", i, err)
 		}
 	}
 
 	// 51st should fail
-	_, err := taskSvc.Create(challenge.ID, "One More", "", "")
+	_, err := taskSvc.Create(challenge.ID, "One More", "", "", challenge.CreatorID)
 	if err != ErrMaxTasksReached {
 		t.Errorf("Create() error = %v, want ErrMaxTasksReached", err)
 	}
@@ -137,12 +137,12 @@ func TestTaskService_Update(t *testing.T) {
 	taskSvc := NewTaskService(repo)
 
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
-	task, _ := taskSvc.Create(challenge.ID, "Original Title", "", "")
+	task, _ := taskSvc.Create(challenge.ID, "Original Title", "", "", challenge.CreatorID)
 
 	// Update task
 	task.Title = "Updated Title"
 	task.Description = "New Description"
-	err := taskSvc.Update(task)
+	err := taskSvc.Update(task, challenge.CreatorID)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
 	}
@@ -174,9 +174,9 @@ func TestTaskService_CountByChallengeID(t *testing.T) {
 	}
 
 	// Create some tasks
-	taskSvc.Create(challenge.ID, "Task 1", "", "")
-	taskSvc.Create(challenge.ID, "Task 2", "", "")
-	taskSvc.Create(challenge.ID, "Task 3", "", "")
+	taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 3", "", "", challenge.CreatorID)
 
 	count, err = taskSvc.CountByChallengeID(challenge.ID)
 	if err != nil {
@@ -193,7 +193,7 @@ func TestTaskService_GetByID(t *testing.T) {
 	taskSvc := NewTaskService(repo)
 
 	challenge, _ := challengeSvc.Create("Test Challenge", "", 12345, 0, false)
-	task, _ := taskSvc.Create(challenge.ID, "Test Task", "Description", "")
+	task, _ := taskSvc.Create(challenge.ID, "Test Task", "Description", "", challenge.CreatorID)
 
 	// Get existing task
 	found, err := taskSvc.GetByID(task.ID)
@@ -3,14 +3,20 @@ package service
 import (
 	"errors"
 	"math/rand"
+	"strconv"
+	"strings"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+	"github.com/rgeraskin/squad-challenge-bot/internal/util"
 )
 
 var (
-	ErrTemplateNotFound      = errors.New("template not found")
-	ErrTemplateNameExists    = errors.New("template with this name already exists")
+	ErrTemplateNotFound    = errors.New("template not found")
+	ErrTemplateNameExists  = errors.New("template with this name already exists")
+	ErrMaxTemplatesReached = errors.New("maximum number of personal templates reached")
+	ErrNotTemplateOwner    = errors.New("not the owner of this template")
+	ErrShareCodeNotFound   = errors.New("template share code not found")
 )
 
 // TemplateService handles template business logic
@@ -23,8 +29,9 @@ func NewTemplateService(repo repository.Repository) *TemplateService {
 	return &TemplateService{repo: repo}
 }
 
-// CreateFromChallenge creates a template from an existing challenge
-func (s *TemplateService) CreateFromChallenge(challengeID string) (*domain.Template, error) {
+// CreateFromChallenge creates a global template from an existing challenge.
+// userID is recorded as the editor of the template's first version.
+func (s *TemplateService) CreateFromChallenge(challengeID string, userID int64) (*domain.Template, error) {
 	// Get challenge
 	challenge, err := s.repo.Challenge().GetByID(challengeID)
 	if err != nil {
@@ -34,8 +41,40 @@ func (s *TemplateService) CreateFromChallenge(challengeID string) (*domain.Templ
 		return nil, ErrChallengeNotFound
 	}
 
+	return s.copyChallenge(challenge, 0, userID)
+}
+
+// SaveAsPersonal creates a personal template owned by userID from a challenge.
+// Only the challenge admin (or a super admin) can save a challenge this way.
+func (s *TemplateService) SaveAsPersonal(challengeID string, userID int64, isSuperAdmin bool) (*domain.Template, error) {
+	challenge, err := s.repo.Challenge().GetByID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil {
+		return nil, ErrChallengeNotFound
+	}
+	if challenge.CreatorID != userID && !isSuperAdmin {
+		return nil, ErrNotAdmin
+	}
+
+	count, err := s.repo.Template().CountByOwner(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= domain.MaxTemplatesPerUser {
+		return nil, ErrMaxTemplatesReached
+	}
+
+	return s.copyChallenge(challenge, userID, userID)
+}
+
+// copyChallenge copies a challenge with its tasks into a new template owned by ownerID
+func (s *TemplateService) copyChallenge(challenge *domain.Challenge, ownerID int64, editorID int64) (*domain.Template, error) {
+	challengeID := challenge.ID
+
 	// Check if template with this name already exists
-	exists, err := s.repo.Template().ExistsByName(challenge.Name)
+	exists, err := s.repo.Template().ExistsByName(challenge.Name, ownerID)
 	if err != nil {
 		return nil, err
 	}
@@ -49,35 +88,40 @@ func (s *TemplateService) CreateFromChallenge(challengeID string) (*domain.Templ
 		Description:     challenge.Description,
 		DailyTaskLimit:  challenge.DailyTaskLimit,
 		HideFutureTasks: challenge.HideFutureTasks,
+		OwnerID:         ownerID,
 	}
 
-	if err := s.repo.Template().Create(template); err != nil {
-		return nil, err
-	}
+	// Create the template and copy tasks in one transaction so a failure
+	// never leaves a half-copied template behind
+	err = s.repo.WithTx(func(repo repository.Repository) error {
+		if err := repo.Template().Create(template); err != nil {
+			return err
+		}
 
-	// Copy tasks
-	tasks, err := s.repo.Task().GetByChallengeID(challengeID)
-	if err != nil {
-		// Rollback: delete template if task copying fails
-		s.repo.Template().Delete(template.ID)
-		return nil, err
-	}
+		tasks, err := repo.Task().GetByChallengeID(challengeID)
+		if err != nil {
+			return err
+		}
 
-	for _, task := range tasks {
-		templateTask := &domain.TemplateTask{
-			TemplateID:  template.ID,
-			OrderNum:    task.OrderNum,
-			Title:       task.Title,
-			Description: task.Description,
-			ImageFileID: task.ImageFileID,
+		templateTasks := make([]*domain.TemplateTask, len(tasks))
+		for i, task := range tasks {
+			templateTasks[i] = &domain.TemplateTask{
+				TemplateID:  template.ID,
+				OrderNum:    task.OrderNum,
+				Title:       task.Title,
+				Description: task.Description,
+				ImageFileID: task.ImageFileID,
+			}
 		}
-		if err := s.repo.TemplateTask().Create(templateTask); err != nil {
-			// Rollback
-			s.repo.Template().Delete(template.ID)
-			return nil, err
+		if err := repo.TemplateTask().CreateBatch(templateTasks); err != nil {
+			return err
 		}
+		_, err = recordTemplateVersion(repo, template.ID, editorID)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-
 	return template, nil
 }
 
@@ -93,9 +137,109 @@ func (s *TemplateService) GetByID(id int64) (*domain.Template, error) {
 	return template, nil
 }
 
-// GetAll returns all templates
+// GetAll returns all global templates curated by super admins
 func (s *TemplateService) GetAll() ([]*domain.Template, error) {
-	return s.repo.Template().GetAll()
+	return s.repo.Template().GetGlobal()
+}
+
+// GetByOwner returns the personal templates owned by a user
+func (s *TemplateService) GetByOwner(ownerID int64) ([]*domain.Template, error) {
+	return s.repo.Template().GetByOwner(ownerID)
+}
+
+// GetForUser retrieves a template visible to the user: a global template, one they own,
+// or a personal template shared via link whose code is shareCode. Knowing the ID of a shared
+// template is not enough to open it
+func (s *TemplateService) GetForUser(id int64, userID int64, shareCode string) (*domain.Template, error) {
+	template, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if template.OwnerID == 0 || template.OwnerID == userID {
+		return template, nil
+	}
+	if shareCode == "" {
+		return nil, ErrTemplateNotFound
+	}
+	shared, err := s.GetByShareCode(shareCode)
+	if errors.Is(err, ErrShareCodeNotFound) || (err == nil && shared.ID != id) {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return shared, nil
+}
+
+// GetByShareCode retrieves a shared template by its share code
+func (s *TemplateService) GetByShareCode(code string) (*domain.Template, error) {
+	template, err := s.repo.Template().GetByShareCode(strings.ToUpper(code))
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, ErrShareCodeNotFound
+	}
+	return template, nil
+}
+
+// CanManageSharing reports whether the user can share or revoke a template:
+// the owner of a personal template, or a super admin for global templates
+func CanManageSharing(template *domain.Template, userID int64, isSuperAdmin bool) bool {
+	if template.OwnerID == 0 {
+		return isSuperAdmin
+	}
+	return template.OwnerID == userID
+}
+
+// Share returns the template's share code, generating one if it isn't shared yet
+func (s *TemplateService) Share(id int64, userID int64, isSuperAdmin bool) (string, error) {
+	template, err := s.GetByID(id)
+	if err != nil {
+		return "", err
+	}
+	if !CanManageSharing(template, userID, isSuperAdmin) {
+		return "", ErrNotTemplateOwner
+	}
+	if template.ShareCode != "" {
+		return template.ShareCode, nil
+	}
+
+	// Generate unique code
+	var code string
+	for i := 0; i < 10; i++ {
+		code, err = util.GenerateID()
+		if err != nil {
+			return "", err
+		}
+		exists, err := s.repo.Template().ExistsByShareCode(code)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			break
+		}
+		if i == 9 {
+			return "", errors.New("failed to generate unique share code")
+		}
+	}
+
+	if err := s.repo.Template().UpdateShareCode(id, code); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// RevokeShare removes the template's share code, invalidating existing links
+func (s *TemplateService) RevokeShare(id int64, userID int64, isSuperAdmin bool) error {
+	template, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if !CanManageSharing(template, userID, isSuperAdmin) {
+		return ErrNotTemplateOwner
+	}
+	return s.repo.Template().UpdateShareCode(id, "")
 }
 
 // GetTasks returns all tasks for a template
@@ -113,168 +257,395 @@ func (s *TemplateService) GetTaskCount(templateID int64) (int, error) {
 	return s.repo.TemplateTask().CountByTemplateID(templateID)
 }
 
-// Delete deletes a template and its tasks
-func (s *TemplateService) Delete(id int64) error {
-	// Tasks are automatically deleted via CASCADE
-	return s.repo.Template().Delete(id)
+// Delete deletes a template and its tasks on behalf of userID
+func (s *TemplateService) Delete(id int64, userID int64) error {
+	template, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	return s.delete(template, userID)
 }
 
-// Count returns the total number of templates
+// DeletePersonal deletes a personal template owned by the user
+func (s *TemplateService) DeletePersonal(id int64, userID int64) error {
+	template, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if template.OwnerID == 0 || template.OwnerID != userID {
+		return ErrNotTemplateOwner
+	}
+	return s.delete(template, userID)
+}
+
+// delete removes a template and records who did it
+func (s *TemplateService) delete(template *domain.Template, userID int64) error {
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		entry := newTemplateEntry(template, userID, domain.AuditTemplateDelete)
+		// Tasks are automatically deleted via CASCADE
+		return []*domain.AuditEntry{entry}, repo.Template().Delete(template.ID)
+	})
+}
+
+// Count returns the number of global templates
 func (s *TemplateService) Count() (int, error) {
-	return s.repo.Template().Count()
+	return s.repo.Template().CountByOwner(0)
 }
 
+// CountAvailable returns the number of templates a user can start a challenge from:
+// global templates plus their own personal templates
+func (s *TemplateService) CountAvailable(userID int64) (int, error) {
+	global, err := s.repo.Template().CountByOwner(0)
+	if err != nil {
+		return 0, err
+	}
+	own, err := s.repo.Template().CountByOwner(userID)
+	if err != nil {
+		return 0, err
+	}
+	return global + own, nil
+}
+
+// Template edits below record a new template version in the same transaction, with editorID as its author
+
 // UpdateName updates the template name
-func (s *TemplateService) UpdateName(id int64, name string) error {
-	return s.repo.Template().UpdateName(id, name)
+func (s *TemplateService) UpdateName(id int64, name string, editorID int64) error {
+	template, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	entry := newTemplateEntry(template, editorID, domain.AuditTemplateRename)
+	entry.Before, entry.After = template.Name, name
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		if err := repo.Template().UpdateName(id, name); err != nil {
+			return nil, err
+		}
+		_, err := recordTemplateVersion(repo, id, editorID)
+		return []*domain.AuditEntry{entry}, err
+	})
 }
 
 // UpdateDescription updates the template description
-func (s *TemplateService) UpdateDescription(id int64, description string) error {
-	return s.repo.Template().UpdateDescription(id, description)
+func (s *TemplateService) UpdateDescription(id int64, description string, editorID int64) error {
+	template, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	entry := newTemplateEntry(template, editorID, domain.AuditTemplateDescription)
+	entry.Before, entry.After = template.Description, description
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		if err := repo.Template().UpdateDescription(id, description); err != nil {
+			return nil, err
+		}
+		_, err := recordTemplateVersion(repo, id, editorID)
+		return []*domain.AuditEntry{entry}, err
+	})
 }
 
 // UpdateDailyLimit updates the template daily limit
-func (s *TemplateService) UpdateDailyLimit(id int64, limit int) error {
-	return s.repo.Template().UpdateDailyLimit(id, limit)
+func (s *TemplateService) UpdateDailyLimit(id int64, limit int, editorID int64) error {
+	template, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	entry := newTemplateEntry(template, editorID, domain.AuditTemplateDailyLimit)
+	entry.Before, entry.After = strconv.Itoa(template.DailyTaskLimit), strconv.Itoa(limit)
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		if err := repo.Template().UpdateDailyLimit(id, limit); err != nil {
+			return nil, err
+		}
+		_, err := recordTemplateVersion(repo, id, editorID)
+		return []*domain.AuditEntry{entry}, err
+	})
 }
 
 // UpdateHideFutureTasks updates the template hide future tasks setting
-func (s *TemplateService) UpdateHideFutureTasks(id int64, hide bool) error {
-	return s.repo.Template().UpdateHideFutureTasks(id, hide)
+func (s *TemplateService) UpdateHideFutureTasks(id int64, hide bool, editorID int64) error {
+	template, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+	entry := newTemplateEntry(template, editorID, domain.AuditTemplateSequential)
+	entry.Before, entry.After = strconv.FormatBool(template.HideFutureTasks), strconv.FormatBool(hide)
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		if err := repo.Template().UpdateHideFutureTasks(id, hide); err != nil {
+			return nil, err
+		}
+		_, err := recordTemplateVersion(repo, id, editorID)
+		return []*domain.AuditEntry{entry}, err
+	})
 }
 
 // CreateTask creates a new task for a template
-func (s *TemplateService) CreateTask(task *domain.TemplateTask) error {
+func (s *TemplateService) CreateTask(task *domain.TemplateTask, editorID int64) error {
 	// Get max order number
 	maxOrder, err := s.repo.TemplateTask().GetMaxOrderNum(task.TemplateID)
 	if err != nil {
 		return err
 	}
 	task.OrderNum = maxOrder + 1
-	return s.repo.TemplateTask().Create(task)
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		if err := repo.TemplateTask().Create(task); err != nil {
+			return nil, err
+		}
+		_, err := recordTemplateVersion(repo, task.TemplateID, editorID)
+		return []*domain.AuditEntry{newTemplateTaskEntry(task, editorID, domain.AuditTemplateTaskCreate)}, err
+	})
 }
 
-// DeleteTask deletes a template task and renumbers remaining tasks
-func (s *TemplateService) DeleteTask(taskID int64, templateID int64) error {
-	if err := s.repo.TemplateTask().Delete(taskID); err != nil {
-		return err
+// CreateTasksBulk appends tasks to a template in a single transaction
+func (s *TemplateService) CreateTasksBulk(templateID int64, bulk []BulkTask, editorID int64) ([]*domain.TemplateTask, error) {
+	count, err := s.repo.TemplateTask().CountByTemplateID(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if count+len(bulk) > domain.MaxTasksPerChallenge {
+		return nil, ErrMaxTasksReached
 	}
 
-	// Renumber remaining tasks
-	tasks, err := s.repo.TemplateTask().GetByTemplateID(templateID)
+	maxOrder, err := s.repo.TemplateTask().GetMaxOrderNum(templateID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for i, t := range tasks {
-		if t.OrderNum != i+1 {
-			if err := s.repo.TemplateTask().UpdateOrderNum(t.ID, i+1); err != nil {
-				return err
-			}
+	tasks := make([]*domain.TemplateTask, 0, len(bulk))
+	for i, b := range bulk {
+		if b.Title == "" {
+			return nil, ErrEmptyTaskTitle
 		}
+		tasks = append(tasks, &domain.TemplateTask{
+			TemplateID:  templateID,
+			OrderNum:    maxOrder + i + 1,
+			Title:       b.Title,
+			Description: b.Description,
+		})
 	}
 
-	return nil
-}
-
-// MoveTask moves a template task to a new position
-func (s *TemplateService) MoveTask(taskID int64, templateID int64, newPosition int) error {
-	tasks, err := s.repo.TemplateTask().GetByTemplateID(templateID)
+	err = withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		template, err := repo.Template().GetByID(templateID)
+		if err != nil {
+			return nil, err
+		}
+		if template == nil {
+			return nil, ErrTemplateNotFound
+		}
+		entry := newTemplateEntry(template, editorID, domain.AuditTemplateTaskBulkCreate)
+		entry.After = strconv.Itoa(len(tasks))
+		if err := repo.TemplateTask().CreateBatch(tasks); err != nil {
+			return nil, err
+		}
+		_, err = recordTemplateVersion(repo, templateID, editorID)
+		return []*domain.AuditEntry{entry}, err
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return tasks, nil
+}
 
-	if newPosition < 1 || newPosition > len(tasks) {
-		return errors.New("invalid position")
-	}
+// DeleteTask deletes a template task and renumbers remaining tasks
+func (s *TemplateService) DeleteTask(taskID int64, templateID int64, editorID int64) error {
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		task, err := repo.TemplateTask().GetByID(taskID)
+		if err != nil {
+			return nil, err
+		}
+		if task == nil {
+			return nil, ErrTaskNotFound
+		}
+		entries := []*domain.AuditEntry{newTemplateTaskEntry(task, editorID, domain.AuditTemplateTaskDelete)}
 
-	// Find the task to move
-	var movingTask *domain.TemplateTask
-	var oldPosition int
-	for i, t := range tasks {
-		if t.ID == taskID {
-			movingTask = t
-			oldPosition = i + 1
-			break
+		if err := repo.TemplateTask().Delete(taskID); err != nil {
+			return nil, err
 		}
-	}
 
-	if movingTask == nil {
-		return errors.New("task not found")
-	}
+		// Renumber remaining tasks
+		tasks, err := repo.TemplateTask().GetByTemplateID(templateID)
+		if err != nil {
+			return nil, err
+		}
 
-	if oldPosition == newPosition {
-		return nil // No change needed
-	}
+		updates := make(map[int64]int)
+		for i, t := range tasks {
+			if t.OrderNum != i+1 {
+				updates[t.ID] = i + 1
+			}
+		}
+
+		if len(updates) > 0 {
+			if err := repo.TemplateTask().UpdateOrderNums(templateID, updates); err != nil {
+				return nil, err
+			}
+		}
+		_, err = recordTemplateVersion(repo, templateID, editorID)
+		return entries, err
+	})
+}
 
-	// Calculate new order numbers
-	updates := make(map[int64]int)
+// MoveTask moves a template task to a new position
+func (s *TemplateService) MoveTask(taskID int64, templateID int64, newPosition int, editorID int64) error {
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		tasks, err := repo.TemplateTask().GetByTemplateID(templateID)
+		if err != nil {
+			return nil, err
+		}
+
+		if newPosition < 1 || newPosition > len(tasks) {
+			return nil, errors.New("invalid position")
+		}
 
-	if newPosition < oldPosition {
-		// Moving up: shift tasks between newPosition and oldPosition down
-		for _, t := range tasks {
-			if t.OrderNum >= newPosition && t.OrderNum < oldPosition {
-				updates[t.ID] = t.OrderNum + 1
+		// Find the task to move
+		var movingTask *domain.TemplateTask
+		var oldPosition int
+		for i, t := range tasks {
+			if t.ID == taskID {
+				movingTask = t
+				oldPosition = i + 1
+				break
 			}
 		}
-	} else {
-		// Moving down: shift tasks between oldPosition and newPosition up
-		for _, t := range tasks {
-			if t.OrderNum > oldPosition && t.OrderNum <= newPosition {
-				updates[t.ID] = t.OrderNum - 1
+
+		if movingTask == nil {
+			return nil, errors.New("task not found")
+		}
+
+		if oldPosition == newPosition {
+			return nil, nil // No change needed, and no new version
+		}
+
+		// Calculate new order numbers
+		updates := make(map[int64]int)
+
+		if newPosition < oldPosition {
+			// Moving up: shift tasks between newPosition and oldPosition down
+			for _, t := range tasks {
+				if t.OrderNum >= newPosition && t.OrderNum < oldPosition {
+					updates[t.ID] = t.OrderNum + 1
+				}
+			}
+		} else {
+			// Moving down: shift tasks between oldPosition and newPosition up
+			for _, t := range tasks {
+				if t.OrderNum > oldPosition && t.OrderNum <= newPosition {
+					updates[t.ID] = t.OrderNum - 1
+				}
 			}
 		}
-	}
 
-	updates[taskID] = newPosition
+		updates[taskID] = newPosition
 
-	return s.repo.TemplateTask().UpdateOrderNums(templateID, updates)
+		if err := repo.TemplateTask().UpdateOrderNums(templateID, updates); err != nil {
+			return nil, err
+		}
+		entry := newTemplateTaskEntry(movingTask, editorID, domain.AuditTemplateTaskMove)
+		entry.Before, entry.After = strconv.Itoa(oldPosition), strconv.Itoa(newPosition)
+		_, err = recordTemplateVersion(repo, templateID, editorID)
+		return []*domain.AuditEntry{entry}, err
+	})
 }
 
 // UpdateTaskTitle updates a template task title
-func (s *TemplateService) UpdateTaskTitle(id int64, title string) error {
-	return s.repo.TemplateTask().UpdateTitle(id, title)
+func (s *TemplateService) UpdateTaskTitle(id int64, title string, editorID int64) error {
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		task, err := repo.TemplateTask().GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if task == nil {
+			return nil, ErrTaskNotFound
+		}
+		entry := newTemplateTaskEntry(task, editorID, domain.AuditTemplateTaskTitle)
+		entry.Before, entry.After = task.Title, title
+		if err := repo.TemplateTask().UpdateTitle(id, title); err != nil {
+			return nil, err
+		}
+		_, err = recordTemplateVersion(repo, task.TemplateID, editorID)
+		return []*domain.AuditEntry{entry}, err
+	})
 }
 
 // UpdateTaskDescription updates a template task description
-func (s *TemplateService) UpdateTaskDescription(id int64, description string) error {
-	return s.repo.TemplateTask().UpdateDescription(id, description)
+func (s *TemplateService) UpdateTaskDescription(id int64, description string, editorID int64) error {
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		task, err := repo.TemplateTask().GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if task == nil {
+			return nil, ErrTaskNotFound
+		}
+		entry := newTemplateTaskEntry(task, editorID, domain.AuditTemplateTaskDescription)
+		entry.Before, entry.After = task.Description, description
+		if err := repo.TemplateTask().UpdateDescription(id, description); err != nil {
+			return nil, err
+		}
+		_, err = recordTemplateVersion(repo, task.TemplateID, editorID)
+		return []*domain.AuditEntry{entry}, err
+	})
 }
 
 // UpdateTaskImage updates a template task image
-func (s *TemplateService) UpdateTaskImage(id int64, imageFileID string) error {
-	return s.repo.TemplateTask().UpdateImage(id, imageFileID)
+func (s *TemplateService) UpdateTaskImage(id int64, imageFileID string, editorID int64) error {
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		task, err := repo.TemplateTask().GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if task == nil {
+			return nil, ErrTaskNotFound
+		}
+		entry := newTemplateTaskEntry(task, editorID, domain.AuditTemplateTaskImage)
+		if err := repo.TemplateTask().UpdateImage(id, imageFileID); err != nil {
+			return nil, err
+		}
+		_, err = recordTemplateVersion(repo, task.TemplateID, editorID)
+		return []*domain.AuditEntry{entry}, err
+	})
 }
 
 // RandomizeTaskOrder randomizes the order of tasks in a template
-func (s *TemplateService) RandomizeTaskOrder(templateID int64) error {
-	tasks, err := s.repo.TemplateTask().GetByTemplateID(templateID)
-	if err != nil {
-		return err
-	}
+func (s *TemplateService) RandomizeTaskOrder(templateID int64, editorID int64) error {
+	return withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		tasks, err := repo.TemplateTask().GetByTemplateID(templateID)
+		if err != nil {
+			return nil, err
+		}
 
-	if len(tasks) < 2 {
-		return nil // Nothing to randomize
-	}
+		if len(tasks) < 2 {
+			return nil, nil // Nothing to randomize, and no new version
+		}
 
-	// Create shuffled positions
-	positions := make([]int, len(tasks))
-	for i := range positions {
-		positions[i] = i + 1
-	}
+		// Create shuffled positions
+		positions := make([]int, len(tasks))
+		for i := range positions {
+			positions[i] = i + 1
+		}
 
-	// Fisher-Yates shuffle
-	for i := len(positions) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
-		positions[i], positions[j] = positions[j], positions[i]
-	}
+		// Fisher-Yates shuffle
+		for i := len(positions) - 1; i > 0; i-- {
+			j := rand.Intn(i + 1)
+			positions[i], positions[j] = positions[j], positions[i]
+		}
 
-	// Build updates map
-	updates := make(map[int64]int)
-	for i, t := range tasks {
-		updates[t.ID] = positions[i]
-	}
+		// Build updates map
+		updates := make(map[int64]int)
+		for i, t := range tasks {
+			updates[t.ID] = positions[i]
+		}
 
-	return s.repo.TemplateTask().UpdateOrderNums(templateID, updates)
+		template, err := repo.Template().GetByID(templateID)
+		if err != nil {
+			return nil, err
+		}
+		if template == nil {
+			return nil, ErrTemplateNotFound
+		}
+
+		if err := repo.TemplateTask().UpdateOrderNums(templateID, updates); err != nil {
+			return nil, err
+		}
+		entry := newTemplateEntry(template, editorID, domain.AuditTemplateTaskShuffle)
+		_, err = recordTemplateVersion(repo, templateID, editorID)
+		return []*domain.AuditEntry{entry}, err
+	})
 }
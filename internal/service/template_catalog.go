@@ -0,0 +1,142 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+var (
+	ErrCategoryNotFound    = errors.New("template category not found")
+	ErrCategoryExists      = errors.New("template category with this name already exists")
+	ErrInvalidCategoryName = errors.New("invalid template category name")
+	ErrInvalidTags         = errors.New("invalid template tags")
+)
+
+// GetCatalogPage returns one page (0-based) of global templates matching the filter
+// and the total page count
+func (s *TemplateService) GetCatalogPage(filter domain.TemplateFilter, page int) ([]*domain.Template, int, error) {
+	count, err := s.repo.Template().CountFiltered(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalPages := (count + domain.TemplatesPageSize - 1) / domain.TemplatesPageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+
+	templates, err := s.repo.Template().Find(filter, domain.TemplatesPageSize, page*domain.TemplatesPageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	return templates, totalPages, nil
+}
+
+// GetCategories returns all template categories sorted by name
+func (s *TemplateService) GetCategories() ([]*domain.TemplateCategory, error) {
+	return s.repo.TemplateCategory().GetAll()
+}
+
+// GetCategory retrieves a template category by ID
+func (s *TemplateService) GetCategory(id int64) (*domain.TemplateCategory, error) {
+	category, err := s.repo.TemplateCategory().GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, ErrCategoryNotFound
+	}
+	return category, nil
+}
+
+// CountByCategory returns the number of global templates per category ID (0 = uncategorized)
+func (s *TemplateService) CountByCategory() (map[int64]int, error) {
+	return s.repo.Template().CountByCategory()
+}
+
+// CreateCategory creates a new template category
+func (s *TemplateService) CreateCategory(name string) (*domain.TemplateCategory, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > domain.MaxCategoryNameLength {
+		return nil, ErrInvalidCategoryName
+	}
+
+	exists, err := s.repo.TemplateCategory().ExistsByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrCategoryExists
+	}
+
+	category := &domain.TemplateCategory{Name: name}
+	if err := s.repo.TemplateCategory().Create(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// DeleteCategory deletes a category; its templates become uncategorized
+func (s *TemplateService) DeleteCategory(id int64) error {
+	return s.repo.TemplateCategory().Delete(id)
+}
+
+// SetCategory assigns a template to a category (0 = uncategorized)
+func (s *TemplateService) SetCategory(templateID, categoryID int64) error {
+	if categoryID != 0 {
+		if _, err := s.GetCategory(categoryID); err != nil {
+			return err
+		}
+	}
+	return s.repo.Template().UpdateCategory(templateID, categoryID)
+}
+
+// SetTags parses a comma-separated tag list and stores it on the template.
+// An empty input or "-" clears the tags.
+func (s *TemplateService) SetTags(templateID int64, input string) ([]string, error) {
+	tags, err := ParseTags(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Template().UpdateTags(templateID, strings.Join(tags, ",")); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// ParseTags normalizes a comma-separated tag list: lowercase, trimmed, without a
+// leading '#', deduplicated. An empty input or "-" yields no tags.
+func ParseTags(input string) ([]string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" || input == "-" {
+		return nil, nil
+	}
+
+	var tags []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(input, ",") {
+		tag := strings.ToLower(strings.TrimSpace(part))
+		tag = strings.TrimSpace(strings.TrimLeft(tag, "#"))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > domain.MaxTemplateTagLength {
+			return nil, ErrInvalidTags
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	if len(tags) > domain.MaxTemplateTags {
+		return nil, ErrInvalidTags
+	}
+	return tags, nil
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"-", nil, false},
+		{"Fitness, #morning ,fitness,, Beginner", []string{"fitness", "morning", "beginner"}, false},
+		{strings.Repeat("a", domain.MaxTemplateTagLength+1), nil, true},
+		{tooManyTags(), nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTags(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTags(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseTags(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func tooManyTags() string {
+	tags := make([]string, domain.MaxTemplateTags+1)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("tag%d", i)
+	}
+	return strings.Join(tags, ",")
+}
+
+func TestTemplateService_GetCatalogPage(t *testing.T) {
+	repo := setupTestRepo(t)
+	templateSvc := NewTemplateService(repo)
+
+	total := domain.TemplatesPageSize + 2
+	for i := 0; i < total; i++ {
+		repo.Template().Create(&domain.Template{Name: fmt.Sprintf("Template %d", i)})
+	}
+
+	templates, totalPages, err := templateSvc.GetCatalogPage(domain.TemplateFilter{}, 0)
+	if err != nil {
+		t.Fatalf("GetCatalogPage() error = %v", err)
+	}
+	if totalPages != 2 {
+		t.Errorf("totalPages = %d, want 2", totalPages)
+	}
+	if len(templates) != domain.TemplatesPageSize {
+		t.Errorf("first page size = %d, want %d", len(templates), domain.TemplatesPageSize)
+	}
+
+	// Out-of-range pages are clamped to the last page
+	templates, _, _ = templateSvc.GetCatalogPage(domain.TemplateFilter{}, 5)
+	if len(templates) != 2 {
+		t.Errorf("last page size = %d, want 2", len(templates))
+	}
+
+	// No matches still yields one (empty) page
+	templates, totalPages, _ = templateSvc.GetCatalogPage(domain.TemplateFilter{Query: "nothing"}, 0)
+	if len(templates) != 0 || totalPages != 1 {
+		t.Errorf("GetCatalogPage(no match) = %d templates, %d pages; want 0, 1", len(templates), totalPages)
+	}
+}
+
+func TestTemplateService_Categories(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	if _, err := templateSvc.CreateCategory("  "); err != ErrInvalidCategoryName {
+		t.Errorf("CreateCategory(blank) error = %v, want ErrInvalidCategoryName", err)
+	}
+	category, err := templateSvc.CreateCategory("Health")
+	if err != nil {
+		t.Fatalf("CreateCategory() error = %v", err)
+	}
+	if _, err := templateSvc.CreateCategory("Health"); err != ErrCategoryExists {
+		t.Errorf("CreateCategory(duplicate) error = %v, want ErrCategoryExists", err)
+	}
+
+	challenge, _ := challengeSvc.Create("Yoga", "", 12345, 0, false)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
+
+	if err := templateSvc.SetCategory(template.ID, 999); err != ErrCategoryNotFound {
+		t.Errorf("SetCategory(missing) error = %v, want ErrCategoryNotFound", err)
+	}
+	if err := templateSvc.SetCategory(template.ID, category.ID); err != nil {
+		t.Fatalf("SetCategory() error = %v", err)
+	}
+	if _, err := templateSvc.SetTags(template.ID, "Stretch, morning"); err != nil {
+		t.Fatalf("SetTags() error = %v", err)
+	}
+
+	updated, _ := templateSvc.GetByID(template.ID)
+	if updated.CategoryID != category.ID {
+		t.Errorf("CategoryID = %d, want %d", updated.CategoryID, category.ID)
+	}
+	if !reflect.DeepEqual(updated.TagList(), []string{"stretch", "morning"}) {
+		t.Errorf("TagList() = %v, want [stretch morning]", updated.TagList())
+	}
+
+	counts, _ := templateSvc.CountByCategory()
+	if counts[category.ID] != 1 {
+		t.Errorf("CountByCategory()[%d] = %d, want 1", category.ID, counts[category.ID])
+	}
+}
+
+func TestChallengeService_CreateFromTemplate_CountsUse(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	source, _ := challengeSvc.Create("Source", "", 12345, 0, false)
+	template, _ := templateSvc.CreateFromChallenge(source.ID, 0)
+
+	for i := 0; i < 2; i++ {
+		if _, err := challengeSvc.CreateFromTemplate(template, nil, "Cohort", 67890); err != nil {
+			t.Fatalf("CreateFromTemplate() error = %v", err)
+		}
+	}
+
+	updated, _ := templateSvc.GetByID(template.ID)
+	if updated.UseCount != 2 {
+		t.Errorf("UseCount = %d, want 2", updated.UseCount)
+	}
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+var ErrChallengeNotLinked = errors.New("challenge was not created from a template")
+
+// TemplateTaskChange pairs a challenge task with the edited template task it came from
+type TemplateTaskChange struct {
+	Task         *domain.Task
+	TemplateTask *domain.TemplateTask
+}
+
+// TitleChanged reports whether the template changed the task title
+func (c TemplateTaskChange) TitleChanged() bool {
+	return c.Task.Title != c.TemplateTask.Title
+}
+
+// DescriptionChanged reports whether the template changed the task description
+func (c TemplateTaskChange) DescriptionChanged() bool {
+	return c.Task.Description != c.TemplateTask.Description
+}
+
+// TemplateSyncDiff lists the template changes not yet applied to a challenge.
+// Tasks removed from the template are not propagated, so completions are never lost, and
+// tasks the challenge admin deleted are not brought back.
+type TemplateSyncDiff struct {
+	Challenge *domain.Challenge
+	Template  *domain.Template
+	Added     []*domain.TemplateTask
+	Changed   []TemplateTaskChange
+}
+
+// Empty reports whether the challenge is up to date with its template
+func (d *TemplateSyncDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0
+}
+
+// GetLinkedChallenges returns the challenges created from a template
+func (s *TemplateService) GetLinkedChallenges(templateID int64) ([]*domain.Challenge, error) {
+	return s.repo.Challenge().GetByTemplateID(templateID)
+}
+
+// DiffChallenge compares a challenge with the current state of its source template
+func (s *TemplateService) DiffChallenge(challengeID string) (*TemplateSyncDiff, error) {
+	challenge, err := s.repo.Challenge().GetByID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil {
+		return nil, ErrChallengeNotFound
+	}
+	if challenge.TemplateID == 0 {
+		return nil, ErrChallengeNotLinked
+	}
+
+	template, err := s.GetByID(challenge.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+	return s.diff(challenge, template)
+}
+
+// PreviewPush returns the pending changes for every challenge created from a template
+func (s *TemplateService) PreviewPush(templateID int64) ([]*TemplateSyncDiff, error) {
+	template, err := s.GetByID(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	challenges, err := s.repo.Challenge().GetByTemplateID(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]*TemplateSyncDiff, 0, len(challenges))
+	for _, challenge := range challenges {
+		diff, err := s.diff(challenge, template)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// ApplyUpdate applies the pending template changes to a challenge: edited titles and
+// descriptions are updated in place (keeping completions) and new tasks are appended.
+// Only the challenge admin (or a super admin) can apply an update.
+func (s *TemplateService) ApplyUpdate(challengeID string, userID int64, isSuperAdmin bool) (*TemplateSyncDiff, error) {
+	diff, err := s.DiffChallenge(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if diff.Challenge.CreatorID != userID && !isSuperAdmin {
+		return nil, ErrNotAdmin
+	}
+	if diff.Empty() {
+		return diff, nil
+	}
+
+	count, err := s.repo.Task().CountByChallengeID(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	if count+len(diff.Added) > domain.MaxTasksPerChallenge {
+		return nil, ErrMaxTasksReached
+	}
+
+	updated := make([]*domain.Task, 0, len(diff.Changed))
+	for _, change := range diff.Changed {
+		task := *change.Task
+		task.Title = change.TemplateTask.Title
+		task.Description = change.TemplateTask.Description
+		updated = append(updated, &task)
+	}
+
+	maxOrder, err := s.repo.Task().GetMaxOrderNum(challengeID)
+	if err != nil {
+		return nil, err
+	}
+	created := make([]*domain.Task, 0, len(diff.Added))
+	for i, tt := range diff.Added {
+		created = append(created, &domain.Task{
+			ChallengeID:    challengeID,
+			OrderNum:       maxOrder + i + 1,
+			Title:          tt.Title,
+			Description:    tt.Description,
+			ImageFileID:    tt.ImageFileID,
+			TemplateTaskID: tt.ID,
+		})
+	}
+
+	err = withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		if err := repo.Task().ApplyBatch(updated, created); err != nil {
+			return nil, err
+		}
+
+		entry := newChallengeEntry(diff.Challenge, userID, domain.AuditChallengeSync)
+		entry.TemplateID = diff.Template.ID
+		entry.Before = strconv.Itoa(diff.Challenge.TemplateVersion)
+
+		// The challenge is now in sync with the latest template version
+		latest, err := repo.TemplateVersion().GetLatest(diff.Template.ID)
+		if err != nil {
+			return nil, err
+		}
+		if latest != nil {
+			if err := repo.Challenge().UpdateTemplateVersion(challengeID, latest.Version); err != nil {
+				return nil, err
+			}
+			diff.Challenge.TemplateVersion = latest.Version
+		}
+		entry.After = strconv.Itoa(diff.Challenge.TemplateVersion)
+		return []*domain.AuditEntry{entry}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// diff matches challenge tasks to template tasks by their recorded source task. Only template
+// tasks added since the version the challenge was last synced to count as new; the others
+// have no match because the challenge admin deleted them. Tasks in the trash still count as
+// matched, so restoring one never leaves two tasks from the same template task
+func (s *TemplateService) diff(challenge *domain.Challenge, template *domain.Template) (*TemplateSyncDiff, error) {
+	templateTasks, err := s.repo.TemplateTask().GetByTemplateID(template.ID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.repo.Task().GetByChallengeID(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	synced, err := s.syncedTemplateTaskIDs(challenge)
+	if err != nil {
+		return nil, err
+	}
+	trashed, err := s.repo.Task().GetDeletedByChallengeID(challenge.ID)
+	if err != nil {
+		return nil, err
+	}
+	inTrash := make(map[int64]bool, len(trashed))
+	for _, task := range trashed {
+		inTrash[task.TemplateTaskID] = true
+	}
+
+	bySource := make(map[int64]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		if task.TemplateTaskID != 0 {
+			bySource[task.TemplateTaskID] = task
+		}
+	}
+
+	diff := &TemplateSyncDiff{Challenge: challenge, Template: template}
+	for _, tt := range templateTasks {
+		task, ok := bySource[tt.ID]
+		if !ok {
+			if !synced[tt.ID] && !inTrash[tt.ID] {
+				diff.Added = append(diff.Added, tt)
+			}
+			continue
+		}
+		change := TemplateTaskChange{Task: task, TemplateTask: tt}
+		if change.TitleChanged() || change.DescriptionChanged() {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+	return diff, nil
+}
+
+// syncedTemplateTaskIDs returns the template tasks in the version the challenge was last synced
+// to. It is empty for challenges created before templates had versions
+func (s *TemplateService) syncedTemplateTaskIDs(challenge *domain.Challenge) (map[int64]bool, error) {
+	synced := make(map[int64]bool)
+	if challenge.TemplateVersion == 0 {
+		return synced, nil
+	}
+
+	version, err := s.repo.TemplateVersion().GetByVersion(challenge.TemplateID, challenge.TemplateVersion)
+	if err != nil || version == nil {
+		return synced, err
+	}
+	tasks, err := s.repo.TemplateVersion().GetTasks(version.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		synced[task.TemplateTaskID] = true
+	}
+	return synced, nil
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+// setupLinkedChallenge creates a template with two tasks and a challenge created from it
+func setupLinkedChallenge(t *testing.T, templateSvc *TemplateService, challengeSvc *ChallengeService) (*domain.Template, *domain.Challenge) {
+	t.Helper()
+
+	source, err := challengeSvc.Create("Source", "", 12345, 0, false)
+	if err != nil {
+		t.Fatalf("Create challenge error = %v", err)
+	}
+	taskSvc := NewTaskService(templateSvc.repo)
+	taskSvc.Create(source.ID, "Task 1", "Desc 1", "", source.CreatorID)
+	taskSvc.Create(source.ID, "Task 2", "Desc 2", "", source.CreatorID)
+
+	template, err := templateSvc.CreateFromChallenge(source.ID, 0)
+	if err != nil {
+		t.Fatalf("CreateFromChallenge() error = %v", err)
+	}
+	templateTasks, _ := templateSvc.GetTasks(template.ID)
+
+	challenge, err := challengeSvc.CreateFromTemplate(template, templateTasks, "Cohort", 67890)
+	if err != nil {
+		t.Fatalf("CreateFromTemplate() error = %v", err)
+	}
+	return template, challenge
+}
+
+func TestChallengeService_CreateFromTemplate_LinksTemplate(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	template, challenge := setupLinkedChallenge(t, templateSvc, challengeSvc)
+
+	if challenge.TemplateID != template.ID {
+		t.Errorf("TemplateID = %d, want %d", challenge.TemplateID, template.ID)
+	}
+
+	templateTasks, _ := templateSvc.GetTasks(template.ID)
+	tasks, _ := repo.Task().GetByChallengeID(challenge.ID)
+	for i, task := range tasks {
+		if task.TemplateTaskID != templateTasks[i].ID {
+			t.Errorf("Task[%d] TemplateTaskID = %d, want %d", i, task.TemplateTaskID, templateTasks[i].ID)
+		}
+	}
+
+	linked, err := templateSvc.GetLinkedChallenges(template.ID)
+	if err != nil {
+		t.Fatalf("GetLinkedChallenges() error = %v", err)
+	}
+	if len(linked) != 1 || linked[0].ID != challenge.ID {
+		t.Errorf("GetLinkedChallenges() = %v, want [%s]", linked, challenge.ID)
+	}
+}
+
+func TestTemplateService_DiffChallenge(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	template, challenge := setupLinkedChallenge(t, templateSvc, challengeSvc)
+
+	diff, err := templateSvc.DiffChallenge(challenge.ID)
+	if err != nil {
+		t.Fatalf("DiffChallenge() error = %v", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("DiffChallenge() on fresh challenge should be empty, got %+v", diff)
+	}
+
+	templateTasks, _ := templateSvc.GetTasks(template.ID)
+	templateSvc.UpdateTaskTitle(templateTasks[0].ID, "Task 1 (harder)", 0)
+	templateSvc.CreateTask(&domain.TemplateTask{TemplateID: template.ID, Title: "Task 3"}, 0)
+
+	diff, err = templateSvc.DiffChallenge(challenge.ID)
+	if err != nil {
+		t.Fatalf("DiffChallenge() error = %v", err)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Title != "Task 3" {
+		t.Errorf("Added = %v, want [Task 3]", diff.Added)
+	}
+	if len(diff.Changed) != 1 {
+		t.Fatalf("Changed count = %d, want 1", len(diff.Changed))
+	}
+	change := diff.Changed[0]
+	if !change.TitleChanged() || change.DescriptionChanged() {
+		t.Errorf("TitleChanged() = %v, DescriptionChanged() = %v, want true, false",
+			change.TitleChanged(), change.DescriptionChanged())
+	}
+
+	// Challenges created from scratch are not linked
+	scratch, _ := challengeSvc.Create("Scratch", "", 12345, 0, false)
+	if _, err := templateSvc.DiffChallenge(scratch.ID); !errors.Is(err, ErrChallengeNotLinked) {
+		t.Errorf("DiffChallenge() on scratch challenge error = %v, want ErrChallengeNotLinked", err)
+	}
+}
+
+func TestTemplateService_ApplyUpdate(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+
+	template, challenge := setupLinkedChallenge(t, templateSvc, challengeSvc)
+
+	// A participant completes the first task
+	participant, err := participantSvc.Join(challenge.ID, 67890, "Alice", "🔥", 0)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	tasks, _ := repo.Task().GetByChallengeID(challenge.ID)
+	if _, err := completionSvc.Complete(tasks[0].ID, participant.ID); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+
+	templateTasks, _ := templateSvc.GetTasks(template.ID)
+	templateSvc.UpdateTaskTitle(templateTasks[0].ID, "Task 1 (harder)", 0)
+	templateSvc.UpdateTaskDescription(templateTasks[1].ID, "New desc 2", 0)
+	templateSvc.CreateTask(&domain.TemplateTask{TemplateID: template.ID, Title: "Task 3"}, 0)
+
+	// Only the challenge admin can apply
+	if _, err := templateSvc.ApplyUpdate(challenge.ID, 11111, false); !errors.Is(err, ErrNotAdmin) {
+		t.Errorf("ApplyUpdate() by non-admin error = %v, want ErrNotAdmin", err)
+	}
+
+	diff, err := templateSvc.ApplyUpdate(challenge.ID, 67890, false)
+	if err != nil {
+		t.Fatalf("ApplyUpdate() error = %v", err)
+	}
+	if len(diff.Added) != 1 || len(diff.Changed) != 2 {
+		t.Errorf("ApplyUpdate() added = %d, changed = %d, want 1, 2", len(diff.Added), len(diff.Changed))
+	}
+
+	updated, _ := repo.Task().GetByChallengeID(challenge.ID)
+	if len(updated) != 3 {
+		t.Fatalf("Task count = %d, want 3", len(updated))
+	}
+	if updated[0].ID != tasks[0].ID || updated[0].Title != "Task 1 (harder)" {
+		t.Errorf("Task[0] = (%d, %q), want (%d, %q)", updated[0].ID, updated[0].Title, tasks[0].ID, "Task 1 (harder)")
+	}
+	if updated[1].Description != "New desc 2" {
+		t.Errorf("Task[1] Description = %q, want %q", updated[1].Description, "New desc 2")
+	}
+	if updated[2].Title != "Task 3" || updated[2].OrderNum != 3 || updated[2].TemplateTaskID == 0 {
+		t.Errorf("Task[2] = %+v, want appended linked Task 3", updated[2])
+	}
+
+	// Existing completion survives the update
+	completed, err := completionSvc.IsCompleted(tasks[0].ID, participant.ID)
+	if err != nil {
+		t.Fatalf("IsCompleted() error = %v", err)
+	}
+	if !completed {
+		t.Error("Completion should be preserved after applying the update")
+	}
+
+	// Nothing left to apply
+	diff, err = templateSvc.DiffChallenge(challenge.ID)
+	if err != nil {
+		t.Fatalf("DiffChallenge() error = %v", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("DiffChallenge() after apply should be empty, got %+v", diff)
+	}
+}
+
+func TestTemplateService_ApplyUpdate_TaskLimit(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	template, challenge := setupLinkedChallenge(t, templateSvc, challengeSvc)
+
+	for i := 0; i < domain.MaxTasksPerChallenge; i++ {
+		templateSvc.CreateTask(&domain.TemplateTask{TemplateID: template.ID, Title: "Extra"}, 0)
+	}
+
+	if _, err := templateSvc.ApplyUpdate(challenge.ID, 67890, false); !errors.Is(err, ErrMaxTasksReached) {
+		t.Errorf("ApplyUpdate() error = %v, want ErrMaxTasksReached", err)
+	}
+
+	tasks, _ := repo.Task().GetByChallengeID(challenge.ID)
+	if len(tasks) != 2 {
+		t.Errorf("Task count = %d, want 2 (update must not be partially applied)", len(tasks))
+	}
+}
+
+func TestTemplateService_ApplyUpdate_KeepsDeletedTasksDeleted(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+	taskSvc := NewTaskService(repo)
+
+	template, challenge := setupLinkedChallenge(t, templateSvc, challengeSvc)
+
+	// The admin deletes a task that came from the template, and it is purged from the trash
+	tasks, _ := repo.Task().GetByChallengeID(challenge.ID)
+	if err := taskSvc.Delete(tasks[0].ID, challenge.ID, 67890); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	NewTrashService(repo, time.Hour).Purge(time.Now().Add(2 * time.Hour))
+
+	templateSvc.CreateTask(&domain.TemplateTask{TemplateID: template.ID, Title: "Task 3"}, 0)
+
+	diff, err := templateSvc.ApplyUpdate(challenge.ID, 67890, false)
+	if err != nil {
+		t.Fatalf("ApplyUpdate() error = %v", err)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Title != "Task 3" {
+		t.Errorf("Added = %+v, want only Task 3", diff.Added)
+	}
+
+	tasks, _ = repo.Task().GetByChallengeID(challenge.ID)
+	if len(tasks) != 2 || tasks[0].Title != "Task 2" || tasks[1].Title != "Task 3" {
+		t.Errorf("Tasks after update = %+v, want Task 2, Task 3", tasks)
+	}
+
+	// Later updates don't bring it back either
+	diff, _ = templateSvc.DiffChallenge(challenge.ID)
+	if !diff.Empty() {
+		t.Errorf("DiffChallenge() after update = %+v, want empty", diff)
+	}
+}
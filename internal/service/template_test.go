@@ -1,6 +1,8 @@
 package service
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
@@ -22,11 +24,11 @@ func TestTemplateService_CreateFromChallenge(t *testing.T) {
 	}
 
 	// Add tasks
-	taskSvc.Create(challenge.ID, "Task 1", "Desc 1", "")
-	taskSvc.Create(challenge.ID, "Task 2", "", "img123")
+	taskSvc.Create(challenge.ID, "Task 1", "Desc 1", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 2", "", "img123", challenge.CreatorID)
 
 	// Create template from challenge
-	template, err := templateSvc.CreateFromChallenge(challenge.ID)
+	template, err := templateSvc.CreateFromChallenge(challenge.ID, 0)
 	if err != nil {
 		t.Fatalf("CreateFromChallenge() error = %v", err)
 	}
@@ -70,13 +72,13 @@ func TestTemplateService_CreateFromChallenge_DuplicateName(t *testing.T) {
 	challenge, _ := challengeSvc.Create("Duplicate Name", "", 12345, 0, false)
 
 	// Create first template
-	_, err := templateSvc.CreateFromChallenge(challenge.ID)
+	_, err := templateSvc.CreateFromChallenge(challenge.ID, 0)
 	if err != nil {
 		t.Fatalf("First CreateFromChallenge() error = %v", err)
 	}
 
 	// Try to create duplicate
-	_, err = templateSvc.CreateFromChallenge(challenge.ID)
+	_, err = templateSvc.CreateFromChallenge(challenge.ID, 0)
 	if err != ErrTemplateNameExists {
 		t.Errorf("Second CreateFromChallenge() error = %v, want ErrTemplateNameExists", err)
 	}
@@ -86,7 +88,7 @@ func TestTemplateService_CreateFromChallenge_NotFound(t *testing.T) {
 	repo := setupTestRepo(t)
 	templateSvc := NewTemplateService(repo)
 
-	_, err := templateSvc.CreateFromChallenge("NOTEXIST")
+	_, err := templateSvc.CreateFromChallenge("NOTEXIST", 0)
 	if err != ErrChallengeNotFound {
 		t.Errorf("CreateFromChallenge(non-existent) error = %v, want ErrChallengeNotFound", err)
 	}
@@ -98,7 +100,7 @@ func TestTemplateService_GetByID(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	created, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	created, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
 	got, err := templateSvc.GetByID(created.ID)
 	if err != nil {
@@ -132,8 +134,8 @@ func TestTemplateService_GetAll(t *testing.T) {
 	// Create templates
 	ch1, _ := challengeSvc.Create("Template 1", "", 12345, 0, false)
 	ch2, _ := challengeSvc.Create("Template 2", "", 12345, 0, false)
-	templateSvc.CreateFromChallenge(ch1.ID)
-	templateSvc.CreateFromChallenge(ch2.ID)
+	templateSvc.CreateFromChallenge(ch1.ID, 0)
+	templateSvc.CreateFromChallenge(ch2.ID, 0)
 
 	templates, err = templateSvc.GetAll()
 	if err != nil {
@@ -150,9 +152,9 @@ func TestTemplateService_Delete(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
-	err := templateSvc.Delete(template.ID)
+	err := templateSvc.Delete(template.ID, 12345)
 	if err != nil {
 		t.Fatalf("Delete() error = %v", err)
 	}
@@ -169,9 +171,9 @@ func TestTemplateService_UpdateName(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Original", "", 12345, 0, false)
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
-	err := templateSvc.UpdateName(template.ID, "Updated Name")
+	err := templateSvc.UpdateName(template.ID, "Updated Name", 0)
 	if err != nil {
 		t.Fatalf("UpdateName() error = %v", err)
 	}
@@ -188,9 +190,9 @@ func TestTemplateService_UpdateDescription(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
-	err := templateSvc.UpdateDescription(template.ID, "New Description")
+	err := templateSvc.UpdateDescription(template.ID, "New Description", 0)
 	if err != nil {
 		t.Fatalf("UpdateDescription() error = %v", err)
 	}
@@ -207,9 +209,9 @@ func TestTemplateService_UpdateDailyLimit(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
-	err := templateSvc.UpdateDailyLimit(template.ID, 10)
+	err := templateSvc.UpdateDailyLimit(template.ID, 10, 0)
 	if err != nil {
 		t.Fatalf("UpdateDailyLimit() error = %v", err)
 	}
@@ -226,9 +228,9 @@ func TestTemplateService_UpdateHideFutureTasks(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
-	err := templateSvc.UpdateHideFutureTasks(template.ID, true)
+	err := templateSvc.UpdateHideFutureTasks(template.ID, true, 0)
 	if err != nil {
 		t.Fatalf("UpdateHideFutureTasks() error = %v", err)
 	}
@@ -245,14 +247,14 @@ func TestTemplateService_CreateTask(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
 	task := &domain.TemplateTask{
 		TemplateID:  template.ID,
 		Title:       "New Task",
 		Description: "Task Description",
 	}
-	err := templateSvc.CreateTask(task)
+	err := templateSvc.CreateTask(task, 0)
 	if err != nil {
 		t.Fatalf("CreateTask() error = %v", err)
 	}
@@ -266,7 +268,7 @@ func TestTemplateService_CreateTask(t *testing.T) {
 		TemplateID: template.ID,
 		Title:      "Second Task",
 	}
-	templateSvc.CreateTask(task2)
+	templateSvc.CreateTask(task2, 0)
 	if task2.OrderNum != 2 {
 		t.Errorf("CreateTask() second task OrderNum = %d, want 2", task2.OrderNum)
 	}
@@ -279,11 +281,11 @@ func TestTemplateService_DeleteTask(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	taskSvc.Create(challenge.ID, "Task 1", "", "")
-	taskSvc.Create(challenge.ID, "Task 2", "", "")
-	taskSvc.Create(challenge.ID, "Task 3", "", "")
+	taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 3", "", "", challenge.CreatorID)
 
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
 	tasks, _ := templateSvc.GetTasks(template.ID)
 	if len(tasks) != 3 {
@@ -291,7 +293,7 @@ func TestTemplateService_DeleteTask(t *testing.T) {
 	}
 
 	// Delete middle task
-	err := templateSvc.DeleteTask(tasks[1].ID, template.ID)
+	err := templateSvc.DeleteTask(tasks[1].ID, template.ID, 0)
 	if err != nil {
 		t.Fatalf("DeleteTask() error = %v", err)
 	}
@@ -316,15 +318,15 @@ func TestTemplateService_MoveTask(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	taskSvc.Create(challenge.ID, "Task A", "", "")
-	taskSvc.Create(challenge.ID, "Task B", "", "")
-	taskSvc.Create(challenge.ID, "Task C", "", "")
+	taskSvc.Create(challenge.ID, "Task A", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task B", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task C", "", "", challenge.CreatorID)
 
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
 	tasks, _ := templateSvc.GetTasks(template.ID)
 	// Move Task C (position 3) to position 1
-	err := templateSvc.MoveTask(tasks[2].ID, template.ID, 1)
+	err := templateSvc.MoveTask(tasks[2].ID, template.ID, 1, 0)
 	if err != nil {
 		t.Fatalf("MoveTask() error = %v", err)
 	}
@@ -349,17 +351,17 @@ func TestTemplateService_MoveTask_InvalidPosition(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	taskSvc.Create(challenge.ID, "Task 1", "", "")
+	taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
 
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 	tasks, _ := templateSvc.GetTasks(template.ID)
 
-	err := templateSvc.MoveTask(tasks[0].ID, template.ID, 5)
+	err := templateSvc.MoveTask(tasks[0].ID, template.ID, 5, 0)
 	if err == nil {
 		t.Error("MoveTask() with invalid position should return error")
 	}
 
-	err = templateSvc.MoveTask(tasks[0].ID, template.ID, 0)
+	err = templateSvc.MoveTask(tasks[0].ID, template.ID, 0, 0)
 	if err == nil {
 		t.Error("MoveTask() with position 0 should return error")
 	}
@@ -373,14 +375,14 @@ func TestTemplateService_RandomizeTaskOrder(t *testing.T) {
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
 	for i := 0; i < 10; i++ {
-		taskSvc.Create(challenge.ID, "Task", "", "")
+		taskSvc.Create(challenge.ID, "Task", "", "", challenge.CreatorID)
 	}
 
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
 	// Randomize multiple times to ensure it works
 	for i := 0; i < 5; i++ {
-		err := templateSvc.RandomizeTaskOrder(template.ID)
+		err := templateSvc.RandomizeTaskOrder(template.ID, 0)
 		if err != nil {
 			t.Fatalf("RandomizeTaskOrder() error = %v", err)
 		}
@@ -412,12 +414,12 @@ func TestTemplateService_RandomizeTaskOrder_SingleTask(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	taskSvc.Create(challenge.ID, "Only Task", "", "")
+	taskSvc.Create(challenge.ID, "Only Task", "", "", challenge.CreatorID)
 
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
 	// Should not error with single task
-	err := templateSvc.RandomizeTaskOrder(template.ID)
+	err := templateSvc.RandomizeTaskOrder(template.ID, 0)
 	if err != nil {
 		t.Fatalf("RandomizeTaskOrder() with single task error = %v", err)
 	}
@@ -438,8 +440,8 @@ func TestTemplateService_Count(t *testing.T) {
 
 	ch1, _ := challengeSvc.Create("Template 1", "", 12345, 0, false)
 	ch2, _ := challengeSvc.Create("Template 2", "", 12345, 0, false)
-	templateSvc.CreateFromChallenge(ch1.ID)
-	templateSvc.CreateFromChallenge(ch2.ID)
+	templateSvc.CreateFromChallenge(ch1.ID, 0)
+	templateSvc.CreateFromChallenge(ch2.ID, 0)
 
 	count, err = templateSvc.Count()
 	if err != nil {
@@ -457,11 +459,11 @@ func TestTemplateService_GetTaskCount(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	taskSvc.Create(challenge.ID, "Task 1", "", "")
-	taskSvc.Create(challenge.ID, "Task 2", "", "")
-	taskSvc.Create(challenge.ID, "Task 3", "", "")
+	taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 2", "", "", challenge.CreatorID)
+	taskSvc.Create(challenge.ID, "Task 3", "", "", challenge.CreatorID)
 
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 
 	count, err := templateSvc.GetTaskCount(template.ID)
 	if err != nil {
@@ -479,12 +481,12 @@ func TestTemplateService_UpdateTaskTitle(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	taskSvc.Create(challenge.ID, "Original", "", "")
+	taskSvc.Create(challenge.ID, "Original", "", "", challenge.CreatorID)
 
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 	tasks, _ := templateSvc.GetTasks(template.ID)
 
-	err := templateSvc.UpdateTaskTitle(tasks[0].ID, "Updated Title")
+	err := templateSvc.UpdateTaskTitle(tasks[0].ID, "Updated Title", 0)
 	if err != nil {
 		t.Fatalf("UpdateTaskTitle() error = %v", err)
 	}
@@ -502,12 +504,12 @@ func TestTemplateService_UpdateTaskDescription(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	taskSvc.Create(challenge.ID, "Task", "", "")
+	taskSvc.Create(challenge.ID, "Task", "", "", challenge.CreatorID)
 
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 	tasks, _ := templateSvc.GetTasks(template.ID)
 
-	err := templateSvc.UpdateTaskDescription(tasks[0].ID, "New Description")
+	err := templateSvc.UpdateTaskDescription(tasks[0].ID, "New Description", 0)
 	if err != nil {
 		t.Fatalf("UpdateTaskDescription() error = %v", err)
 	}
@@ -525,12 +527,12 @@ func TestTemplateService_UpdateTaskImage(t *testing.T) {
 	templateSvc := NewTemplateService(repo)
 
 	challenge, _ := challengeSvc.Create("Test", "", 12345, 0, false)
-	taskSvc.Create(challenge.ID, "Task", "", "")
+	taskSvc.Create(challenge.ID, "Task", "", "", challenge.CreatorID)
 
-	template, _ := templateSvc.CreateFromChallenge(challenge.ID)
+	template, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
 	tasks, _ := templateSvc.GetTasks(template.ID)
 
-	err := templateSvc.UpdateTaskImage(tasks[0].ID, "new_image_id")
+	err := templateSvc.UpdateTaskImage(tasks[0].ID, "new_image_id", 0)
 	if err != nil {
 		t.Fatalf("UpdateTaskImage() error = %v", err)
 	}
@@ -540,3 +542,187 @@ func TestTemplateService_UpdateTaskImage(t *testing.T) {
 		t.Errorf("UpdateTaskImage() ImageFileID = %q, want %q", updated.ImageFileID, "new_image_id")
 	}
 }
+
+func TestTemplateService_SaveAsPersonal(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	challenge, _ := challengeSvc.Create("Cohort", "", 12345, 2, false)
+	taskSvc.Create(challenge.ID, "Task 1", "", "", challenge.CreatorID)
+
+	// Only the challenge admin can save it
+	if _, err := templateSvc.SaveAsPersonal(challenge.ID, 999, false); err != ErrNotAdmin {
+		t.Errorf("SaveAsPersonal() by non-admin error = %v, want ErrNotAdmin", err)
+	}
+
+	template, err := templateSvc.SaveAsPersonal(challenge.ID, 12345, false)
+	if err != nil {
+		t.Fatalf("SaveAsPersonal() error = %v", err)
+	}
+	if template.OwnerID != 12345 {
+		t.Errorf("OwnerID = %d, want 12345", template.OwnerID)
+	}
+	if count, _ := templateSvc.GetTaskCount(template.ID); count != 1 {
+		t.Errorf("GetTaskCount() = %d, want 1", count)
+	}
+
+	// Same name is rejected for the same owner
+	if _, err := templateSvc.SaveAsPersonal(challenge.ID, 12345, false); err != ErrTemplateNameExists {
+		t.Errorf("SaveAsPersonal() duplicate error = %v, want ErrTemplateNameExists", err)
+	}
+
+	// A global template with the same name is independent of the personal one
+	if _, err := templateSvc.CreateFromChallenge(challenge.ID, 0); err != nil {
+		t.Fatalf("CreateFromChallenge() error = %v", err)
+	}
+
+	// Personal templates stay out of the global list
+	global, _ := templateSvc.GetAll()
+	if len(global) != 1 || global[0].OwnerID != 0 {
+		t.Errorf("GetAll() = %v, want only the global template", global)
+	}
+	mine, _ := templateSvc.GetByOwner(12345)
+	if len(mine) != 1 || mine[0].ID != template.ID {
+		t.Errorf("GetByOwner() = %v, want the personal template", mine)
+	}
+	if count, _ := templateSvc.Count(); count != 1 {
+		t.Errorf("Count() = %d, want 1", count)
+	}
+	if count, _ := templateSvc.CountAvailable(12345); count != 2 {
+		t.Errorf("CountAvailable(owner) = %d, want 2", count)
+	}
+	if count, _ := templateSvc.CountAvailable(999); count != 1 {
+		t.Errorf("CountAvailable(other) = %d, want 1", count)
+	}
+}
+
+func TestTemplateService_SaveAsPersonal_Limit(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	for i := 0; i < domain.MaxTemplatesPerUser; i++ {
+		repo.Template().Create(&domain.Template{Name: fmt.Sprintf("Tpl %d", i), OwnerID: 12345})
+	}
+
+	challenge, _ := challengeSvc.Create("One more", "", 12345, 0, false)
+	if _, err := templateSvc.SaveAsPersonal(challenge.ID, 12345, false); err != ErrMaxTemplatesReached {
+		t.Errorf("SaveAsPersonal() error = %v, want ErrMaxTemplatesReached", err)
+	}
+}
+
+func TestTemplateService_PersonalAccess(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	challenge, _ := challengeSvc.Create("Private", "", 12345, 0, false)
+	template, _ := templateSvc.SaveAsPersonal(challenge.ID, 12345, false)
+
+	if _, err := templateSvc.GetForUser(template.ID, 12345, ""); err != nil {
+		t.Errorf("GetForUser(owner) error = %v", err)
+	}
+	if _, err := templateSvc.GetForUser(template.ID, 999, ""); err != ErrTemplateNotFound {
+		t.Errorf("GetForUser(other) error = %v, want ErrTemplateNotFound", err)
+	}
+
+	global, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
+	if _, err := templateSvc.GetForUser(global.ID, 999, ""); err != nil {
+		t.Errorf("GetForUser(global) error = %v", err)
+	}
+
+	// Only the owner can delete a personal template; global ones are managed by super admins
+	if err := templateSvc.DeletePersonal(template.ID, 999); err != ErrNotTemplateOwner {
+		t.Errorf("DeletePersonal(other) error = %v, want ErrNotTemplateOwner", err)
+	}
+	if err := templateSvc.DeletePersonal(global.ID, 12345); err != ErrNotTemplateOwner {
+		t.Errorf("DeletePersonal(global) error = %v, want ErrNotTemplateOwner", err)
+	}
+	if err := templateSvc.DeletePersonal(template.ID, 12345); err != nil {
+		t.Fatalf("DeletePersonal(owner) error = %v", err)
+	}
+	if _, err := templateSvc.GetByID(template.ID); err != ErrTemplateNotFound {
+		t.Errorf("GetByID() after delete error = %v, want ErrTemplateNotFound", err)
+	}
+}
+
+func TestTemplateService_Share(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	challenge, _ := challengeSvc.Create("Shared", "", 12345, 0, false)
+	personal, _ := templateSvc.SaveAsPersonal(challenge.ID, 12345, false)
+	global, _ := templateSvc.CreateFromChallenge(challenge.ID, 0)
+
+	// Only the owner can share a personal template, only super admins a global one
+	if _, err := templateSvc.Share(personal.ID, 999, false); err != ErrNotTemplateOwner {
+		t.Errorf("Share(personal, other) error = %v, want ErrNotTemplateOwner", err)
+	}
+	if _, err := templateSvc.Share(global.ID, 12345, false); err != ErrNotTemplateOwner {
+		t.Errorf("Share(global, non-super-admin) error = %v, want ErrNotTemplateOwner", err)
+	}
+	if _, err := templateSvc.Share(global.ID, 999, true); err != nil {
+		t.Errorf("Share(global, super admin) error = %v", err)
+	}
+
+	// Not visible to others until shared
+	if _, err := templateSvc.GetForUser(personal.ID, 999, ""); err != ErrTemplateNotFound {
+		t.Errorf("GetForUser() before share error = %v, want ErrTemplateNotFound", err)
+	}
+
+	code, err := templateSvc.Share(personal.ID, 12345, false)
+	if err != nil {
+		t.Fatalf("Share() error = %v", err)
+	}
+	if len(code) != 8 {
+		t.Errorf("Share() code = %q, want 8 characters", code)
+	}
+
+	// Sharing again keeps the same code
+	again, _ := templateSvc.Share(personal.ID, 12345, false)
+	if again != code {
+		t.Errorf("Share() again = %q, want %q", again, code)
+	}
+
+	found, err := templateSvc.GetByShareCode(strings.ToLower(code))
+	if err != nil {
+		t.Fatalf("GetByShareCode() error = %v", err)
+	}
+	if found.ID != personal.ID {
+		t.Errorf("GetByShareCode() ID = %d, want %d", found.ID, personal.ID)
+	}
+	if _, err := templateSvc.GetForUser(personal.ID, 999, strings.ToLower(code)); err != nil {
+		t.Errorf("GetForUser() with share code error = %v", err)
+	}
+
+	// Sharing doesn't expose the template to anyone who only knows its ID
+	if _, err := templateSvc.GetForUser(personal.ID, 999, ""); err != ErrTemplateNotFound {
+		t.Errorf("GetForUser() without share code error = %v, want ErrTemplateNotFound", err)
+	}
+	otherChallenge, _ := challengeSvc.Create("Other", "", 777, 0, false)
+	other, _ := templateSvc.SaveAsPersonal(otherChallenge.ID, 777, false)
+	otherCode, _ := templateSvc.Share(other.ID, 777, false)
+	if _, err := templateSvc.GetForUser(personal.ID, 999, otherCode); err != ErrTemplateNotFound {
+		t.Errorf("GetForUser() with another template's code error = %v, want ErrTemplateNotFound", err)
+	}
+
+	// Revoking invalidates the code
+	if err := templateSvc.RevokeShare(personal.ID, 999, false); err != ErrNotTemplateOwner {
+		t.Errorf("RevokeShare(other) error = %v, want ErrNotTemplateOwner", err)
+	}
+	if err := templateSvc.RevokeShare(personal.ID, 12345, false); err != nil {
+		t.Fatalf("RevokeShare() error = %v", err)
+	}
+	if _, err := templateSvc.GetByShareCode(code); err != ErrShareCodeNotFound {
+		t.Errorf("GetByShareCode() after revoke error = %v, want ErrShareCodeNotFound", err)
+	}
+	if _, err := templateSvc.GetForUser(personal.ID, 999, code); err != ErrTemplateNotFound {
+		t.Errorf("GetForUser() after revoke error = %v, want ErrTemplateNotFound", err)
+	}
+	if _, err := templateSvc.GetByShareCode(""); err != ErrShareCodeNotFound {
+		t.Errorf("GetByShareCode(\"\") error = %v, want ErrShareCodeNotFound", err)
+	}
+}
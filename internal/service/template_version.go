@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+var ErrTemplateVersionNotFound = errors.New("template version not found")
+
+// recordTemplateVersion snapshots the current settings and tasks of a template as a new version
+func recordTemplateVersion(repo repository.Repository, templateID int64, editorID int64) (*domain.TemplateVersion, error) {
+	template, err := repo.Template().GetByID(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil {
+		return nil, ErrTemplateNotFound
+	}
+
+	tasks, err := repo.TemplateTask().GetByTemplateID(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	version := &domain.TemplateVersion{
+		TemplateID:      templateID,
+		EditorID:        editorID,
+		Name:            template.Name,
+		Description:     template.Description,
+		DailyTaskLimit:  template.DailyTaskLimit,
+		HideFutureTasks: template.HideFutureTasks,
+	}
+	snapshot := make([]*domain.TemplateVersionTask, 0, len(tasks))
+	for _, t := range tasks {
+		snapshot = append(snapshot, &domain.TemplateVersionTask{
+			TemplateTaskID: t.ID,
+			OrderNum:       t.OrderNum,
+			Title:          t.Title,
+			Description:    t.Description,
+			ImageFileID:    t.ImageFileID,
+		})
+	}
+
+	if err := repo.TemplateVersion().Create(version, snapshot); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// GetVersionsPage returns one page of a template's versions (newest first) and the total number of pages
+func (s *TemplateService) GetVersionsPage(templateID int64, page int) ([]*domain.TemplateVersion, int, error) {
+	count, err := s.repo.TemplateVersion().CountByTemplateID(templateID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalPages := (count + domain.TemplateVersionsPageSize - 1) / domain.TemplateVersionsPageSize
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	if page >= totalPages {
+		page = totalPages - 1
+	}
+
+	versions, err := s.repo.TemplateVersion().GetByTemplateID(
+		templateID,
+		domain.TemplateVersionsPageSize,
+		page*domain.TemplateVersionsPageSize,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	return versions, totalPages, nil
+}
+
+// GetVersion retrieves a template version by ID
+func (s *TemplateService) GetVersion(versionID int64) (*domain.TemplateVersion, error) {
+	version, err := s.repo.TemplateVersion().GetByID(versionID)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		return nil, ErrTemplateVersionNotFound
+	}
+	return version, nil
+}
+
+// GetVersionTasks returns the task list of a template version
+func (s *TemplateService) GetVersionTasks(versionID int64) ([]*domain.TemplateVersionTask, error) {
+	return s.repo.TemplateVersion().GetTasks(versionID)
+}
+
+// GetLatestVersion returns the current version of a template (nil if none was recorded)
+func (s *TemplateService) GetLatestVersion(templateID int64) (*domain.TemplateVersion, error) {
+	return s.repo.TemplateVersion().GetLatest(templateID)
+}
+
+// RestoreVersion brings a template back to an older version. Tasks keep their identity,
+// so challenges linked to the template still match them. The restore is itself recorded
+// as a new version, so it can be undone.
+func (s *TemplateService) RestoreVersion(versionID int64, editorID int64) (*domain.TemplateVersion, error) {
+	version, err := s.GetVersion(versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.GetByID(version.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if version.Name != template.Name {
+		exists, err := s.repo.Template().ExistsByName(version.Name, template.OwnerID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrTemplateNameExists
+		}
+	}
+
+	snapshot, err := s.repo.TemplateVersion().GetTasks(versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Name = version.Name
+	template.Description = version.Description
+	template.DailyTaskLimit = version.DailyTaskLimit
+	template.HideFutureTasks = version.HideFutureTasks
+
+	tasks := make([]*domain.TemplateTask, 0, len(snapshot))
+	for _, t := range snapshot {
+		tasks = append(tasks, &domain.TemplateTask{
+			ID:          t.TemplateTaskID,
+			TemplateID:  template.ID,
+			OrderNum:    t.OrderNum,
+			Title:       t.Title,
+			Description: t.Description,
+			ImageFileID: t.ImageFileID,
+		})
+	}
+
+	var restored *domain.TemplateVersion
+	err = withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		latest, err := repo.TemplateVersion().GetLatest(template.ID)
+		if err != nil {
+			return nil, err
+		}
+		entry := newTemplateEntry(template, editorID, domain.AuditTemplateRestore)
+		entry.After = strconv.Itoa(version.Version)
+		if latest != nil {
+			entry.Before = strconv.Itoa(latest.Version)
+		}
+
+		if err := repo.Template().Restore(template, tasks); err != nil {
+			return nil, err
+		}
+		restored, err = recordTemplateVersion(repo, template.ID, editorID)
+		return []*domain.AuditEntry{entry}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
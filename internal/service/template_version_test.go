@@ -0,0 +1,264 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+func TestTemplateService_VersionHistory(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+	taskSvc := NewTaskService(repo)
+
+	source, _ := challengeSvc.Create("History", "", 12345, 0, false)
+	taskSvc.Create(source.ID, "Task 1", "", "", source.CreatorID)
+	taskSvc.Create(source.ID, "Task 2", "", "", source.CreatorID)
+
+	template, err := templateSvc.CreateFromChallenge(source.ID, 111)
+	if err != nil {
+		t.Fatalf("CreateFromChallenge() error = %v", err)
+	}
+
+	latest, err := templateSvc.GetLatestVersion(template.ID)
+	if err != nil {
+		t.Fatalf("GetLatestVersion() error = %v", err)
+	}
+	if latest == nil || latest.Version != 1 || latest.EditorID != 111 {
+		t.Fatalf("Initial version = %+v, want version 1 by 111", latest)
+	}
+
+	tasks, _ := templateSvc.GetTasks(template.ID)
+	templateSvc.UpdateTaskTitle(tasks[0].ID, "Task 1 edited", 222)
+	templateSvc.UpdateDailyLimit(template.ID, 3, 222)
+	templateSvc.RandomizeTaskOrder(template.ID, 333)
+
+	versions, totalPages, err := templateSvc.GetVersionsPage(template.ID, 0)
+	if err != nil {
+		t.Fatalf("GetVersionsPage() error = %v", err)
+	}
+	if totalPages != 1 || len(versions) != 4 {
+		t.Fatalf("GetVersionsPage() = %d versions, %d pages, want 4, 1", len(versions), totalPages)
+	}
+	// Newest first
+	if versions[0].Version != 4 || versions[0].EditorID != 333 {
+		t.Errorf("versions[0] = v%d by %d, want v4 by 333", versions[0].Version, versions[0].EditorID)
+	}
+	if versions[2].Version != 2 || versions[2].EditorID != 222 {
+		t.Errorf("versions[2] = v%d by %d, want v2 by 222", versions[2].Version, versions[2].EditorID)
+	}
+
+	v2Tasks, err := templateSvc.GetVersionTasks(versions[2].ID)
+	if err != nil {
+		t.Fatalf("GetVersionTasks() error = %v", err)
+	}
+	if len(v2Tasks) != 2 || v2Tasks[0].Title != "Task 1 edited" || v2Tasks[0].TemplateTaskID != tasks[0].ID {
+		t.Errorf("v2 tasks = %+v, want edited Task 1 first", v2Tasks)
+	}
+	if versions[1].DailyTaskLimit != 3 {
+		t.Errorf("v3 DailyTaskLimit = %d, want 3", versions[1].DailyTaskLimit)
+	}
+
+	// Challenges record the version they were created from
+	current, _ := templateSvc.GetTasks(template.ID)
+	challenge, err := challengeSvc.CreateFromTemplate(template, current, "Cohort", 67890)
+	if err != nil {
+		t.Fatalf("CreateFromTemplate() error = %v", err)
+	}
+	if challenge.TemplateVersion != 4 {
+		t.Errorf("TemplateVersion = %d, want 4", challenge.TemplateVersion)
+	}
+}
+
+func TestTemplateService_VersionsPagination(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	source, _ := challengeSvc.Create("Paged", "", 12345, 0, false)
+	template, _ := templateSvc.CreateFromChallenge(source.ID, 0)
+	for i := 0; i < domain.TemplateVersionsPageSize; i++ {
+		templateSvc.UpdateDescription(template.ID, "edit", 0)
+	}
+
+	versions, totalPages, err := templateSvc.GetVersionsPage(template.ID, 5)
+	if err != nil {
+		t.Fatalf("GetVersionsPage() error = %v", err)
+	}
+	if totalPages != 2 {
+		t.Errorf("totalPages = %d, want 2", totalPages)
+	}
+	// Out-of-range pages are clamped to the last one, holding the first version
+	if len(versions) != 1 || versions[0].Version != 1 {
+		t.Errorf("last page = %+v, want only version 1", versions)
+	}
+}
+
+func TestTemplateService_RestoreVersion(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	template, challenge := setupLinkedChallenge(t, templateSvc, challengeSvc)
+	v1, _ := templateSvc.GetLatestVersion(template.ID)
+	original, _ := templateSvc.GetTasks(template.ID)
+
+	// Destructive edits: delete the first task, rename the template, add a task
+	if err := templateSvc.DeleteTask(original[0].ID, template.ID, 222); err != nil {
+		t.Fatalf("DeleteTask() error = %v", err)
+	}
+	templateSvc.UpdateName(template.ID, "Renamed", 222)
+	templateSvc.CreateTask(&domain.TemplateTask{TemplateID: template.ID, Title: "Task 3"}, 222)
+
+	restored, err := templateSvc.RestoreVersion(v1.ID, 333)
+	if err != nil {
+		t.Fatalf("RestoreVersion() error = %v", err)
+	}
+	if restored.Version != 5 || restored.EditorID != 333 {
+		t.Errorf("Restore recorded v%d by %d, want v5 by 333", restored.Version, restored.EditorID)
+	}
+
+	got, _ := templateSvc.GetByID(template.ID)
+	if got.Name != template.Name {
+		t.Errorf("Name = %q, want %q", got.Name, template.Name)
+	}
+
+	tasks, _ := templateSvc.GetTasks(template.ID)
+	if len(tasks) != len(original) {
+		t.Fatalf("Task count = %d, want %d", len(tasks), len(original))
+	}
+	for i, task := range tasks {
+		if task.ID != original[i].ID || task.Title != original[i].Title || task.OrderNum != original[i].OrderNum {
+			t.Errorf("Task[%d] = (%d, %q, %d), want (%d, %q, %d)", i,
+				task.ID, task.Title, task.OrderNum, original[i].ID, original[i].Title, original[i].OrderNum)
+		}
+	}
+
+	// Restored tasks keep their identity, so linked challenges still match them
+	diff, err := templateSvc.DiffChallenge(challenge.ID)
+	if err != nil {
+		t.Fatalf("DiffChallenge() error = %v", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("DiffChallenge() after restore should be empty, got %d added, %d changed", len(diff.Added), len(diff.Changed))
+	}
+
+	if _, err := templateSvc.RestoreVersion(99999, 333); !errors.Is(err, ErrTemplateVersionNotFound) {
+		t.Errorf("RestoreVersion() unknown version error = %v, want ErrTemplateVersionNotFound", err)
+	}
+}
+
+func TestTemplateService_RestoreVersion_NameTaken(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	source, _ := challengeSvc.Create("Original", "", 12345, 0, false)
+	template, _ := templateSvc.CreateFromChallenge(source.ID, 0)
+	v1, _ := templateSvc.GetLatestVersion(template.ID)
+	templateSvc.UpdateName(template.ID, "Renamed", 0)
+
+	// Another template takes the old name
+	other, _ := challengeSvc.Create("Original", "", 12345, 0, false)
+	if _, err := templateSvc.CreateFromChallenge(other.ID, 0); err != nil {
+		t.Fatalf("CreateFromChallenge() error = %v", err)
+	}
+
+	if _, err := templateSvc.RestoreVersion(v1.ID, 0); !errors.Is(err, ErrTemplateNameExists) {
+		t.Errorf("RestoreVersion() error = %v, want ErrTemplateNameExists", err)
+	}
+}
+
+func TestTemplateService_ApplyUpdate_RecordsVersion(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+
+	template, challenge := setupLinkedChallenge(t, templateSvc, challengeSvc)
+	if challenge.TemplateVersion != 1 {
+		t.Fatalf("TemplateVersion = %d, want 1", challenge.TemplateVersion)
+	}
+
+	templateSvc.CreateTask(&domain.TemplateTask{TemplateID: template.ID, Title: "Task 3"}, 0)
+
+	if _, err := templateSvc.ApplyUpdate(challenge.ID, 67890, false); err != nil {
+		t.Fatalf("ApplyUpdate() error = %v", err)
+	}
+
+	got, _ := challengeSvc.GetByID(challenge.ID)
+	if got.TemplateVersion != 2 {
+		t.Errorf("TemplateVersion after update = %d, want 2", got.TemplateVersion)
+	}
+}
+
+var errVersionFailed = errors.New("version failed")
+
+// failingVersionRepo fails every template version snapshot, also inside transactions
+type failingVersionRepo struct {
+	repository.Repository
+}
+
+func (r failingVersionRepo) TemplateVersion() repository.TemplateVersionRepository {
+	return failingVersions{r.Repository.TemplateVersion()}
+}
+
+func (r failingVersionRepo) WithTx(fn func(repo repository.Repository) error) error {
+	return r.Repository.WithTx(func(repo repository.Repository) error {
+		return fn(failingVersionRepo{repo})
+	})
+}
+
+type failingVersions struct {
+	repository.TemplateVersionRepository
+}
+
+func (failingVersions) Create(*domain.TemplateVersion, []*domain.TemplateVersionTask) error {
+	return errVersionFailed
+}
+
+func TestTemplateService_EditWithoutVersionRollsBack(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+
+	source, _ := challengeSvc.Create("Atomic", "", 12345, 0, false)
+	taskSvc.Create(source.ID, "Task 1", "", "", source.CreatorID)
+	template, err := NewTemplateService(repo).CreateFromChallenge(source.ID, 111)
+	if err != nil {
+		t.Fatalf("CreateFromChallenge() error = %v", err)
+	}
+	tasks, _ := repo.TemplateTask().GetByTemplateID(template.ID)
+
+	failing := NewTemplateService(failingVersionRepo{repo})
+	if err := failing.UpdateName(template.ID, "Renamed", 222); !errors.Is(err, errVersionFailed) {
+		t.Errorf("UpdateName() error = %v, want the snapshot error", err)
+	}
+	if err := failing.UpdateTaskTitle(tasks[0].ID, "Edited", 222); !errors.Is(err, errVersionFailed) {
+		t.Errorf("UpdateTaskTitle() error = %v, want the snapshot error", err)
+	}
+	if _, err := failing.SaveAsPersonal(source.ID, source.CreatorID, false); !errors.Is(err, errVersionFailed) {
+		t.Errorf("SaveAsPersonal() error = %v, want the snapshot error", err)
+	}
+
+	got, _ := repo.Template().GetByID(template.ID)
+	if got.Name != "Atomic" {
+		t.Errorf("Name = %q, want the edit rolled back", got.Name)
+	}
+	task, _ := repo.TemplateTask().GetByID(tasks[0].ID)
+	if task.Title != "Task 1" {
+		t.Errorf("Task title = %q, want the edit rolled back", task.Title)
+	}
+	entries, _ := repo.Audit().GetAll(10, 0)
+	for _, e := range entries {
+		if e.ActorID == 222 {
+			t.Errorf("Audit entry %q kept for a rolled back edit", e.Action)
+		}
+	}
+	owned, _ := repo.Template().GetByOwner(source.CreatorID)
+	if len(owned) != 0 {
+		t.Errorf("SaveAsPersonal() left %d templates behind", len(owned))
+	}
+}
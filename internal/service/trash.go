@@ -0,0 +1,140 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+	"github.com/rgeraskin/squad-challenge-bot/internal/repository"
+)
+
+var ErrTemplateTaskExists = errors.New("challenge already has a task from the same template task")
+
+// TrashService handles deleted tasks and challenges. They stay in the trash with their
+// completions for the retention window, can be restored until then and are purged after
+type TrashService struct {
+	repo      repository.Repository
+	retention time.Duration
+}
+
+// NewTrashService creates a new TrashService
+func NewTrashService(repo repository.Repository, retention time.Duration) *TrashService {
+	return &TrashService{repo: repo, retention: retention}
+}
+
+// RetentionDays returns how many days deleted items are kept
+func (s *TrashService) RetentionDays() int {
+	return int(s.retention / (24 * time.Hour))
+}
+
+// PurgeAt returns when an item deleted at deletedAt is purged
+func (s *TrashService) PurgeAt(deletedAt time.Time) time.Time {
+	return deletedAt.Add(s.retention)
+}
+
+// GetTasks returns the trashed tasks of a challenge, most recently deleted first
+func (s *TrashService) GetTasks(challengeID string) ([]*domain.Task, error) {
+	return s.repo.Task().GetDeletedByChallengeID(challengeID)
+}
+
+// RestoreTask brings a trashed task back to the end of its challenge's task list on behalf
+// of userID. Completions are kept while a task is in the trash, so progress comes back with it.
+// A task from a template is not restored when the challenge already has another task from the
+// same template task, so template updates keep matching one task per template task
+func (s *TrashService) RestoreTask(taskID int64, challengeID string, userID int64) (*domain.Task, error) {
+	var task *domain.Task
+	err := withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		var err error
+		task, err = repo.Task().GetDeletedByID(taskID)
+		if err != nil {
+			return nil, err
+		}
+		if task == nil || task.ChallengeID != challengeID {
+			return nil, ErrTaskNotFound
+		}
+		if task.TemplateTaskID != 0 {
+			tasks, err := repo.Task().GetByChallengeID(challengeID)
+			if err != nil {
+				return nil, err
+			}
+			for _, t := range tasks {
+				if t.TemplateTaskID == task.TemplateTaskID {
+					return nil, ErrTemplateTaskExists
+				}
+			}
+		}
+
+		count, err := repo.Task().CountByChallengeID(challengeID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= domain.MaxTasksPerChallenge {
+			return nil, ErrMaxTasksReached
+		}
+		maxOrder, err := repo.Task().GetMaxOrderNum(challengeID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := repo.Task().Restore(taskID, maxOrder+1); err != nil {
+			return nil, err
+		}
+		task.OrderNum = maxOrder + 1
+		task.DeletedAt = nil
+
+		entry, err := newTaskEntry(repo, task, userID, domain.AuditTaskRestore)
+		return []*domain.AuditEntry{entry}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// GetChallenges returns all trashed challenges, most recently deleted first
+func (s *TrashService) GetChallenges() ([]*domain.Challenge, error) {
+	return s.repo.Challenge().GetDeleted()
+}
+
+// RestoreChallenge brings a trashed challenge back with its tasks, participants and progress
+func (s *TrashService) RestoreChallenge(challengeID string, userID int64) (*domain.Challenge, error) {
+	var challenge *domain.Challenge
+	err := withAudit(s.repo, func(repo repository.Repository) ([]*domain.AuditEntry, error) {
+		var err error
+		challenge, err = repo.Challenge().GetDeletedByID(challengeID)
+		if err != nil {
+			return nil, err
+		}
+		if challenge == nil {
+			return nil, ErrChallengeNotFound
+		}
+
+		if err := repo.Challenge().Restore(challengeID); err != nil {
+			return nil, err
+		}
+		challenge.DeletedAt = nil
+
+		entry := newChallengeEntry(challenge, userID, domain.AuditChallengeRestore)
+		return []*domain.AuditEntry{entry}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// Purge removes tasks and challenges that have been in the trash longer than the retention
+// window and returns how many tasks and challenges were removed
+func (s *TrashService) Purge(now time.Time) (int, int, error) {
+	before := now.Add(-s.retention)
+
+	challenges, err := s.repo.Challenge().PurgeDeleted(before)
+	if err != nil {
+		return 0, 0, err
+	}
+	tasks, err := s.repo.Task().PurgeDeleted(before)
+	if err != nil {
+		return 0, challenges, err
+	}
+	return tasks, challenges, nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
+)
+
+const testRetention = 30 * 24 * time.Hour
+
+func TestTrashService_RestoreTaskKeepsProgress(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	participantSvc := NewParticipantService(repo)
+	completionSvc := NewCompletionService(repo)
+	trashSvc := NewTrashService(repo, testRetention)
+
+	adminID := int64(12345)
+	challenge, _ := challengeSvc.Create("Test", "", adminID, 0, false)
+	task1, _ := taskSvc.Create(challenge.ID, "Task 1", "", "", adminID)
+	taskSvc.Create(challenge.ID, "Task 2", "", "", adminID)
+	participant, _ := participantSvc.Join(challenge.ID, adminID, "Admin", "💪", 0)
+
+	if _, err := completionSvc.Complete(task1.ID, participant.ID); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if err := taskSvc.Delete(task1.ID, challenge.ID, adminID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	count, _ := completionSvc.CountByParticipantID(participant.ID)
+	if count != 0 {
+		t.Errorf("Completions of trashed task counted: %d, want 0", count)
+	}
+	trashed, _ := trashSvc.GetTasks(challenge.ID)
+	if len(trashed) != 1 || trashed[0].ID != task1.ID {
+		t.Fatalf("GetTasks() = %v, want Task 1", trashed)
+	}
+
+	restored, err := trashSvc.RestoreTask(task1.ID, challenge.ID, adminID)
+	if err != nil {
+		t.Fatalf("RestoreTask() error = %v", err)
+	}
+	if restored.OrderNum != 2 {
+		t.Errorf("Restored OrderNum = %d, want 2 (end of list)", restored.OrderNum)
+	}
+
+	count, _ = completionSvc.CountByParticipantID(participant.ID)
+	if count != 1 {
+		t.Errorf("Completions after restore = %d, want 1", count)
+	}
+	trashed, _ = trashSvc.GetTasks(challenge.ID)
+	if len(trashed) != 0 {
+		t.Errorf("Trash after restore has %d tasks, want 0", len(trashed))
+	}
+}
+
+func TestTrashService_RestoreTaskErrors(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	trashSvc := NewTrashService(repo, testRetention)
+
+	adminID := int64(12345)
+	challenge, _ := challengeSvc.Create("Test", "", adminID, 0, false)
+	other, _ := challengeSvc.Create("Other", "", adminID, 0, false)
+	task, _ := taskSvc.Create(challenge.ID, "Doomed", "", "", adminID)
+	taskSvc.Delete(task.ID, challenge.ID, adminID)
+
+	if _, err := trashSvc.RestoreTask(task.ID, other.ID, adminID); err != ErrTaskNotFound {
+		t.Errorf("RestoreTask() into another challenge error = %v, want ErrTaskNotFound", err)
+	}
+
+	for i := 0; i < domain.MaxTasksPerChallenge; i++ {
+		if _, err := taskSvc.Create(challenge.ID, "Task", "", "", adminID); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if _, err := trashSvc.RestoreTask(task.ID, challenge.ID, adminID); err != ErrMaxTasksReached {
+		t.Errorf("RestoreTask() into a full challenge error = %v, want ErrMaxTasksReached", err)
+	}
+}
+
+func TestTrashService_Purge(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	taskSvc := NewTaskService(repo)
+	trashSvc := NewTrashService(repo, testRetention)
+
+	adminID := int64(12345)
+	kept, _ := challengeSvc.Create("Kept", "", adminID, 0, false)
+	task, _ := taskSvc.Create(kept.ID, "Doomed task", "", "", adminID)
+	doomed, _ := challengeSvc.Create("Doomed", "", adminID, 0, false)
+	taskSvc.Delete(task.ID, kept.ID, adminID)
+	challengeSvc.Delete(doomed.ID, adminID, false)
+
+	// Still within the retention window
+	tasks, challenges, err := trashSvc.Purge(time.Now().Add(testRetention - time.Hour))
+	if err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if tasks != 0 || challenges != 0 {
+		t.Errorf("Purge() within retention = %d tasks, %d challenges, want 0, 0", tasks, challenges)
+	}
+
+	tasks, challenges, err = trashSvc.Purge(time.Now().Add(testRetention + time.Hour))
+	if err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if tasks != 1 || challenges != 1 {
+		t.Errorf("Purge() after retention = %d tasks, %d challenges, want 1, 1", tasks, challenges)
+	}
+
+	if _, err := trashSvc.RestoreChallenge(doomed.ID, adminID); err != ErrChallengeNotFound {
+		t.Errorf("RestoreChallenge() after purge error = %v, want ErrChallengeNotFound", err)
+	}
+	if _, err := challengeSvc.GetByID(kept.ID); err != nil {
+		t.Errorf("Untrashed challenge purged: %v", err)
+	}
+}
+
+func TestTrashService_TrashedTemplateTasks(t *testing.T) {
+	repo := setupTestRepo(t)
+	challengeSvc := NewChallengeService(repo)
+	templateSvc := NewTemplateService(repo)
+	taskSvc := NewTaskService(repo)
+	trashSvc := NewTrashService(repo, testRetention)
+
+	_, challenge := setupLinkedChallenge(t, templateSvc, challengeSvc)
+	// Created before templates had versions, so only the trash tells deleted tasks apart
+	repo.Challenge().UpdateTemplateVersion(challenge.ID, 0)
+
+	tasks, _ := repo.Task().GetByChallengeID(challenge.ID)
+	trashed := tasks[0]
+	taskSvc.Delete(trashed.ID, challenge.ID, 67890)
+
+	diff, err := templateSvc.DiffChallenge(challenge.ID)
+	if err != nil {
+		t.Fatalf("DiffChallenge() error = %v", err)
+	}
+	if len(diff.Added) != 0 {
+		t.Errorf("Added = %+v, want trashed task not to count as new", diff.Added)
+	}
+	if _, err := trashSvc.RestoreTask(trashed.ID, challenge.ID, 67890); err != nil {
+		t.Fatalf("RestoreTask() error = %v", err)
+	}
+
+	// A live task from the same template task blocks the restore
+	taskSvc.Delete(trashed.ID, challenge.ID, 67890)
+	duplicate := &domain.Task{ChallengeID: challenge.ID, OrderNum: 3, Title: trashed.Title, TemplateTaskID: trashed.TemplateTaskID}
+	if err := repo.Task().Create(duplicate); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := trashSvc.RestoreTask(trashed.ID, challenge.ID, 67890); err != ErrTemplateTaskExists {
+		t.Errorf("RestoreTask() with a live copy error = %v, want ErrTemplateTaskExists", err)
+	}
+}
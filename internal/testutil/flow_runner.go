@@ -68,7 +68,7 @@ func (f *FlowRunner) CreateChallengeWithTasks(creatorID int64, challengeName str
 
 	// Create tasks
 	for i := 0; i < numTasks; i++ {
-		_, err := f.Task.Create(challenge.ID, "Task "+string(rune('A'+i)), "", "")
+		_, err := f.Task.Create(challenge.ID, "Task "+string(rune('A'+i)), "", "", creatorID)
 		if err != nil {
 			f.T.Fatalf("Failed to create task: %v", err)
 		}
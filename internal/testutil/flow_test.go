@@ -2,6 +2,7 @@ package testutil
 
 import (
 	"testing"
+	"time"
 
 	"github.com/rgeraskin/squad-challenge-bot/internal/domain"
 	"github.com/rgeraskin/squad-challenge-bot/internal/service"
@@ -30,9 +31,9 @@ func TestFlow_CreateChallengeAndAddTasks(t *testing.T) {
 	}
 
 	// Step 3: Add tasks
-	task1, _ := f.Task.Create(challenge.ID, "Morning Stretch", "Stretch for 10 minutes", "")
-	task2, _ := f.Task.Create(challenge.ID, "50 Squats", "", "")
-	task3, _ := f.Task.Create(challenge.ID, "100 Push-ups", "", "")
+	task1, _ := f.Task.Create(challenge.ID, "Morning Stretch", "Stretch for 10 minutes", "", creatorID)
+	task2, _ := f.Task.Create(challenge.ID, "50 Squats", "", "", creatorID)
+	task3, _ := f.Task.Create(challenge.ID, "100 Push-ups", "", "", creatorID)
 
 	// Verify task order
 	tasks, _ := f.Task.GetByChallengeID(challenge.ID)
@@ -297,16 +298,23 @@ func TestFlow_DeleteChallenge(t *testing.T) {
 		t.Errorf("GetByID after delete: error = %v, want ErrChallengeNotFound", err)
 	}
 
-	// Verify tasks cascaded
+	// Tasks and participants stay in the trash with the challenge
 	tasks, _ := f.Task.GetByChallengeID(challengeID)
-	if len(tasks) != 0 {
-		t.Error("Tasks should be deleted with challenge")
+	if len(tasks) != 5 {
+		t.Errorf("Tasks in trash = %d, want 5", len(tasks))
 	}
-
-	// Verify participants cascaded
 	count, _ := f.Participant.CountByChallengeID(challengeID)
-	if count != 0 {
-		t.Error("Participants should be deleted with challenge")
+	if count != 2 {
+		t.Errorf("Participants in trash = %d, want 2", count)
+	}
+
+	// Restore brings it back
+	trash := service.NewTrashService(f.Repo, 30*24*time.Hour)
+	if _, err := trash.RestoreChallenge(challengeID, creatorID); err != nil {
+		t.Fatalf("RestoreChallenge failed: %v", err)
+	}
+	if _, err := f.Challenge.GetByID(challengeID); err != nil {
+		t.Errorf("GetByID after restore: error = %v", err)
 	}
 }
 
@@ -320,7 +328,7 @@ func TestFlow_ReorderTasks(t *testing.T) {
 	task5ID := tasks[4].ID // Task at position 5
 
 	// Move task 5 to position 1
-	err := f.Task.MoveTask(task5ID, challengeID, 1)
+	err := f.Task.MoveTask(task5ID, challengeID, 1, userID)
 	if err != nil {
 		t.Fatalf("MoveTask failed: %v", err)
 	}